@@ -20,12 +20,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ChangeKind int32
+
+const (
+	ChangeKind_SAVED    ChangeKind = 0 //данные пользователя сохранены другой сессией
+	ChangeKind_LOCKED   ChangeKind = 1 //данные заблокированы на изменение другой сессией
+	ChangeKind_UNLOCKED ChangeKind = 2 //блокировка данных другой сессией снята
+)
+
+// Enum value maps for ChangeKind.
+var (
+	ChangeKind_name = map[int32]string{
+		0: "SAVED",
+		1: "LOCKED",
+		2: "UNLOCKED",
+	}
+	ChangeKind_value = map[string]int32{
+		"SAVED":    0,
+		"LOCKED":   1,
+		"UNLOCKED": 2,
+	}
+)
+
+func (x ChangeKind) Enum() *ChangeKind {
+	p := new(ChangeKind)
+	*p = x
+	return p
+}
+
+func (x ChangeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_grpc_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeKind) Type() protoreflect.EnumType {
+	return &file_proto_grpc_proto_enumTypes[0]
+}
+
+func (x ChangeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeKind.Descriptor instead.
+func (ChangeKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{0}
+}
+
 type NewSessionIDRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserPublicKeyBZ []byte `protobuf:"bytes,1,opt,name=userPublicKeyBZ,proto3" json:"userPublicKeyBZ,omitempty"` //открытый ключ для шифрования данных
+	UserPublicKeyBZ []byte `protobuf:"bytes,1,opt,name=userPublicKeyBZ,proto3" json:"userPublicKeyBZ,omitempty"` //эфемерный открытый ключ X25519 клиента
+	ClientVersion   string `protobuf:"bytes,2,opt,name=clientVersion,proto3" json:"clientVersion,omitempty"`     //версия приложения клиента
+	DeviceName      string `protobuf:"bytes,3,opt,name=deviceName,proto3" json:"deviceName,omitempty"`           //имя устройства клиента
 }
 
 func (x *NewSessionIDRequest) Reset() {
@@ -67,13 +118,27 @@ func (x *NewSessionIDRequest) GetUserPublicKeyBZ() []byte {
 	return nil
 }
 
+func (x *NewSessionIDRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *NewSessionIDRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
 type NewSessionIDResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	SessionID   string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`     //новый SessionID
-	PublicKeyBZ []byte `protobuf:"bytes,2,opt,name=publicKeyBZ,proto3" json:"publicKeyBZ,omitempty"` //открытый ключ для шифрования данных
+	PublicKeyBZ []byte `protobuf:"bytes,2,opt,name=publicKeyBZ,proto3" json:"publicKeyBZ,omitempty"` //эфемерный открытый ключ X25519 сервера
 }
 
 func (x *NewSessionIDResponce) Reset() {
@@ -122,20 +187,226 @@ func (x *NewSessionIDResponce) GetPublicKeyBZ() []byte {
 	return nil
 }
 
-type NewUserRequest struct {
+type MasterKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Salt       []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`             //соль для вычисления мастер-ключа из пароля пользователя
+	Time       uint32 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`            //количество проходов argon2id
+	Memory     uint32 `protobuf:"varint,3,opt,name=memory,proto3" json:"memory,omitempty"`        //объем памяти argon2id, в килобайтах
+	Threads    uint32 `protobuf:"varint,4,opt,name=threads,proto3" json:"threads,omitempty"`      //количество потоков argon2id
+	WrappedKey []byte `protobuf:"bytes,5,opt,name=wrappedKey,proto3" json:"wrappedKey,omitempty"` //ключ шифрования данных, зашифрованный мастер-ключом пользователя
+}
+
+func (x *MasterKey) Reset() {
+	*x = MasterKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MasterKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MasterKey) ProtoMessage() {}
+
+func (x *MasterKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MasterKey.ProtoReflect.Descriptor instead.
+func (*MasterKey) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MasterKey) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *MasterKey) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MasterKey) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *MasterKey) GetThreads() uint32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *MasterKey) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type KeyParamsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
-	NewUser   []byte `protobuf:"bytes,2,opt,name=newUser,proto3" json:"newUser,omitempty"`     //зашифрованные логин и пароль нового пользователя
+	Login     []byte `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`         //зашифрованный логин пользователя
 	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
 }
 
+func (x *KeyParamsRequest) Reset() {
+	*x = KeyParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyParamsRequest) ProtoMessage() {}
+
+func (x *KeyParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyParamsRequest.ProtoReflect.Descriptor instead.
+func (*KeyParamsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KeyParamsRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *KeyParamsRequest) GetLogin() []byte {
+	if x != nil {
+		return x.Login
+	}
+	return nil
+}
+
+func (x *KeyParamsRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type KeyParamsResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MasterKey *MasterKey `protobuf:"bytes,1,opt,name=masterKey,proto3" json:"masterKey,omitempty"` //параметры вычисления мастер-ключа пользователя без ключа шифрования данных
+	Legacy    bool       `protobuf:"varint,2,opt,name=legacy,proto3" json:"legacy,omitempty"`      //отметка о ключе шифрования данных, созданном сервером; true - клиент передает пароль без преобразования
+	Sign      []byte     `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
+}
+
+func (x *KeyParamsResponce) Reset() {
+	*x = KeyParamsResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyParamsResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyParamsResponce) ProtoMessage() {}
+
+func (x *KeyParamsResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyParamsResponce.ProtoReflect.Descriptor instead.
+func (*KeyParamsResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *KeyParamsResponce) GetMasterKey() *MasterKey {
+	if x != nil {
+		return x.MasterKey
+	}
+	return nil
+}
+
+func (x *KeyParamsResponce) GetLegacy() bool {
+	if x != nil {
+		return x.Legacy
+	}
+	return false
+}
+
+func (x *KeyParamsResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type NewUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string     `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	NewUser   []byte     `protobuf:"bytes,2,opt,name=newUser,proto3" json:"newUser,omitempty"`     //зашифрованные логин и ключ аутентификации нового пользователя
+	UserSign  []byte     `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	MasterKey *MasterKey `protobuf:"bytes,4,opt,name=masterKey,proto3" json:"masterKey,omitempty"` //параметры мастер-ключа и зашифрованный им ключ шифрования данных
+}
+
 func (x *NewUserRequest) Reset() {
 	*x = NewUserRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[2]
+		mi := &file_proto_grpc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -148,7 +419,7 @@ func (x *NewUserRequest) String() string {
 func (*NewUserRequest) ProtoMessage() {}
 
 func (x *NewUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[2]
+	mi := &file_proto_grpc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +432,7 @@ func (x *NewUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewUserRequest.ProtoReflect.Descriptor instead.
 func (*NewUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{2}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewUserRequest) GetSessionID() string {
@@ -185,13 +456,20 @@ func (x *NewUserRequest) GetUserSign() []byte {
 	return nil
 }
 
+func (x *NewUserRequest) GetMasterKey() *MasterKey {
+	if x != nil {
+		return x.MasterKey
+	}
+	return nil
+}
+
 type NewUserResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	UserID    []byte `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`       //зашифрованный userID пользователя
-	SymKey    []byte `protobuf:"bytes,2,opt,name=symKey,proto3" json:"symKey,omitempty"`       //зашифрованный симметричный ключ пользователя
+	SymKey    []byte `protobuf:"bytes,2,opt,name=symKey,proto3" json:"symKey,omitempty"`       //не используется, ключ шифрования данных создается клиентом
 	TimeStamp string `protobuf:"bytes,3,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени последнего сохранения данных пользователя
 	Sign      []byte `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
 }
@@ -199,7 +477,7 @@ type NewUserResponce struct {
 func (x *NewUserResponce) Reset() {
 	*x = NewUserResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[3]
+		mi := &file_proto_grpc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -212,7 +490,7 @@ func (x *NewUserResponce) String() string {
 func (*NewUserResponce) ProtoMessage() {}
 
 func (x *NewUserResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[3]
+	mi := &file_proto_grpc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,7 +503,7 @@ func (x *NewUserResponce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewUserResponce.ProtoReflect.Descriptor instead.
 func (*NewUserResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{3}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NewUserResponce) GetUserID() []byte {
@@ -264,12 +542,13 @@ type LoginUserRequest struct {
 	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
 	LoginUser []byte `protobuf:"bytes,2,opt,name=loginUser,proto3" json:"loginUser,omitempty"` //зашифрованные логин и пароль пользователя
 	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	TotpCode  []byte `protobuf:"bytes,4,opt,name=totpCode,proto3" json:"totpCode,omitempty"`   //зашифрованный код TOTP или код восстановления, если у пользователя включена двухфакторная аутентификация
 }
 
 func (x *LoginUserRequest) Reset() {
 	*x = LoginUserRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[4]
+		mi := &file_proto_grpc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -282,7 +561,7 @@ func (x *LoginUserRequest) String() string {
 func (*LoginUserRequest) ProtoMessage() {}
 
 func (x *LoginUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[4]
+	mi := &file_proto_grpc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +574,7 @@ func (x *LoginUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginUserRequest.ProtoReflect.Descriptor instead.
 func (*LoginUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{4}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LoginUserRequest) GetSessionID() string {
@@ -319,19 +598,28 @@ func (x *LoginUserRequest) GetUserSign() []byte {
 	return nil
 }
 
+func (x *LoginUserRequest) GetTotpCode() []byte {
+	if x != nil {
+		return x.TotpCode
+	}
+	return nil
+}
+
 type LoginUserResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserID []byte `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"` //зашифрованный userID пользователя
-	Sign   []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`     //Подпись данных сервером
+	UserID    []byte     `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`       //зашифрованный userID пользователя
+	Sign      []byte     `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
+	MasterKey *MasterKey `protobuf:"bytes,4,opt,name=masterKey,proto3" json:"masterKey,omitempty"` //параметры мастер-ключа и зашифрованный им ключ шифрования данных
+	SymKey    []byte     `protobuf:"bytes,5,opt,name=symKey,proto3" json:"symKey,omitempty"`       //зашифрованный ключ шифрования данных, созданный сервером, передается только до перехода на ключ клиента
 }
 
 func (x *LoginUserResponce) Reset() {
 	*x = LoginUserResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[5]
+		mi := &file_proto_grpc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -344,7 +632,7 @@ func (x *LoginUserResponce) String() string {
 func (*LoginUserResponce) ProtoMessage() {}
 
 func (x *LoginUserResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[5]
+	mi := &file_proto_grpc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,7 +645,7 @@ func (x *LoginUserResponce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginUserResponce.ProtoReflect.Descriptor instead.
 func (*LoginUserResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{5}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LoginUserResponce) GetUserID() []byte {
@@ -374,6 +662,20 @@ func (x *LoginUserResponce) GetSign() []byte {
 	return nil
 }
 
+func (x *LoginUserResponce) GetMasterKey() *MasterKey {
+	if x != nil {
+		return x.MasterKey
+	}
+	return nil
+}
+
+func (x *LoginUserResponce) GetSymKey() []byte {
+	if x != nil {
+		return x.SymKey
+	}
+	return nil
+}
+
 type UserDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -386,7 +688,7 @@ type UserDataRequest struct {
 func (x *UserDataRequest) Reset() {
 	*x = UserDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[6]
+		mi := &file_proto_grpc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -399,7 +701,7 @@ func (x *UserDataRequest) String() string {
 func (*UserDataRequest) ProtoMessage() {}
 
 func (x *UserDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[6]
+	mi := &file_proto_grpc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +714,7 @@ func (x *UserDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserDataRequest.ProtoReflect.Descriptor instead.
 func (*UserDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{6}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UserDataRequest) GetSessionID() string {
@@ -436,16 +738,17 @@ type UserDataResponce struct {
 
 	UserData   []byte `protobuf:"bytes,1,opt,name=userData,proto3" json:"userData,omitempty"`     //зашифрованные данные пользователя
 	TimeStamp  string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`   //отметка времени последнего сохранения данных пользователя
-	SymKey     []byte `protobuf:"bytes,3,opt,name=symKey,proto3" json:"symKey,omitempty"`         //зашифрованный симметричный ключ пользователя
+	SymKey     []byte `protobuf:"bytes,3,opt,name=symKey,proto3" json:"symKey,omitempty"`         //не используется, ключ шифрования данных передается при авторизации
 	Locked     bool   `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о блокировке данных на изменение; true - заблокировано, false - свободно
 	TimeLocked string `protobuf:"bytes,5,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени данные на редактирование заблокированы пользователем
 	Sign       []byte `protobuf:"bytes,6,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
+	Version    int64  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`      //версия сохраненных данных пользователя
 }
 
 func (x *UserDataResponce) Reset() {
 	*x = UserDataResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[7]
+		mi := &file_proto_grpc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -458,7 +761,7 @@ func (x *UserDataResponce) String() string {
 func (*UserDataResponce) ProtoMessage() {}
 
 func (x *UserDataResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[7]
+	mi := &file_proto_grpc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +774,7 @@ func (x *UserDataResponce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserDataResponce.ProtoReflect.Descriptor instead.
 func (*UserDataResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{7}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UserDataResponce) GetUserData() []byte {
@@ -516,32 +819,41 @@ func (x *UserDataResponce) GetSign() []byte {
 	return nil
 }
 
-type TimeStampRequest struct {
+func (x *UserDataResponce) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type LockOwner struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
-	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	DeviceName    string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`       //имя устройства сессии, установившей блокировку
+	ClientVersion string `protobuf:"bytes,2,opt,name=clientVersion,proto3" json:"clientVersion,omitempty"` //версия приложения клиента сессии, установившей блокировку
+	TimeAcquired  string `protobuf:"bytes,3,opt,name=timeAcquired,proto3" json:"timeAcquired,omitempty"`   //время установки блокировки
+	Current       bool   `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`            //true - блокировка установлена сессией, из которой отправлен запрос
 }
 
-func (x *TimeStampRequest) Reset() {
-	*x = TimeStampRequest{}
+func (x *LockOwner) Reset() {
+	*x = LockOwner{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[8]
+		mi := &file_proto_grpc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TimeStampRequest) String() string {
+func (x *LockOwner) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimeStampRequest) ProtoMessage() {}
+func (*LockOwner) ProtoMessage() {}
 
-func (x *TimeStampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[8]
+func (x *LockOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,9 +864,78 @@ func (x *TimeStampRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimeStampRequest.ProtoReflect.Descriptor instead.
+// Deprecated: Use LockOwner.ProtoReflect.Descriptor instead.
+func (*LockOwner) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LockOwner) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *LockOwner) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *LockOwner) GetTimeAcquired() string {
+	if x != nil {
+		return x.TimeAcquired
+	}
+	return ""
+}
+
+func (x *LockOwner) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type TimeStampRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *TimeStampRequest) Reset() {
+	*x = TimeStampRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeStampRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeStampRequest) ProtoMessage() {}
+
+func (x *TimeStampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeStampRequest.ProtoReflect.Descriptor instead.
 func (*TimeStampRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{8}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TimeStampRequest) GetSessionID() string {
@@ -576,16 +957,18 @@ type TimeStampResponce struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TimeStamp  string `protobuf:"bytes,1,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`   //отметка времени последнего сохранения данных пользователя
-	Locked     bool   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о блокировке данных на изменение; true - заблокировано, false - свободно
-	TimeLocked string `protobuf:"bytes,3,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени данные на редактирование заблокированы пользователем
-	Sign       []byte `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
+	TimeStamp  string     `protobuf:"bytes,1,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`   //отметка времени последнего сохранения данных пользователя
+	Locked     bool       `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о блокировке данных на изменение; true - заблокировано, false - свободно
+	TimeLocked string     `protobuf:"bytes,3,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени данные на редактирование заблокированы пользователем
+	Sign       []byte     `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
+	Version    int64      `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`      //версия сохраненных данных пользователя
+	Owner      *LockOwner `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`           //сессия, установившая блокировку
 }
 
 func (x *TimeStampResponce) Reset() {
 	*x = TimeStampResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[9]
+		mi := &file_proto_grpc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -598,7 +981,7 @@ func (x *TimeStampResponce) String() string {
 func (*TimeStampResponce) ProtoMessage() {}
 
 func (x *TimeStampResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[9]
+	mi := &file_proto_grpc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +994,7 @@ func (x *TimeStampResponce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeStampResponce.ProtoReflect.Descriptor instead.
 func (*TimeStampResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{9}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TimeStampResponce) GetTimeStamp() string {
@@ -642,6 +1025,20 @@ func (x *TimeStampResponce) GetSign() []byte {
 	return nil
 }
 
+func (x *TimeStampResponce) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TimeStampResponce) GetOwner() *LockOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
 type DataLockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -649,12 +1046,13 @@ type DataLockRequest struct {
 
 	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
 	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	TakeOver  bool   `protobuf:"varint,3,opt,name=takeOver,proto3" json:"takeOver,omitempty"`  //true - снять блокировку другой сессии пользователя и заблокировать данные текущей сессией
 }
 
 func (x *DataLockRequest) Reset() {
 	*x = DataLockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[10]
+		mi := &file_proto_grpc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -667,7 +1065,7 @@ func (x *DataLockRequest) String() string {
 func (*DataLockRequest) ProtoMessage() {}
 
 func (x *DataLockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[10]
+	mi := &file_proto_grpc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,7 +1078,7 @@ func (x *DataLockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataLockRequest.ProtoReflect.Descriptor instead.
 func (*DataLockRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{10}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DataLockRequest) GetSessionID() string {
@@ -697,20 +1095,28 @@ func (x *DataLockRequest) GetUserSign() []byte {
 	return nil
 }
 
+func (x *DataLockRequest) GetTakeOver() bool {
+	if x != nil {
+		return x.TakeOver
+	}
+	return false
+}
+
 type DataLockResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Locked     bool   `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о блокировке данных на изменение
-	TimeLocked string `protobuf:"bytes,2,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени данные на редактирование заблокированы пользователем
-	Sign       []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
+	Locked     bool       `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о блокировке данных на изменение
+	TimeLocked string     `protobuf:"bytes,2,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени данные на редактирование заблокированы пользователем
+	Sign       []byte     `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
+	Owner      *LockOwner `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`           //сессия, установившая блокировку
 }
 
 func (x *DataLockResponce) Reset() {
 	*x = DataLockResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[11]
+		mi := &file_proto_grpc_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -723,7 +1129,7 @@ func (x *DataLockResponce) String() string {
 func (*DataLockResponce) ProtoMessage() {}
 
 func (x *DataLockResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[11]
+	mi := &file_proto_grpc_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,7 +1142,7 @@ func (x *DataLockResponce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataLockResponce.ProtoReflect.Descriptor instead.
 func (*DataLockResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{11}
+	return file_proto_grpc_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DataLockResponce) GetLocked() bool {
@@ -760,34 +1166,39 @@ func (x *DataLockResponce) GetSign() []byte {
 	return nil
 }
 
-type UpdateDataRequest struct {
+func (x *DataLockResponce) GetOwner() *LockOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+type ReleaseLockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
-	TimeStamp string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени последнего сохранения данных пользователя
-	UserData  []byte `protobuf:"bytes,3,opt,name=userData,proto3" json:"userData,omitempty"`   //зашифрованные данные пользователя
-	UserSign  []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
 }
 
-func (x *UpdateDataRequest) Reset() {
-	*x = UpdateDataRequest{}
+func (x *ReleaseLockRequest) Reset() {
+	*x = ReleaseLockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[12]
+		mi := &file_proto_grpc_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateDataRequest) String() string {
+func (x *ReleaseLockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDataRequest) ProtoMessage() {}
+func (*ReleaseLockRequest) ProtoMessage() {}
 
-func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[12]
+func (x *ReleaseLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,66 +1209,51 @@ func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ReleaseLockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseLockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *UpdateDataRequest) GetSessionID() string {
+func (x *ReleaseLockRequest) GetSessionID() string {
 	if x != nil {
 		return x.SessionID
 	}
 	return ""
 }
 
-func (x *UpdateDataRequest) GetTimeStamp() string {
-	if x != nil {
-		return x.TimeStamp
-	}
-	return ""
-}
-
-func (x *UpdateDataRequest) GetUserData() []byte {
-	if x != nil {
-		return x.UserData
-	}
-	return nil
-}
-
-func (x *UpdateDataRequest) GetUserSign() []byte {
+func (x *ReleaseLockRequest) GetUserSign() []byte {
 	if x != nil {
 		return x.UserSign
 	}
 	return nil
 }
 
-type UpdateDataResponce struct {
+type ReleaseLockResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`      //результат true - сохранено, false - ошибка, уточнение в error
-	TimeStamp string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени последнего сохранения данных пользователя
-	Sign      []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //отметка о снятии блокировки
+	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
 }
 
-func (x *UpdateDataResponce) Reset() {
-	*x = UpdateDataResponce{}
+func (x *ReleaseLockResponce) Reset() {
+	*x = ReleaseLockResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[13]
+		mi := &file_proto_grpc_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateDataResponce) String() string {
+func (x *ReleaseLockResponce) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDataResponce) ProtoMessage() {}
+func (*ReleaseLockResponce) ProtoMessage() {}
 
-func (x *UpdateDataResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[13]
+func (x *ReleaseLockResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,33 +1264,26 @@ func (x *UpdateDataResponce) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDataResponce.ProtoReflect.Descriptor instead.
-func (*UpdateDataResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ReleaseLockResponce.ProtoReflect.Descriptor instead.
+func (*ReleaseLockResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateDataResponce) GetStatus() bool {
+func (x *ReleaseLockResponce) GetStatus() bool {
 	if x != nil {
 		return x.Status
 	}
 	return false
 }
 
-func (x *UpdateDataResponce) GetTimeStamp() string {
-	if x != nil {
-		return x.TimeStamp
-	}
-	return ""
-}
-
-func (x *UpdateDataResponce) GetSign() []byte {
+func (x *ReleaseLockResponce) GetSign() []byte {
 	if x != nil {
 		return x.Sign
 	}
 	return nil
 }
 
-type LogOutRequest struct {
+type RenewLockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -903,23 +1292,23 @@ type LogOutRequest struct {
 	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
 }
 
-func (x *LogOutRequest) Reset() {
-	*x = LogOutRequest{}
+func (x *RenewLockRequest) Reset() {
+	*x = RenewLockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[14]
+		mi := &file_proto_grpc_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LogOutRequest) String() string {
+func (x *RenewLockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogOutRequest) ProtoMessage() {}
+func (*RenewLockRequest) ProtoMessage() {}
 
-func (x *LogOutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[14]
+func (x *RenewLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,50 +1319,52 @@ func (x *LogOutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogOutRequest.ProtoReflect.Descriptor instead.
-func (*LogOutRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use RenewLockRequest.ProtoReflect.Descriptor instead.
+func (*RenewLockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LogOutRequest) GetSessionID() string {
+func (x *RenewLockRequest) GetSessionID() string {
 	if x != nil {
 		return x.SessionID
 	}
 	return ""
 }
 
-func (x *LogOutRequest) GetUserSign() []byte {
+func (x *RenewLockRequest) GetUserSign() []byte {
 	if x != nil {
 		return x.UserSign
 	}
 	return nil
 }
 
-type LogOutResponce struct {
+type RenewLockResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status bool `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - пользователь разлогинен, false - ошибка, уточнение в error
+	Locked     bool   `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`        //отметка о продлении блокировки данных на изменение
+	TimeLocked string `protobuf:"bytes,2,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"` //отметка до какого времени продлена блокировка данных
+	Sign       []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`             //Подпись данных сервером
 }
 
-func (x *LogOutResponce) Reset() {
-	*x = LogOutResponce{}
+func (x *RenewLockResponce) Reset() {
+	*x = RenewLockResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[15]
+		mi := &file_proto_grpc_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LogOutResponce) String() string {
+func (x *RenewLockResponce) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogOutResponce) ProtoMessage() {}
+func (*RenewLockResponce) ProtoMessage() {}
 
-func (x *LogOutResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[15]
+func (x *RenewLockResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,46 +1375,61 @@ func (x *LogOutResponce) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogOutResponce.ProtoReflect.Descriptor instead.
-func (*LogOutResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RenewLockResponce.ProtoReflect.Descriptor instead.
+func (*RenewLockResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *LogOutResponce) GetStatus() bool {
+func (x *RenewLockResponce) GetLocked() bool {
 	if x != nil {
-		return x.Status
+		return x.Locked
 	}
 	return false
 }
 
-type ChangePasswordRequest struct {
+func (x *RenewLockResponce) GetTimeLocked() string {
+	if x != nil {
+		return x.TimeLocked
+	}
+	return ""
+}
+
+func (x *RenewLockResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type UpdateDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionID   string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`     //SessionID пользователя
-	OldPassword []byte `protobuf:"bytes,2,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"` //зашифрованные текущий пароль пользователя
-	NewPassword []byte `protobuf:"bytes,3,opt,name=newPassword,proto3" json:"newPassword,omitempty"` //зашифрованные новый пароль пользователя
-	UserSign    []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`       //Подпись данных пользователем
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	TimeStamp string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени последнего сохранения данных пользователя, не используется для проверки актуальности
+	UserData  []byte `protobuf:"bytes,3,opt,name=userData,proto3" json:"userData,omitempty"`   //зашифрованные данные пользователя
+	UserSign  []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+	Version   int64  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`    //версия данных пользователя, на основе которой выполнены изменения
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
+func (x *UpdateDataRequest) Reset() {
+	*x = UpdateDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[16]
+		mi := &file_proto_grpc_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangePasswordRequest) String() string {
+func (x *UpdateDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+func (*UpdateDataRequest) ProtoMessage() {}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[16]
+func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,315 +1440,3726 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use UpdateDataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ChangePasswordRequest) GetSessionID() string {
+func (x *UpdateDataRequest) GetSessionID() string {
 	if x != nil {
 		return x.SessionID
 	}
 	return ""
 }
 
-func (x *ChangePasswordRequest) GetOldPassword() []byte {
+func (x *UpdateDataRequest) GetTimeStamp() string {
 	if x != nil {
-		return x.OldPassword
+		return x.TimeStamp
 	}
-	return nil
+	return ""
 }
 
-func (x *ChangePasswordRequest) GetNewPassword() []byte {
+func (x *UpdateDataRequest) GetUserData() []byte {
 	if x != nil {
-		return x.NewPassword
+		return x.UserData
 	}
 	return nil
 }
 
-func (x *ChangePasswordRequest) GetUserSign() []byte {
+func (x *UpdateDataRequest) GetUserSign() []byte {
 	if x != nil {
 		return x.UserSign
 	}
 	return nil
 }
 
-type ChangePasswordResponce struct {
+func (x *UpdateDataRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateDataResponce struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - пароль изменен, false - ошибка, уточнение в error
-	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
+	Status    bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`      //результат true - сохранено, false - ошибка, уточнение в error
+	TimeStamp string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени последнего сохранения данных пользователя
+	Sign      []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
+	Version   int64  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`    //новая версия сохраненных данных пользователя
 }
 
-func (x *ChangePasswordResponce) Reset() {
-	*x = ChangePasswordResponce{}
+func (x *UpdateDataResponce) Reset() {
+	*x = UpdateDataResponce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grpc_proto_msgTypes[17]
+		mi := &file_proto_grpc_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangePasswordResponce) String() string {
+func (x *UpdateDataResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDataResponce) ProtoMessage() {}
+
+func (x *UpdateDataResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDataResponce.ProtoReflect.Descriptor instead.
+func (*UpdateDataResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateDataResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *UpdateDataResponce) GetTimeStamp() string {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return ""
+}
+
+func (x *UpdateDataResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *UpdateDataResponce) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type LogOutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *LogOutRequest) Reset() {
+	*x = LogOutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogOutRequest) ProtoMessage() {}
+
+func (x *LogOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogOutRequest.ProtoReflect.Descriptor instead.
+func (*LogOutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LogOutRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *LogOutRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type LogOutResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - пользователь разлогинен, false - ошибка, уточнение в error
+}
+
+func (x *LogOutResponce) Reset() {
+	*x = LogOutResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogOutResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogOutResponce) ProtoMessage() {}
+
+func (x *LogOutResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogOutResponce.ProtoReflect.Descriptor instead.
+func (*LogOutResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LogOutResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID   string     `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`     //SessionID пользователя
+	OldPassword []byte     `protobuf:"bytes,2,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"` //зашифрованный текущий ключ аутентификации пользователя
+	NewPassword []byte     `protobuf:"bytes,3,opt,name=newPassword,proto3" json:"newPassword,omitempty"` //зашифрованный новый ключ аутентификации пользователя
+	UserSign    []byte     `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`       //Подпись данных пользователем
+	MasterKey   *MasterKey `protobuf:"bytes,5,opt,name=masterKey,proto3" json:"masterKey,omitempty"`     //параметры нового мастер-ключа и зашифрованный им ключ шифрования данных
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChangePasswordRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() []byte {
+	if x != nil {
+		return x.OldPassword
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() []byte {
+	if x != nil {
+		return x.NewPassword
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetMasterKey() *MasterKey {
+	if x != nil {
+		return x.MasterKey
+	}
+	return nil
+}
+
+type ChangePasswordResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - пароль изменен, false - ошибка, уточнение в error
+	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
+}
+
+func (x *ChangePasswordResponce) Reset() {
+	*x = ChangePasswordResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangePasswordResponce) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*ChangePasswordResponce) ProtoMessage() {}
 
 func (x *ChangePasswordResponce) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grpc_proto_msgTypes[17]
+	mi := &file_proto_grpc_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ChangePasswordResponce.ProtoReflect.Descriptor instead.
-func (*ChangePasswordResponce) Descriptor() ([]byte, []int) {
-	return file_proto_grpc_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *ChangePasswordResponce) GetStatus() bool {
-	if x != nil {
-		return x.Status
-	}
-	return false
-}
-
-func (x *ChangePasswordResponce) GetSign() []byte {
-	if x != nil {
-		return x.Sign
-	}
-	return nil
-}
-
-var File_proto_grpc_proto protoreflect.FileDescriptor
-
-var file_proto_grpc_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x04, 0x67, 0x72, 0x70, 0x63, 0x22, 0x3f, 0x0a, 0x13, 0x6e, 0x65, 0x77, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x28, 0x0a, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
-	0x42, 0x5a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x5a, 0x22, 0x56, 0x0a, 0x14, 0x6e, 0x65, 0x77,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
-	0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x5a, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42,
-	0x5a, 0x22, 0x64, 0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
-	0x44, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x73, 0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x55, 0x73,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x6a, 0x0a, 0x10,
-	0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1c,
-	0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x3f, 0x0a, 0x11, 0x6c, 0x6f, 0x67, 0x69,
-	0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x4b, 0x0a, 0x0f, 0x75, 0x73, 0x65,
-	0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xb0, 0x01, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c,
-	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63,
-	0x6b, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c,
-	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x4c, 0x0a, 0x10, 0x74, 0x69, 0x6d,
-	0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
-	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x7d, 0x0a, 0x11, 0x74, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f,
-	0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b,
-	0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b,
-	0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x4b, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53,
-	0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53,
-	0x69, 0x67, 0x6e, 0x22, 0x5e, 0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12,
-	0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73,
-	0x69, 0x67, 0x6e, 0x22, 0x87, 0x01, 0x0a, 0x11, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x5e, 0x0a,
-	0x12, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x49, 0x0a,
-	0x0d, 0x6c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
-	0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x28, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x4f,
-	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x15, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x6c,
-	0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b,
-	0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a,
-	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x44, 0x0a, 0x16, 0x63, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e,
-	0x32, 0xc0, 0x04, 0x0a, 0x0a, 0x47, 0x6f, 0x70, 0x68, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x12,
-	0x45, 0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
-	0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x70,
-	0x63, 0x2e, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x55, 0x73, 0x65,
-	0x72, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6e,
-	0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3c,
-	0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x67, 0x72,
-	0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08,
-	0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
-	0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x74, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67,
-	0x72, 0x70, 0x63, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63,
-	0x6b, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63,
-	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65,
-	0x12, 0x3f, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
-	0x65, 0x12, 0x33, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x67, 0x72,
-	0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x63, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_proto_grpc_proto_rawDescOnce sync.Once
-	file_proto_grpc_proto_rawDescData = file_proto_grpc_proto_rawDesc
-)
-
-func file_proto_grpc_proto_rawDescGZIP() []byte {
-	file_proto_grpc_proto_rawDescOnce.Do(func() {
-		file_proto_grpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_grpc_proto_rawDescData)
-	})
-	return file_proto_grpc_proto_rawDescData
-}
-
-var file_proto_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
-var file_proto_grpc_proto_goTypes = []interface{}{
-	(*NewSessionIDRequest)(nil),    // 0: grpc.newSessionIDRequest
-	(*NewSessionIDResponce)(nil),   // 1: grpc.newSessionIDResponce
-	(*NewUserRequest)(nil),         // 2: grpc.newUserRequest
-	(*NewUserResponce)(nil),        // 3: grpc.newUserResponce
-	(*LoginUserRequest)(nil),       // 4: grpc.loginUserRequest
-	(*LoginUserResponce)(nil),      // 5: grpc.loginUserResponce
-	(*UserDataRequest)(nil),        // 6: grpc.userDataRequest
-	(*UserDataResponce)(nil),       // 7: grpc.userDataResponce
-	(*TimeStampRequest)(nil),       // 8: grpc.timeStampRequest
-	(*TimeStampResponce)(nil),      // 9: grpc.timeStampResponce
-	(*DataLockRequest)(nil),        // 10: grpc.dataLockRequest
-	(*DataLockResponce)(nil),       // 11: grpc.dataLockResponce
-	(*UpdateDataRequest)(nil),      // 12: grpc.updateDataRequest
-	(*UpdateDataResponce)(nil),     // 13: grpc.updateDataResponce
-	(*LogOutRequest)(nil),          // 14: grpc.logOutRequest
-	(*LogOutResponce)(nil),         // 15: grpc.logOutResponce
-	(*ChangePasswordRequest)(nil),  // 16: grpc.changePasswordRequest
-	(*ChangePasswordResponce)(nil), // 17: grpc.changePasswordResponce
-}
-var file_proto_grpc_proto_depIdxs = []int32{
-	0,  // 0: grpc.GophKeeper.NewSessionID:input_type -> grpc.newSessionIDRequest
-	2,  // 1: grpc.GophKeeper.NewUser:input_type -> grpc.newUserRequest
-	4,  // 2: grpc.GophKeeper.LoginUser:input_type -> grpc.loginUserRequest
-	6,  // 3: grpc.GophKeeper.UserData:input_type -> grpc.userDataRequest
-	8,  // 4: grpc.GophKeeper.TimeStamp:input_type -> grpc.timeStampRequest
-	10, // 5: grpc.GophKeeper.DataLock:input_type -> grpc.dataLockRequest
-	12, // 6: grpc.GophKeeper.UpdateData:input_type -> grpc.updateDataRequest
-	14, // 7: grpc.GophKeeper.LogOut:input_type -> grpc.logOutRequest
-	16, // 8: grpc.GophKeeper.ChangePassword:input_type -> grpc.changePasswordRequest
-	1,  // 9: grpc.GophKeeper.NewSessionID:output_type -> grpc.newSessionIDResponce
-	3,  // 10: grpc.GophKeeper.NewUser:output_type -> grpc.newUserResponce
-	5,  // 11: grpc.GophKeeper.LoginUser:output_type -> grpc.loginUserResponce
-	7,  // 12: grpc.GophKeeper.UserData:output_type -> grpc.userDataResponce
-	9,  // 13: grpc.GophKeeper.TimeStamp:output_type -> grpc.timeStampResponce
-	11, // 14: grpc.GophKeeper.DataLock:output_type -> grpc.dataLockResponce
-	13, // 15: grpc.GophKeeper.UpdateData:output_type -> grpc.updateDataResponce
-	15, // 16: grpc.GophKeeper.LogOut:output_type -> grpc.logOutResponce
-	17, // 17: grpc.GophKeeper.ChangePassword:output_type -> grpc.changePasswordResponce
-	9,  // [9:18] is the sub-list for method output_type
-	0,  // [0:9] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
-}
-
-func init() { file_proto_grpc_proto_init() }
-func file_proto_grpc_proto_init() {
-	if File_proto_grpc_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_grpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewSessionIDRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponce.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChangePasswordResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *ChangePasswordResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordID   string `protobuf:"bytes,1,opt,name=recordID,proto3" json:"recordID,omitempty"`     //идентификатор записи
+	RecordType string `protobuf:"bytes,2,opt,name=recordType,proto3" json:"recordType,omitempty"` //тип записи: password, card, text, binary
+	Version    int64  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`      //версия записи на сервере
+	TimeStamp  string `protobuf:"bytes,4,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`   //отметка времени последнего изменения записи
+	RecordData []byte `protobuf:"bytes,5,opt,name=recordData,proto3" json:"recordData,omitempty"` //зашифрованные данные записи
+	Revision   int64  `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`    //ревизия данных пользователя, в которой запись изменена последний раз
+	Deleted    bool   `protobuf:"varint,7,opt,name=deleted,proto3" json:"deleted,omitempty"`      //отметка об удалении записи
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Record) GetRecordID() string {
+	if x != nil {
+		return x.RecordID
+	}
+	return ""
+}
+
+func (x *Record) GetRecordType() string {
+	if x != nil {
+		return x.RecordType
+	}
+	return ""
+}
+
+func (x *Record) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Record) GetTimeStamp() string {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return ""
+}
+
+func (x *Record) GetRecordData() []byte {
+	if x != nil {
+		return x.RecordData
+	}
+	return nil
+}
+
+func (x *Record) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Record) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type CreateRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string  `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Record    *Record `protobuf:"bytes,2,opt,name=record,proto3" json:"record,omitempty"`       //новая запись пользователя
+	UserSign  []byte  `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *CreateRecordRequest) Reset() {
+	*x = CreateRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecordRequest) ProtoMessage() {}
+
+func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateRecordRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *CreateRecordRequest) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *CreateRecordRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type UpdateRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string  `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Record    *Record `protobuf:"bytes,2,opt,name=record,proto3" json:"record,omitempty"`       //измененная запись пользователя с версией, на основе которой она изменена
+	UserSign  []byte  `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *UpdateRecordRequest) Reset() {
+	*x = UpdateRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRecordRequest) ProtoMessage() {}
+
+func (x *UpdateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRecordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateRecordRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *UpdateRecordRequest) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *UpdateRecordRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type RecordResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"` //сохраненная на сервере запись пользователя
+	Sign   []byte  `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`     //Подпись данных сервером
+}
+
+func (x *RecordResponce) Reset() {
+	*x = RecordResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordResponce) ProtoMessage() {}
+
+func (x *RecordResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordResponce.ProtoReflect.Descriptor instead.
+func (*RecordResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RecordResponce) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type DeleteRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	RecordID  string `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`   //идентификатор удаляемой записи
+	Version   int64  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`    //версия удаляемой записи
+	UserSign  []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *DeleteRecordRequest) Reset() {
+	*x = DeleteRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecordRequest) ProtoMessage() {}
+
+func (x *DeleteRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecordRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteRecordRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DeleteRecordRequest) GetRecordID() string {
+	if x != nil {
+		return x.RecordID
+	}
+	return ""
+}
+
+func (x *DeleteRecordRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeleteRecordRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type DeleteRecordResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - запись удалена, false - ошибка, уточнение в error
+	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
+}
+
+func (x *DeleteRecordResponce) Reset() {
+	*x = DeleteRecordResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRecordResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecordResponce) ProtoMessage() {}
+
+func (x *DeleteRecordResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecordResponce.ProtoReflect.Descriptor instead.
+func (*DeleteRecordResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteRecordResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *DeleteRecordResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type ListRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *ListRecordsRequest) Reset() {
+	*x = ListRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsRequest) ProtoMessage() {}
+
+func (x *ListRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListRecordsRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type ListRecordsResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` //список записей пользователя без данных
+	Sign    []byte    `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`       //Подпись данных сервером
+}
+
+func (x *ListRecordsResponce) Reset() {
+	*x = ListRecordsResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRecordsResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsResponce) ProtoMessage() {}
+
+func (x *ListRecordsResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsResponce.ProtoReflect.Descriptor instead.
+func (*ListRecordsResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListRecordsResponce) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListRecordsResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type GetRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	RecordID  string `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`   //идентификатор запрашиваемой записи
+	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *GetRecordRequest) Reset() {
+	*x = GetRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordRequest) ProtoMessage() {}
+
+func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetRecordRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *GetRecordRequest) GetRecordID() string {
+	if x != nil {
+		return x.RecordID
+	}
+	return ""
+}
+
+func (x *GetRecordRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string    `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Revision  int64     `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`  //последняя известная клиенту ревизия данных на сервере
+	Records   []*Record `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`     //измененные клиентом записи с версией, на основе которой они изменены
+	UserSign  []byte    `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRequest) ProtoMessage() {}
+
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SyncRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *SyncRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type SyncResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision  int64     `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`  //текущая ревизия данных пользователя на сервере
+	Records   []*Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`     //записи, измененные или удаленные после ревизии клиента
+	Conflicts []*Record `protobuf:"bytes,3,rep,name=conflicts,proto3" json:"conflicts,omitempty"` //серверные копии записей, которые клиент изменил на основе устаревшей версии
+	Sign      []byte    `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`           //Подпись данных сервером
+}
+
+func (x *SyncResponce) Reset() {
+	*x = SyncResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResponce) ProtoMessage() {}
+
+func (x *SyncResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResponce.ProtoReflect.Descriptor instead.
+func (*SyncResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SyncResponce) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *SyncResponce) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *SyncResponce) GetConflicts() []*Record {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+func (x *SyncResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type HistoryVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   int64  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`    //версия данных пользователя
+	TimeStamp string `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"` //отметка времени сохранения версии данных
+}
+
+func (x *HistoryVersion) Reset() {
+	*x = HistoryVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryVersion) ProtoMessage() {}
+
+func (x *HistoryVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryVersion.ProtoReflect.Descriptor instead.
+func (*HistoryVersion) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *HistoryVersion) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HistoryVersion) GetTimeStamp() string {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return ""
+}
+
+type ListHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *ListHistoryRequest) Reset() {
+	*x = ListHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryRequest) ProtoMessage() {}
+
+func (x *ListHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListHistoryRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *ListHistoryRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type ListHistoryResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Versions []*HistoryVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"` //сохраненные предыдущие версии данных пользователя
+	Sign     []byte            `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`         //Подпись данных сервером
+}
+
+func (x *ListHistoryResponce) Reset() {
+	*x = ListHistoryResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHistoryResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryResponce) ProtoMessage() {}
+
+func (x *ListHistoryResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHistoryResponce.ProtoReflect.Descriptor instead.
+func (*ListHistoryResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListHistoryResponce) GetVersions() []*HistoryVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListHistoryResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type RestoreVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID      string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`            //SessionID пользователя
+	Version        int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`               //восстанавливаемая версия данных из истории
+	CurrentVersion int64  `protobuf:"varint,3,opt,name=currentVersion,proto3" json:"currentVersion,omitempty"` //версия данных пользователя, известная клиенту
+	UserSign       []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`              //Подпись данных пользователем
+}
+
+func (x *RestoreVersionRequest) Reset() {
+	*x = RestoreVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVersionRequest) ProtoMessage() {}
+
+func (x *RestoreVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreVersionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RestoreVersionRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *RestoreVersionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RestoreVersionRequest) GetCurrentVersion() int64 {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return 0
+}
+
+func (x *RestoreVersionRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type RestoreVersionResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status      bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`          //результат true - данные восстановлены, false - ошибка, уточнение в error
+	TimeStamp   string   `protobuf:"bytes,2,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`     //отметка времени сохранения восстановленных данных
+	Version     int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`        //новая версия сохраненных данных пользователя
+	Sign        []byte   `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`               //Подпись данных сервером
+	Attachments []string `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"` //вложения, освобожденные после восстановленной версии и снова сохраняемые сервером
+}
+
+func (x *RestoreVersionResponce) Reset() {
+	*x = RestoreVersionResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreVersionResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVersionResponce) ProtoMessage() {}
+
+func (x *RestoreVersionResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreVersionResponce.ProtoReflect.Descriptor instead.
+func (*RestoreVersionResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RestoreVersionResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *RestoreVersionResponce) GetTimeStamp() string {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return ""
+}
+
+func (x *RestoreVersionResponce) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RestoreVersionResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *RestoreVersionResponce) GetAttachments() []string {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID     string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`         //SessionID сессии пользователя
+	Created       string `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`             //время создания сессии
+	LastSeen      string `protobuf:"bytes,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`           //время последнего запроса в сессии
+	ClientVersion string `protobuf:"bytes,4,opt,name=clientVersion,proto3" json:"clientVersion,omitempty"` //версия приложения клиента
+	RemoteAddr    string `protobuf:"bytes,5,opt,name=remoteAddr,proto3" json:"remoteAddr,omitempty"`       //адрес, с которого подключен клиент
+	Current       bool   `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`            //true - сессия, из которой отправлен запрос
+	DeviceName    string `protobuf:"bytes,7,opt,name=deviceName,proto3" json:"deviceName,omitempty"`       //имя устройства клиента
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SessionInfo) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *SessionInfo) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSessionsRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *ListSessionsRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type ListSessionsResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*SessionInfo `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"` //активные сессии пользователя
+	Sign     []byte         `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`         //Подпись данных сервером
+}
+
+func (x *ListSessionsResponce) Reset() {
+	*x = ListSessionsResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponce) ProtoMessage() {}
+
+func (x *ListSessionsResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponce.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSessionsResponce) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListSessionsResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID       string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`             //SessionID пользователя
+	RevokeSessionID string `protobuf:"bytes,2,opt,name=revokeSessionID,proto3" json:"revokeSessionID,omitempty"` //SessionID завершаемой сессии пользователя
+	UserSign        []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`               //Подпись данных пользователем
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RevokeSessionRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *RevokeSessionRequest) GetRevokeSessionID() string {
+	if x != nil {
+		return x.RevokeSessionID
+	}
+	return ""
+}
+
+func (x *RevokeSessionRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type RevokeSessionResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - сессия завершена
+	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
+}
+
+func (x *RevokeSessionResponce) Reset() {
+	*x = RevokeSessionResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeSessionResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponce) ProtoMessage() {}
+
+func (x *RevokeSessionResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponce.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RevokeSessionResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *RevokeSessionResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type EnableTOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Code      []byte `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`           //зашифрованный код TOTP для подтверждения, пустой - запрос нового секрета
+	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *EnableTOTPRequest) Reset() {
+	*x = EnableTOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableTOTPRequest) ProtoMessage() {}
+
+func (x *EnableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *EnableTOTPRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *EnableTOTPRequest) GetCode() []byte {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *EnableTOTPRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type EnableTOTPResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled       bool   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`            //true - двухфакторная аутентификация включена, false - выдан новый секрет, ожидается подтверждение кодом
+	Secret        []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`               //зашифрованный секрет TOTP в кодировке base32
+	Uri           []byte `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`                     //зашифрованная ссылка otpauth:// для приложения-аутентификатора
+	RecoveryCodes []byte `protobuf:"bytes,4,opt,name=recoveryCodes,proto3" json:"recoveryCodes,omitempty"` //зашифрованные одноразовые коды восстановления, разделенные переводом строки
+	Sign          []byte `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`                   //Подпись данных сервером
+}
+
+func (x *EnableTOTPResponce) Reset() {
+	*x = EnableTOTPResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnableTOTPResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableTOTPResponce) ProtoMessage() {}
+
+func (x *EnableTOTPResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableTOTPResponce.ProtoReflect.Descriptor instead.
+func (*EnableTOTPResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *EnableTOTPResponce) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *EnableTOTPResponce) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *EnableTOTPResponce) GetUri() []byte {
+	if x != nil {
+		return x.Uri
+	}
+	return nil
+}
+
+func (x *EnableTOTPResponce) GetRecoveryCodes() []byte {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+func (x *EnableTOTPResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Code      []byte `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`           //зашифрованный код TOTP или код восстановления
+	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DisableTOTPRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DisableTOTPRequest) GetCode() []byte {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *DisableTOTPRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type DisableTOTPResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` //результат true - двухфакторная аутентификация отключена
+	Sign   []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`      //Подпись данных сервером
+}
+
+func (x *DisableTOTPResponce) Reset() {
+	*x = DisableTOTPResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisableTOTPResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponce) ProtoMessage() {}
+
+func (x *DisableTOTPResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponce.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DisableTOTPResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *DisableTOTPResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	Password  []byte `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`   //зашифрованный текущий ключ аутентификации пользователя
+	UserSign  []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteAccountRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetPassword() []byte {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+func (x *DeleteAccountRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type DeleteAccountResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status          bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                   //результат true - учетная запись и все данные пользователя удалены
+	Sign            []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`                        //Подпись данных сервером
+	AttachmentsKept bool   `protobuf:"varint,3,opt,name=attachmentsKept,proto3" json:"attachmentsKept,omitempty"` //true - учетная запись удалена, но файлы вложений пользователя не удалось удалить с диска сервера
+}
+
+func (x *DeleteAccountResponce) Reset() {
+	*x = DeleteAccountResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteAccountResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponce) ProtoMessage() {}
+
+func (x *DeleteAccountResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponce.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteAccountResponce) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *DeleteAccountResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *DeleteAccountResponce) GetAttachmentsKept() bool {
+	if x != nil {
+		return x.AttachmentsKept
+	}
+	return false
+}
+
+type WatchChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя
+	UserSign  []byte `protobuf:"bytes,2,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *WatchChangesRequest) Reset() {
+	*x = WatchChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchChangesRequest) ProtoMessage() {}
+
+func (x *WatchChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *WatchChangesRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *WatchChangesRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind       ChangeKind `protobuf:"varint,1,opt,name=kind,proto3,enum=grpc.ChangeKind" json:"kind,omitempty"` //вид изменения
+	Version    int64      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                //версия сохраненных данных пользователя, 0 - версия не изменилась
+	Revision   int64      `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`              //ревизия данных пользователя после сохранения записей, 0 - ревизия неизвестна
+	TimeStamp  string     `protobuf:"bytes,4,opt,name=timeStamp,proto3" json:"timeStamp,omitempty"`             //отметка времени сохранения данных
+	TimeLocked string     `protobuf:"bytes,5,opt,name=timeLocked,proto3" json:"timeLocked,omitempty"`           //время окончания блокировки данных
+	Owner      *LockOwner `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`                     //сессия, выполнившая изменение
+	Sign       []byte     `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`                       //Подпись данных сервером
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ChangeEvent) GetKind() ChangeKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ChangeKind_SAVED
+}
+
+func (x *ChangeEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetTimeStamp() string {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTimeLocked() string {
+	if x != nil {
+		return x.TimeLocked
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetOwner() *LockOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type UploadAttachmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` //SessionID пользователя, передается в первом сообщении потока
+	Size      int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`          //общий размер зашифрованных частей вложения, передается в первом сообщении потока
+	Data      []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`           //зашифрованная часть вложения, первое сообщение потока частей не содержит
+	UserSign  []byte `protobuf:"bytes,4,opt,name=userSign,proto3" json:"userSign,omitempty"`   //Подпись данных пользователем
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UploadAttachmentRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadAttachmentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type UploadAttachmentResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttachmentID string `protobuf:"bytes,1,opt,name=attachmentID,proto3" json:"attachmentID,omitempty"` //идентификатор сохраненного вложения
+	Used         int64  `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`                //объем вложений пользователя на сервере после сохранения, в байтах
+	Quota        int64  `protobuf:"varint,3,opt,name=quota,proto3" json:"quota,omitempty"`              //допустимый объем вложений пользователя, в байтах
+	Sign         []byte `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`                 //Подпись данных сервером
+}
+
+func (x *UploadAttachmentResponce) Reset() {
+	*x = UploadAttachmentResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAttachmentResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponce) ProtoMessage() {}
+
+func (x *UploadAttachmentResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponce.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UploadAttachmentResponce) GetAttachmentID() string {
+	if x != nil {
+		return x.AttachmentID
+	}
+	return ""
+}
+
+func (x *UploadAttachmentResponce) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *UploadAttachmentResponce) GetQuota() int64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *UploadAttachmentResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID    string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`       //SessionID пользователя
+	AttachmentID string `protobuf:"bytes,2,opt,name=attachmentID,proto3" json:"attachmentID,omitempty"` //идентификатор вложения
+	UserSign     []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`         //Подпись данных пользователем
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DownloadAttachmentRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentID() string {
+	if x != nil {
+		return x.AttachmentID
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type AttachmentChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`  //зашифрованная часть вложения
+	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` //объем, занимаемый вложением на сервере, передается в первом сообщении потока
+	Sign []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`  //Подпись данных сервером, передается в первом сообщении потока
+}
+
+func (x *AttachmentChunk) Reset() {
+	*x = AttachmentChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachmentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentChunk) ProtoMessage() {}
+
+func (x *AttachmentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentChunk.ProtoReflect.Descriptor instead.
+func (*AttachmentChunk) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AttachmentChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AttachmentChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentChunk) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionID    string `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`       //SessionID пользователя
+	AttachmentID string `protobuf:"bytes,2,opt,name=attachmentID,proto3" json:"attachmentID,omitempty"` //идентификатор вложения
+	UserSign     []byte `protobuf:"bytes,3,opt,name=userSign,proto3" json:"userSign,omitempty"`         //Подпись данных пользователем
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteAttachmentRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *DeleteAttachmentRequest) GetAttachmentID() string {
+	if x != nil {
+		return x.AttachmentID
+	}
+	return ""
+}
+
+func (x *DeleteAttachmentRequest) GetUserSign() []byte {
+	if x != nil {
+		return x.UserSign
+	}
+	return nil
+}
+
+type DeleteAttachmentResponce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sign []byte `protobuf:"bytes,1,opt,name=sign,proto3" json:"sign,omitempty"` //Подпись данных сервером
+}
+
+func (x *DeleteAttachmentResponce) Reset() {
+	*x = DeleteAttachmentResponce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grpc_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteAttachmentResponce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponce) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grpc_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponce.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponce) Descriptor() ([]byte, []int) {
+	return file_proto_grpc_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DeleteAttachmentResponce) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+var File_proto_grpc_proto protoreflect.FileDescriptor
+
+var file_proto_grpc_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x67, 0x72, 0x70, 0x63, 0x22, 0x85, 0x01, 0x0a, 0x13, 0x6e, 0x65, 0x77,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x28, 0x0a, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x42, 0x5a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x5a, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0x56, 0x0a, 0x14, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x42, 0x5a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x5a, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x6d, 0x61, 0x73,
+	0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x4b, 0x65, 0x79,
+	0x22, 0x62, 0x0a, 0x10, 0x6b, 0x65, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x22, 0x6e, 0x0a, 0x11, 0x6b, 0x65, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x6d, 0x61, 0x73,
+	0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52, 0x09, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x73, 0x69, 0x67, 0x6e, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x2d, 0x0a, 0x09, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52,
+	0x09, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x22, 0x73, 0x0a, 0x0f, 0x6e, 0x65,
+	0x77, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22,
+	0x86, 0x01, 0x0a, 0x10, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x6f, 0x74, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x74, 0x6f, 0x74, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x2d, 0x0a, 0x09, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52, 0x09,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65,
+	0x79, 0x22, 0x4b, 0x0a, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xca,
+	0x01, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x09,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x22, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x4c, 0x0a,
+	0x10, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xbe, 0x01, 0x0a, 0x11,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d,
+	0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x67, 0x0a, 0x0f,
+	0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x6b,
+	0x65, 0x4f, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x61, 0x6b,
+	0x65, 0x4f, 0x76, 0x65, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x25, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x4e, 0x0a,
+	0x12, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x41, 0x0a,
+	0x13, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e,
+	0x22, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x5f,
+	0x0a, 0x11, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74,
+	0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22,
+	0xa1, 0x01, 0x0a, 0x11, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x78, 0x0a, 0x12, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73,
+	0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x49, 0x0a,
+	0x0d, 0x6c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x28, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x4f,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0xc4, 0x01, 0x0a, 0x15, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x6c,
+	0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x2d, 0x0a, 0x09, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52, 0x09,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x22, 0x44, 0x0a, 0x16, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22,
+	0xd2, 0x01, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x22, 0x75, 0x0a, 0x13, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x75, 0x0a, 0x13, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x12, 0x24, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69,
+	0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69,
+	0x67, 0x6e, 0x22, 0x4a, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x85,
+	0x01, 0x0a, 0x13, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x42, 0x0a, 0x14, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x4e, 0x0a, 0x12, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x51, 0x0a, 0x13, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x26, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x68, 0x0a,
+	0x10, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x8b, 0x01, 0x0a, 0x0b, 0x73, 0x79, 0x6e, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x92, 0x01, 0x0a, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x48, 0x0a, 0x0e, 0x68, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x4e, 0x0a, 0x12, 0x6c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x22, 0x5b, 0x0a, 0x13, 0x6c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67,
+	0x6e, 0x22, 0x93, 0x01, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x16, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x0b, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0d,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4f, 0x0a, 0x13,
+	0x6c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x59, 0x0a,
+	0x14, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x7a, 0x0a, 0x14, 0x72, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x28,
+	0x0a, 0x0f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x22, 0x43, 0x0a, 0x15, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x61, 0x0a, 0x11, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x92, 0x01, 0x0a,
+	0x12, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67,
+	0x6e, 0x22, 0x62, 0x0a, 0x12, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x41, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
+	0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x6c, 0x0a, 0x14, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x6d, 0x0a, 0x15, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x4b, 0x65, 0x70, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x4b, 0x65, 0x70, 0x74, 0x22, 0x4f, 0x0a, 0x13, 0x77, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x12, 0x25, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x7b, 0x0a, 0x17, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x7c, 0x0a, 0x18, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x79, 0x0a, 0x19, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67,
+	0x6e, 0x22, 0x4d, 0x0a, 0x0f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e,
+	0x22, 0x77, 0x0a, 0x17, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x2e, 0x0a, 0x18, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x2a, 0x31, 0x0a, 0x0a, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x41, 0x56, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c,
+	0x0a, 0x08, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x32, 0xa0, 0x0f, 0x0a,
+	0x0a, 0x47, 0x6f, 0x70, 0x68, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x0c, 0x4e,
+	0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x19, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6e, 0x65,
+	0x77, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x63, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6b, 0x65, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6b,
+	0x65, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65,
+	0x12, 0x36, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6e, 0x65, 0x77, 0x55, 0x73, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x69,
+	0x6e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x3c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x74, 0x69,
+	0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x39, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3c,
+	0x0a, 0x09, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x72, 0x65, 0x6e, 0x65, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x6e, 0x65, 0x77,
+	0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0a,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a,
+	0x06, 0x4c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c,
+	0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x6c, 0x6f, 0x67, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x3f, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65,
+	0x12, 0x3f, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x45, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x67, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12,
+	0x11, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x69, 0x73,
+	0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x48,
+	0x0a, 0x0d, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54,
+	0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x48, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x77,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x28, 0x01, 0x12, 0x4e, 0x0a, 0x12,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x61, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x10,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x63, 0x65, 0x42,
+	0x0c, 0x5a, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_grpc_proto_rawDescOnce sync.Once
+	file_proto_grpc_proto_rawDescData = file_proto_grpc_proto_rawDesc
+)
+
+func file_proto_grpc_proto_rawDescGZIP() []byte {
+	file_proto_grpc_proto_rawDescOnce.Do(func() {
+		file_proto_grpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_grpc_proto_rawDescData)
+	})
+	return file_proto_grpc_proto_rawDescData
+}
+
+var file_proto_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
+var file_proto_grpc_proto_goTypes = []interface{}{
+	(ChangeKind)(0),                   // 0: grpc.changeKind
+	(*NewSessionIDRequest)(nil),       // 1: grpc.newSessionIDRequest
+	(*NewSessionIDResponce)(nil),      // 2: grpc.newSessionIDResponce
+	(*MasterKey)(nil),                 // 3: grpc.masterKey
+	(*KeyParamsRequest)(nil),          // 4: grpc.keyParamsRequest
+	(*KeyParamsResponce)(nil),         // 5: grpc.keyParamsResponce
+	(*NewUserRequest)(nil),            // 6: grpc.newUserRequest
+	(*NewUserResponce)(nil),           // 7: grpc.newUserResponce
+	(*LoginUserRequest)(nil),          // 8: grpc.loginUserRequest
+	(*LoginUserResponce)(nil),         // 9: grpc.loginUserResponce
+	(*UserDataRequest)(nil),           // 10: grpc.userDataRequest
+	(*UserDataResponce)(nil),          // 11: grpc.userDataResponce
+	(*LockOwner)(nil),                 // 12: grpc.lockOwner
+	(*TimeStampRequest)(nil),          // 13: grpc.timeStampRequest
+	(*TimeStampResponce)(nil),         // 14: grpc.timeStampResponce
+	(*DataLockRequest)(nil),           // 15: grpc.dataLockRequest
+	(*DataLockResponce)(nil),          // 16: grpc.dataLockResponce
+	(*ReleaseLockRequest)(nil),        // 17: grpc.releaseLockRequest
+	(*ReleaseLockResponce)(nil),       // 18: grpc.releaseLockResponce
+	(*RenewLockRequest)(nil),          // 19: grpc.renewLockRequest
+	(*RenewLockResponce)(nil),         // 20: grpc.renewLockResponce
+	(*UpdateDataRequest)(nil),         // 21: grpc.updateDataRequest
+	(*UpdateDataResponce)(nil),        // 22: grpc.updateDataResponce
+	(*LogOutRequest)(nil),             // 23: grpc.logOutRequest
+	(*LogOutResponce)(nil),            // 24: grpc.logOutResponce
+	(*ChangePasswordRequest)(nil),     // 25: grpc.changePasswordRequest
+	(*ChangePasswordResponce)(nil),    // 26: grpc.changePasswordResponce
+	(*Record)(nil),                    // 27: grpc.record
+	(*CreateRecordRequest)(nil),       // 28: grpc.createRecordRequest
+	(*UpdateRecordRequest)(nil),       // 29: grpc.updateRecordRequest
+	(*RecordResponce)(nil),            // 30: grpc.recordResponce
+	(*DeleteRecordRequest)(nil),       // 31: grpc.deleteRecordRequest
+	(*DeleteRecordResponce)(nil),      // 32: grpc.deleteRecordResponce
+	(*ListRecordsRequest)(nil),        // 33: grpc.listRecordsRequest
+	(*ListRecordsResponce)(nil),       // 34: grpc.listRecordsResponce
+	(*GetRecordRequest)(nil),          // 35: grpc.getRecordRequest
+	(*SyncRequest)(nil),               // 36: grpc.syncRequest
+	(*SyncResponce)(nil),              // 37: grpc.syncResponce
+	(*HistoryVersion)(nil),            // 38: grpc.historyVersion
+	(*ListHistoryRequest)(nil),        // 39: grpc.listHistoryRequest
+	(*ListHistoryResponce)(nil),       // 40: grpc.listHistoryResponce
+	(*RestoreVersionRequest)(nil),     // 41: grpc.restoreVersionRequest
+	(*RestoreVersionResponce)(nil),    // 42: grpc.restoreVersionResponce
+	(*SessionInfo)(nil),               // 43: grpc.sessionInfo
+	(*ListSessionsRequest)(nil),       // 44: grpc.listSessionsRequest
+	(*ListSessionsResponce)(nil),      // 45: grpc.listSessionsResponce
+	(*RevokeSessionRequest)(nil),      // 46: grpc.revokeSessionRequest
+	(*RevokeSessionResponce)(nil),     // 47: grpc.revokeSessionResponce
+	(*EnableTOTPRequest)(nil),         // 48: grpc.enableTOTPRequest
+	(*EnableTOTPResponce)(nil),        // 49: grpc.enableTOTPResponce
+	(*DisableTOTPRequest)(nil),        // 50: grpc.disableTOTPRequest
+	(*DisableTOTPResponce)(nil),       // 51: grpc.disableTOTPResponce
+	(*DeleteAccountRequest)(nil),      // 52: grpc.deleteAccountRequest
+	(*DeleteAccountResponce)(nil),     // 53: grpc.deleteAccountResponce
+	(*WatchChangesRequest)(nil),       // 54: grpc.watchChangesRequest
+	(*ChangeEvent)(nil),               // 55: grpc.changeEvent
+	(*UploadAttachmentRequest)(nil),   // 56: grpc.uploadAttachmentRequest
+	(*UploadAttachmentResponce)(nil),  // 57: grpc.uploadAttachmentResponce
+	(*DownloadAttachmentRequest)(nil), // 58: grpc.downloadAttachmentRequest
+	(*AttachmentChunk)(nil),           // 59: grpc.attachmentChunk
+	(*DeleteAttachmentRequest)(nil),   // 60: grpc.deleteAttachmentRequest
+	(*DeleteAttachmentResponce)(nil),  // 61: grpc.deleteAttachmentResponce
+}
+var file_proto_grpc_proto_depIdxs = []int32{
+	3,  // 0: grpc.keyParamsResponce.masterKey:type_name -> grpc.masterKey
+	3,  // 1: grpc.newUserRequest.masterKey:type_name -> grpc.masterKey
+	3,  // 2: grpc.loginUserResponce.masterKey:type_name -> grpc.masterKey
+	12, // 3: grpc.timeStampResponce.owner:type_name -> grpc.lockOwner
+	12, // 4: grpc.dataLockResponce.owner:type_name -> grpc.lockOwner
+	3,  // 5: grpc.changePasswordRequest.masterKey:type_name -> grpc.masterKey
+	27, // 6: grpc.createRecordRequest.record:type_name -> grpc.record
+	27, // 7: grpc.updateRecordRequest.record:type_name -> grpc.record
+	27, // 8: grpc.recordResponce.record:type_name -> grpc.record
+	27, // 9: grpc.listRecordsResponce.records:type_name -> grpc.record
+	27, // 10: grpc.syncRequest.records:type_name -> grpc.record
+	27, // 11: grpc.syncResponce.records:type_name -> grpc.record
+	27, // 12: grpc.syncResponce.conflicts:type_name -> grpc.record
+	38, // 13: grpc.listHistoryResponce.versions:type_name -> grpc.historyVersion
+	43, // 14: grpc.listSessionsResponce.sessions:type_name -> grpc.sessionInfo
+	0,  // 15: grpc.changeEvent.kind:type_name -> grpc.changeKind
+	12, // 16: grpc.changeEvent.owner:type_name -> grpc.lockOwner
+	1,  // 17: grpc.GophKeeper.NewSessionID:input_type -> grpc.newSessionIDRequest
+	4,  // 18: grpc.GophKeeper.KeyParams:input_type -> grpc.keyParamsRequest
+	6,  // 19: grpc.GophKeeper.NewUser:input_type -> grpc.newUserRequest
+	8,  // 20: grpc.GophKeeper.LoginUser:input_type -> grpc.loginUserRequest
+	10, // 21: grpc.GophKeeper.UserData:input_type -> grpc.userDataRequest
+	13, // 22: grpc.GophKeeper.TimeStamp:input_type -> grpc.timeStampRequest
+	15, // 23: grpc.GophKeeper.DataLock:input_type -> grpc.dataLockRequest
+	17, // 24: grpc.GophKeeper.ReleaseLock:input_type -> grpc.releaseLockRequest
+	19, // 25: grpc.GophKeeper.RenewLock:input_type -> grpc.renewLockRequest
+	21, // 26: grpc.GophKeeper.UpdateData:input_type -> grpc.updateDataRequest
+	23, // 27: grpc.GophKeeper.LogOut:input_type -> grpc.logOutRequest
+	25, // 28: grpc.GophKeeper.ChangePassword:input_type -> grpc.changePasswordRequest
+	28, // 29: grpc.GophKeeper.CreateRecord:input_type -> grpc.createRecordRequest
+	29, // 30: grpc.GophKeeper.UpdateRecord:input_type -> grpc.updateRecordRequest
+	31, // 31: grpc.GophKeeper.DeleteRecord:input_type -> grpc.deleteRecordRequest
+	33, // 32: grpc.GophKeeper.ListRecords:input_type -> grpc.listRecordsRequest
+	35, // 33: grpc.GophKeeper.GetRecord:input_type -> grpc.getRecordRequest
+	36, // 34: grpc.GophKeeper.Sync:input_type -> grpc.syncRequest
+	39, // 35: grpc.GophKeeper.ListHistory:input_type -> grpc.listHistoryRequest
+	41, // 36: grpc.GophKeeper.RestoreVersion:input_type -> grpc.restoreVersionRequest
+	44, // 37: grpc.GophKeeper.ListSessions:input_type -> grpc.listSessionsRequest
+	46, // 38: grpc.GophKeeper.RevokeSession:input_type -> grpc.revokeSessionRequest
+	48, // 39: grpc.GophKeeper.EnableTOTP:input_type -> grpc.enableTOTPRequest
+	50, // 40: grpc.GophKeeper.DisableTOTP:input_type -> grpc.disableTOTPRequest
+	52, // 41: grpc.GophKeeper.DeleteAccount:input_type -> grpc.deleteAccountRequest
+	54, // 42: grpc.GophKeeper.WatchChanges:input_type -> grpc.watchChangesRequest
+	56, // 43: grpc.GophKeeper.UploadAttachment:input_type -> grpc.uploadAttachmentRequest
+	58, // 44: grpc.GophKeeper.DownloadAttachment:input_type -> grpc.downloadAttachmentRequest
+	60, // 45: grpc.GophKeeper.DeleteAttachment:input_type -> grpc.deleteAttachmentRequest
+	2,  // 46: grpc.GophKeeper.NewSessionID:output_type -> grpc.newSessionIDResponce
+	5,  // 47: grpc.GophKeeper.KeyParams:output_type -> grpc.keyParamsResponce
+	7,  // 48: grpc.GophKeeper.NewUser:output_type -> grpc.newUserResponce
+	9,  // 49: grpc.GophKeeper.LoginUser:output_type -> grpc.loginUserResponce
+	11, // 50: grpc.GophKeeper.UserData:output_type -> grpc.userDataResponce
+	14, // 51: grpc.GophKeeper.TimeStamp:output_type -> grpc.timeStampResponce
+	16, // 52: grpc.GophKeeper.DataLock:output_type -> grpc.dataLockResponce
+	18, // 53: grpc.GophKeeper.ReleaseLock:output_type -> grpc.releaseLockResponce
+	20, // 54: grpc.GophKeeper.RenewLock:output_type -> grpc.renewLockResponce
+	22, // 55: grpc.GophKeeper.UpdateData:output_type -> grpc.updateDataResponce
+	24, // 56: grpc.GophKeeper.LogOut:output_type -> grpc.logOutResponce
+	26, // 57: grpc.GophKeeper.ChangePassword:output_type -> grpc.changePasswordResponce
+	30, // 58: grpc.GophKeeper.CreateRecord:output_type -> grpc.recordResponce
+	30, // 59: grpc.GophKeeper.UpdateRecord:output_type -> grpc.recordResponce
+	32, // 60: grpc.GophKeeper.DeleteRecord:output_type -> grpc.deleteRecordResponce
+	34, // 61: grpc.GophKeeper.ListRecords:output_type -> grpc.listRecordsResponce
+	30, // 62: grpc.GophKeeper.GetRecord:output_type -> grpc.recordResponce
+	37, // 63: grpc.GophKeeper.Sync:output_type -> grpc.syncResponce
+	40, // 64: grpc.GophKeeper.ListHistory:output_type -> grpc.listHistoryResponce
+	42, // 65: grpc.GophKeeper.RestoreVersion:output_type -> grpc.restoreVersionResponce
+	45, // 66: grpc.GophKeeper.ListSessions:output_type -> grpc.listSessionsResponce
+	47, // 67: grpc.GophKeeper.RevokeSession:output_type -> grpc.revokeSessionResponce
+	49, // 68: grpc.GophKeeper.EnableTOTP:output_type -> grpc.enableTOTPResponce
+	51, // 69: grpc.GophKeeper.DisableTOTP:output_type -> grpc.disableTOTPResponce
+	53, // 70: grpc.GophKeeper.DeleteAccount:output_type -> grpc.deleteAccountResponce
+	55, // 71: grpc.GophKeeper.WatchChanges:output_type -> grpc.changeEvent
+	57, // 72: grpc.GophKeeper.UploadAttachment:output_type -> grpc.uploadAttachmentResponce
+	59, // 73: grpc.GophKeeper.DownloadAttachment:output_type -> grpc.attachmentChunk
+	61, // 74: grpc.GophKeeper.DeleteAttachment:output_type -> grpc.deleteAttachmentResponce
+	46, // [46:75] is the sub-list for method output_type
+	17, // [17:46] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_proto_grpc_proto_init() }
+func file_proto_grpc_proto_init() {
+	if File_proto_grpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_grpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSessionIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSessionIDResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MasterKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyParamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyParamsResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewUserResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginUserResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserDataResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeStampRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeStampResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataLockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataLockResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseLockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseLockResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenewLockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenewLockResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDataResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogOutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogOutResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangePasswordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangePasswordResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Record); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRecordRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1353,8 +5170,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewSessionIDResponce); i {
+		file_proto_grpc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRecordResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1365,8 +5182,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewUserRequest); i {
+		file_proto_grpc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRecordsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1377,8 +5194,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewUserResponce); i {
+		file_proto_grpc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRecordsResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1389,8 +5206,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginUserRequest); i {
+		file_proto_grpc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1401,8 +5218,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginUserResponce); i {
+		file_proto_grpc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1413,8 +5230,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserDataRequest); i {
+		file_proto_grpc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1425,8 +5242,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserDataResponce); i {
+		file_proto_grpc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryVersion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1437,8 +5254,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeStampRequest); i {
+		file_proto_grpc_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHistoryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1449,8 +5266,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeStampResponce); i {
+		file_proto_grpc_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHistoryResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1461,8 +5278,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataLockRequest); i {
+		file_proto_grpc_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreVersionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1473,8 +5290,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataLockResponce); i {
+		file_proto_grpc_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreVersionResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1485,8 +5302,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDataRequest); i {
+		file_proto_grpc_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1497,8 +5314,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDataResponce); i {
+		file_proto_grpc_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1509,8 +5326,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogOutRequest); i {
+		file_proto_grpc_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1521,8 +5338,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogOutResponce); i {
+		file_proto_grpc_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1533,8 +5350,8 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangePasswordRequest); i {
+		file_proto_grpc_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeSessionResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1545,8 +5362,164 @@ func file_proto_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangePasswordResponce); i {
+		file_proto_grpc_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnableTOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnableTOTPResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableTOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableTOTPResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAccountResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAttachmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAttachmentResponce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadAttachmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachmentChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAttachmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grpc_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAttachmentResponce); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1563,13 +5536,14 @@ func file_proto_grpc_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_grpc_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   18,
+			NumEnums:      1,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_grpc_proto_goTypes,
 		DependencyIndexes: file_proto_grpc_proto_depIdxs,
+		EnumInfos:         file_proto_grpc_proto_enumTypes,
 		MessageInfos:      file_proto_grpc_proto_msgTypes,
 	}.Build()
 	File_proto_grpc_proto = out.File
@@ -19,15 +19,35 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GophKeeper_NewSessionID_FullMethodName   = "/grpc.GophKeeper/NewSessionID"
-	GophKeeper_NewUser_FullMethodName        = "/grpc.GophKeeper/NewUser"
-	GophKeeper_LoginUser_FullMethodName      = "/grpc.GophKeeper/LoginUser"
-	GophKeeper_UserData_FullMethodName       = "/grpc.GophKeeper/UserData"
-	GophKeeper_TimeStamp_FullMethodName      = "/grpc.GophKeeper/TimeStamp"
-	GophKeeper_DataLock_FullMethodName       = "/grpc.GophKeeper/DataLock"
-	GophKeeper_UpdateData_FullMethodName     = "/grpc.GophKeeper/UpdateData"
-	GophKeeper_LogOut_FullMethodName         = "/grpc.GophKeeper/LogOut"
-	GophKeeper_ChangePassword_FullMethodName = "/grpc.GophKeeper/ChangePassword"
+	GophKeeper_NewSessionID_FullMethodName       = "/grpc.GophKeeper/NewSessionID"
+	GophKeeper_KeyParams_FullMethodName          = "/grpc.GophKeeper/KeyParams"
+	GophKeeper_NewUser_FullMethodName            = "/grpc.GophKeeper/NewUser"
+	GophKeeper_LoginUser_FullMethodName          = "/grpc.GophKeeper/LoginUser"
+	GophKeeper_UserData_FullMethodName           = "/grpc.GophKeeper/UserData"
+	GophKeeper_TimeStamp_FullMethodName          = "/grpc.GophKeeper/TimeStamp"
+	GophKeeper_DataLock_FullMethodName           = "/grpc.GophKeeper/DataLock"
+	GophKeeper_ReleaseLock_FullMethodName        = "/grpc.GophKeeper/ReleaseLock"
+	GophKeeper_RenewLock_FullMethodName          = "/grpc.GophKeeper/RenewLock"
+	GophKeeper_UpdateData_FullMethodName         = "/grpc.GophKeeper/UpdateData"
+	GophKeeper_LogOut_FullMethodName             = "/grpc.GophKeeper/LogOut"
+	GophKeeper_ChangePassword_FullMethodName     = "/grpc.GophKeeper/ChangePassword"
+	GophKeeper_CreateRecord_FullMethodName       = "/grpc.GophKeeper/CreateRecord"
+	GophKeeper_UpdateRecord_FullMethodName       = "/grpc.GophKeeper/UpdateRecord"
+	GophKeeper_DeleteRecord_FullMethodName       = "/grpc.GophKeeper/DeleteRecord"
+	GophKeeper_ListRecords_FullMethodName        = "/grpc.GophKeeper/ListRecords"
+	GophKeeper_GetRecord_FullMethodName          = "/grpc.GophKeeper/GetRecord"
+	GophKeeper_Sync_FullMethodName               = "/grpc.GophKeeper/Sync"
+	GophKeeper_ListHistory_FullMethodName        = "/grpc.GophKeeper/ListHistory"
+	GophKeeper_RestoreVersion_FullMethodName     = "/grpc.GophKeeper/RestoreVersion"
+	GophKeeper_ListSessions_FullMethodName       = "/grpc.GophKeeper/ListSessions"
+	GophKeeper_RevokeSession_FullMethodName      = "/grpc.GophKeeper/RevokeSession"
+	GophKeeper_EnableTOTP_FullMethodName         = "/grpc.GophKeeper/EnableTOTP"
+	GophKeeper_DisableTOTP_FullMethodName        = "/grpc.GophKeeper/DisableTOTP"
+	GophKeeper_DeleteAccount_FullMethodName      = "/grpc.GophKeeper/DeleteAccount"
+	GophKeeper_WatchChanges_FullMethodName       = "/grpc.GophKeeper/WatchChanges"
+	GophKeeper_UploadAttachment_FullMethodName   = "/grpc.GophKeeper/UploadAttachment"
+	GophKeeper_DownloadAttachment_FullMethodName = "/grpc.GophKeeper/DownloadAttachment"
+	GophKeeper_DeleteAttachment_FullMethodName   = "/grpc.GophKeeper/DeleteAttachment"
 )
 
 // GophKeeperClient is the client API for GophKeeper service.
@@ -35,14 +55,34 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type GophKeeperClient interface {
 	NewSessionID(ctx context.Context, in *NewSessionIDRequest, opts ...grpc.CallOption) (*NewSessionIDResponce, error)
+	KeyParams(ctx context.Context, in *KeyParamsRequest, opts ...grpc.CallOption) (*KeyParamsResponce, error)
 	NewUser(ctx context.Context, in *NewUserRequest, opts ...grpc.CallOption) (*NewUserResponce, error)
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponce, error)
 	UserData(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*UserDataResponce, error)
 	TimeStamp(ctx context.Context, in *TimeStampRequest, opts ...grpc.CallOption) (*TimeStampResponce, error)
 	DataLock(ctx context.Context, in *DataLockRequest, opts ...grpc.CallOption) (*DataLockResponce, error)
+	ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockResponce, error)
+	RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponce, error)
 	UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UpdateDataResponce, error)
 	LogOut(ctx context.Context, in *LogOutRequest, opts ...grpc.CallOption) (*LogOutResponce, error)
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponce, error)
+	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error)
+	UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error)
+	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponce, error)
+	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponce, error)
+	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponce, error)
+	ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponce, error)
+	RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*RestoreVersionResponce, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponce, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponce, error)
+	EnableTOTP(ctx context.Context, in *EnableTOTPRequest, opts ...grpc.CallOption) (*EnableTOTPResponce, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponce, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponce, error)
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (GophKeeper_WatchChangesClient, error)
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (GophKeeper_UploadAttachmentClient, error)
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (GophKeeper_DownloadAttachmentClient, error)
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponce, error)
 }
 
 type gophKeeperClient struct {
@@ -62,6 +102,15 @@ func (c *gophKeeperClient) NewSessionID(ctx context.Context, in *NewSessionIDReq
 	return out, nil
 }
 
+func (c *gophKeeperClient) KeyParams(ctx context.Context, in *KeyParamsRequest, opts ...grpc.CallOption) (*KeyParamsResponce, error) {
+	out := new(KeyParamsResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_KeyParams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) NewUser(ctx context.Context, in *NewUserRequest, opts ...grpc.CallOption) (*NewUserResponce, error) {
 	out := new(NewUserResponce)
 	err := c.cc.Invoke(ctx, GophKeeper_NewUser_FullMethodName, in, out, opts...)
@@ -107,6 +156,24 @@ func (c *gophKeeperClient) DataLock(ctx context.Context, in *DataLockRequest, op
 	return out, nil
 }
 
+func (c *gophKeeperClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockResponce, error) {
+	out := new(ReleaseLockResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_ReleaseLock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponce, error) {
+	out := new(RenewLockResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_RenewLock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UpdateDataResponce, error) {
 	out := new(UpdateDataResponce)
 	err := c.cc.Invoke(ctx, GophKeeper_UpdateData_FullMethodName, in, out, opts...)
@@ -134,19 +201,263 @@ func (c *gophKeeperClient) ChangePassword(ctx context.Context, in *ChangePasswor
 	return out, nil
 }
 
+func (c *gophKeeperClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error) {
+	out := new(RecordResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error) {
+	out := new(RecordResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_UpdateRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponce, error) {
+	out := new(DeleteRecordResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_DeleteRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponce, error) {
+	out := new(ListRecordsResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_ListRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*RecordResponce, error) {
+	out := new(RecordResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_GetRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponce, error) {
+	out := new(SyncResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_Sync_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryResponce, error) {
+	out := new(ListHistoryResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_ListHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*RestoreVersionResponce, error) {
+	out := new(RestoreVersionResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_RestoreVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponce, error) {
+	out := new(ListSessionsResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_ListSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponce, error) {
+	out := new(RevokeSessionResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) EnableTOTP(ctx context.Context, in *EnableTOTPRequest, opts ...grpc.CallOption) (*EnableTOTPResponce, error) {
+	out := new(EnableTOTPResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_EnableTOTP_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponce, error) {
+	out := new(DisableTOTPResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_DisableTOTP_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponce, error) {
+	out := new(DeleteAccountResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_DeleteAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (GophKeeper_WatchChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[0], GophKeeper_WatchChanges_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperWatchChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeper_WatchChangesClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type gophKeeperWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperWatchChangesClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (GophKeeper_UploadAttachmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[1], GophKeeper_UploadAttachment_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperUploadAttachmentClient{stream}
+	return x, nil
+}
+
+type GophKeeper_UploadAttachmentClient interface {
+	Send(*UploadAttachmentRequest) error
+	CloseAndRecv() (*UploadAttachmentResponce, error)
+	grpc.ClientStream
+}
+
+type gophKeeperUploadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperUploadAttachmentClient) Send(m *UploadAttachmentRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gophKeeperUploadAttachmentClient) CloseAndRecv() (*UploadAttachmentResponce, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadAttachmentResponce)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (GophKeeper_DownloadAttachmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[2], GophKeeper_DownloadAttachment_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperDownloadAttachmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeper_DownloadAttachmentClient interface {
+	Recv() (*AttachmentChunk, error)
+	grpc.ClientStream
+}
+
+type gophKeeperDownloadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperDownloadAttachmentClient) Recv() (*AttachmentChunk, error) {
+	m := new(AttachmentChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponce, error) {
+	out := new(DeleteAttachmentResponce)
+	err := c.cc.Invoke(ctx, GophKeeper_DeleteAttachment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GophKeeperServer is the server API for GophKeeper service.
 // All implementations must embed UnimplementedGophKeeperServer
 // for forward compatibility
 type GophKeeperServer interface {
 	NewSessionID(context.Context, *NewSessionIDRequest) (*NewSessionIDResponce, error)
+	KeyParams(context.Context, *KeyParamsRequest) (*KeyParamsResponce, error)
 	NewUser(context.Context, *NewUserRequest) (*NewUserResponce, error)
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponce, error)
 	UserData(context.Context, *UserDataRequest) (*UserDataResponce, error)
 	TimeStamp(context.Context, *TimeStampRequest) (*TimeStampResponce, error)
 	DataLock(context.Context, *DataLockRequest) (*DataLockResponce, error)
+	ReleaseLock(context.Context, *ReleaseLockRequest) (*ReleaseLockResponce, error)
+	RenewLock(context.Context, *RenewLockRequest) (*RenewLockResponce, error)
 	UpdateData(context.Context, *UpdateDataRequest) (*UpdateDataResponce, error)
 	LogOut(context.Context, *LogOutRequest) (*LogOutResponce, error)
 	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponce, error)
+	CreateRecord(context.Context, *CreateRecordRequest) (*RecordResponce, error)
+	UpdateRecord(context.Context, *UpdateRecordRequest) (*RecordResponce, error)
+	DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponce, error)
+	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponce, error)
+	GetRecord(context.Context, *GetRecordRequest) (*RecordResponce, error)
+	Sync(context.Context, *SyncRequest) (*SyncResponce, error)
+	ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponce, error)
+	RestoreVersion(context.Context, *RestoreVersionRequest) (*RestoreVersionResponce, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponce, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponce, error)
+	EnableTOTP(context.Context, *EnableTOTPRequest) (*EnableTOTPResponce, error)
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponce, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponce, error)
+	WatchChanges(*WatchChangesRequest, GophKeeper_WatchChangesServer) error
+	UploadAttachment(GophKeeper_UploadAttachmentServer) error
+	DownloadAttachment(*DownloadAttachmentRequest, GophKeeper_DownloadAttachmentServer) error
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponce, error)
 	mustEmbedUnimplementedGophKeeperServer()
 }
 
@@ -157,6 +468,9 @@ type UnimplementedGophKeeperServer struct {
 func (UnimplementedGophKeeperServer) NewSessionID(context.Context, *NewSessionIDRequest) (*NewSessionIDResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewSessionID not implemented")
 }
+func (UnimplementedGophKeeperServer) KeyParams(context.Context, *KeyParamsRequest) (*KeyParamsResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeyParams not implemented")
+}
 func (UnimplementedGophKeeperServer) NewUser(context.Context, *NewUserRequest) (*NewUserResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewUser not implemented")
 }
@@ -172,6 +486,12 @@ func (UnimplementedGophKeeperServer) TimeStamp(context.Context, *TimeStampReques
 func (UnimplementedGophKeeperServer) DataLock(context.Context, *DataLockRequest) (*DataLockResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DataLock not implemented")
 }
+func (UnimplementedGophKeeperServer) ReleaseLock(context.Context, *ReleaseLockRequest) (*ReleaseLockResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLock not implemented")
+}
+func (UnimplementedGophKeeperServer) RenewLock(context.Context, *RenewLockRequest) (*RenewLockResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLock not implemented")
+}
 func (UnimplementedGophKeeperServer) UpdateData(context.Context, *UpdateDataRequest) (*UpdateDataResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
 }
@@ -181,6 +501,57 @@ func (UnimplementedGophKeeperServer) LogOut(context.Context, *LogOutRequest) (*L
 func (UnimplementedGophKeeperServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
 }
+func (UnimplementedGophKeeperServer) CreateRecord(context.Context, *CreateRecordRequest) (*RecordResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
+}
+func (UnimplementedGophKeeperServer) UpdateRecord(context.Context, *UpdateRecordRequest) (*RecordResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecord not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecord not implemented")
+}
+func (UnimplementedGophKeeperServer) ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
+}
+func (UnimplementedGophKeeperServer) GetRecord(context.Context, *GetRecordRequest) (*RecordResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
+}
+func (UnimplementedGophKeeperServer) Sync(context.Context, *SyncRequest) (*SyncResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedGophKeeperServer) ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHistory not implemented")
+}
+func (UnimplementedGophKeeperServer) RestoreVersion(context.Context, *RestoreVersionRequest) (*RestoreVersionResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVersion not implemented")
+}
+func (UnimplementedGophKeeperServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedGophKeeperServer) EnableTOTP(context.Context, *EnableTOTPRequest) (*EnableTOTPResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTOTP not implemented")
+}
+func (UnimplementedGophKeeperServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedGophKeeperServer) WatchChanges(*WatchChangesRequest, GophKeeper_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedGophKeeperServer) UploadAttachment(GophKeeper_UploadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedGophKeeperServer) DownloadAttachment(*DownloadAttachmentRequest, GophKeeper_DownloadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
 func (UnimplementedGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {}
 
 // UnsafeGophKeeperServer may be embedded to opt out of forward compatibility for this service.
@@ -212,6 +583,24 @@ func _GophKeeper_NewSessionID_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeper_KeyParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).KeyParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_KeyParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).KeyParams(ctx, req.(*KeyParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GophKeeper_NewUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewUserRequest)
 	if err := dec(in); err != nil {
@@ -302,6 +691,42 @@ func _GophKeeper_DataLock_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeper_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ReleaseLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ReleaseLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ReleaseLock(ctx, req.(*ReleaseLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RenewLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RenewLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RenewLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RenewLock(ctx, req.(*RenewLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GophKeeper_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateDataRequest)
 	if err := dec(in); err != nil {
@@ -356,6 +781,326 @@ func _GophKeeper_ChangePassword_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeper_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateRecord(ctx, req.(*CreateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UpdateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).UpdateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_UpdateRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).UpdateRecord(ctx, req.(*UpdateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteRecord(ctx, req.(*DeleteRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListRecords(ctx, req.(*ListRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetRecord(ctx, req.(*GetRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_Sync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListHistory(ctx, req.(*ListHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RestoreVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RestoreVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RestoreVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RestoreVersion(ctx, req.(*RestoreVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_EnableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).EnableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_EnableTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).EnableTOTP(ctx, req.(*EnableTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DisableTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DisableTOTP(ctx, req.(*DisableTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServer).WatchChanges(m, &gophKeeperWatchChangesServer{stream})
+}
+
+type GophKeeper_WatchChangesServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type gophKeeperWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperWatchChangesServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeper_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GophKeeperServer).UploadAttachment(&gophKeeperUploadAttachmentServer{stream})
+}
+
+type GophKeeper_UploadAttachmentServer interface {
+	SendAndClose(*UploadAttachmentResponce) error
+	Recv() (*UploadAttachmentRequest, error)
+	grpc.ServerStream
+}
+
+type gophKeeperUploadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperUploadAttachmentServer) SendAndClose(m *UploadAttachmentResponce) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gophKeeperUploadAttachmentServer) Recv() (*UploadAttachmentRequest, error) {
+	m := new(UploadAttachmentRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GophKeeper_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServer).DownloadAttachment(m, &gophKeeperDownloadAttachmentServer{stream})
+}
+
+type GophKeeper_DownloadAttachmentServer interface {
+	Send(*AttachmentChunk) error
+	grpc.ServerStream
+}
+
+type gophKeeperDownloadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperDownloadAttachmentServer) Send(m *AttachmentChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeper_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GophKeeper_ServiceDesc is the grpc.ServiceDesc for GophKeeper service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -367,6 +1112,10 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NewSessionID",
 			Handler:    _GophKeeper_NewSessionID_Handler,
 		},
+		{
+			MethodName: "KeyParams",
+			Handler:    _GophKeeper_KeyParams_Handler,
+		},
 		{
 			MethodName: "NewUser",
 			Handler:    _GophKeeper_NewUser_Handler,
@@ -387,6 +1136,14 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DataLock",
 			Handler:    _GophKeeper_DataLock_Handler,
 		},
+		{
+			MethodName: "ReleaseLock",
+			Handler:    _GophKeeper_ReleaseLock_Handler,
+		},
+		{
+			MethodName: "RenewLock",
+			Handler:    _GophKeeper_RenewLock_Handler,
+		},
 		{
 			MethodName: "UpdateData",
 			Handler:    _GophKeeper_UpdateData_Handler,
@@ -399,7 +1156,79 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _GophKeeper_ChangePassword_Handler,
 		},
+		{
+			MethodName: "CreateRecord",
+			Handler:    _GophKeeper_CreateRecord_Handler,
+		},
+		{
+			MethodName: "UpdateRecord",
+			Handler:    _GophKeeper_UpdateRecord_Handler,
+		},
+		{
+			MethodName: "DeleteRecord",
+			Handler:    _GophKeeper_DeleteRecord_Handler,
+		},
+		{
+			MethodName: "ListRecords",
+			Handler:    _GophKeeper_ListRecords_Handler,
+		},
+		{
+			MethodName: "GetRecord",
+			Handler:    _GophKeeper_GetRecord_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _GophKeeper_Sync_Handler,
+		},
+		{
+			MethodName: "ListHistory",
+			Handler:    _GophKeeper_ListHistory_Handler,
+		},
+		{
+			MethodName: "RestoreVersion",
+			Handler:    _GophKeeper_RestoreVersion_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _GophKeeper_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _GophKeeper_RevokeSession_Handler,
+		},
+		{
+			MethodName: "EnableTOTP",
+			Handler:    _GophKeeper_EnableTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _GophKeeper_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _GophKeeper_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _GophKeeper_DeleteAttachment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _GophKeeper_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _GophKeeper_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _GophKeeper_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/grpc.proto",
 }
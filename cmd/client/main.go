@@ -13,12 +13,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"gophkeeper/internal/client/cli"
 	"gophkeeper/internal/client/config"
 	"gophkeeper/internal/client/crypto"
 	"gophkeeper/internal/client/interceptor"
 	"gophkeeper/internal/client/menu"
 	"gophkeeper/internal/client/sender"
 	"gophkeeper/internal/client/storage"
+	"gophkeeper/internal/client/tui"
 	"gophkeeper/internal/logger"
 )
 
@@ -64,11 +66,38 @@ func main() {
 
 	auth := interceptor.NewAuthClient(rsa)
 
-	conn, err := grpc.Dial(cnfg.RunAddress, grpc.WithTransportCredentials(credsTLS), grpc.WithUnaryInterceptor(auth.Unary()))
+	conn, err := grpc.Dial(cnfg.RunAddress, grpc.WithTransportCredentials(credsTLS), grpc.WithUnaryInterceptor(auth.Unary()), grpc.WithStreamInterceptor(auth.Stream()))
 	if err != nil {
 		log.Fatal().Err(err).Msg("gRPC connection error")
 	}
 	sndr := sender.NewGophKeeperClient(conn, rsa, strg)
+	sndr.Version = buildVersion
+	sndr.Device, err = os.Hostname()
+	if err != nil {
+		log.Error().Err(err).Msg("Hostname reading error")
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Error().Err(err).Msg("User cache directory error")
+	} else {
+		sndr.CacheDir = filepath.Join(cacheDir, "gophkeeper")
+	}
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		code := cli.NewApp(&sndr).Run(os.Args[1:])
+		if logfile != nil {
+			logfile.Close()
+		}
+		os.Exit(code)
+	}
+	ui, err := tui.New(&sndr)
+	if err == nil {
+		ui.Run()
+		if logfile != nil {
+			logfile.Close()
+		}
+		return
+	}
+	log.Info().Err(err).Msg("Full-screen interface isn't available, starting text menu")
 	fmt.Printf("Менеджер паролей GophKeeper. Версия клиента: %s, Дата сборки: %s\n", buildVersion, buildDate)
 	fmt.Println("Клиент запущен, устанавливаю соединение с сервером")
 	for {
@@ -36,7 +36,16 @@ func main() {
 		log.Fatal().Err(err).Msg("NewStorage starting DB error")
 	}
 	log.Debug().Msg("storage init")
-	rsa := crypto.NewSessions(cnfg)
+	var sessions crypto.SessionStore = crypto.NewMemoryStore()
+	if cnfg.SessionStore == "postgres" {
+		pgSessions, err := storage.NewSessionStore(cnfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("NewSessionStore starting DB error")
+		}
+		defer pgSessions.CloseDB()
+		sessions = pgSessions
+	}
+	rsa := crypto.NewSessions(cnfg, sessions)
 	gRPCconf := handler.NewGophKeeperServer(cnfg, strg, rsa)
 	log.Debug().Msg("handler init")
 	listen, err := net.Listen("tcp", cnfg.RunAddress)
@@ -67,7 +76,7 @@ func main() {
 	}
 	creds := credentials.NewTLS(configTLS)
 	interceptor := interceptor.NewAuthInterceptor(rsa)
-	s := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()), grpc.Creds(creds))
+	s := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()), grpc.StreamInterceptor(interceptor.Stream()), grpc.Creds(creds))
 	proto.RegisterGophKeeperServer(s, gRPCconf)
 	reflection.Register(s)
 	go func() {
@@ -79,6 +88,7 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	<-sigChan
 	log.Info().Msgf("OS cmd received stop signal")
+	gRPCconf.StopWatching()
 	s.GracefulStop()
 	strg.CloseDB()
 }
@@ -0,0 +1,247 @@
+// Модуль предназначен для выполнения команд клиента без интерактивного меню, например из скриптов CI.
+// Каждая команда открывает отдельную сессию: авторизует пользователя, получает записи с сервера,
+// выполняет действие, синхронизирует изменения и завершает сессию.
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"gophkeeper/internal/client/sender"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// Переменные окружения, из которых читаются учетные данные, если они не переданы через стандартный ввод.
+const (
+	EnvLogin    = "GOPHKEEPER_LOGIN"    //Логин пользователя
+	EnvPassword = "GOPHKEEPER_PASSWORD" //Пароль пользователя
+	EnvTOTP     = "GOPHKEEPER_TOTP"     //Код двухфакторной аутентификации или код восстановления
+	EnvSecret   = "GOPHKEEPER_SECRET"   //Пароль, сохраняемый в записи командами add и edit
+)
+
+// Коды завершения команд.
+const (
+	ExitOK    = 0 //Команда выполнена
+	ExitError = 1 //Ошибка выполнения команды
+	ExitUsage = 2 //Неверные аргументы команды
+)
+
+// command структура описывает команду клиента.
+type command struct {
+	usage string                     //Синтаксис команды
+	about string                     //Описание команды
+	run   func(*App, []string) error //Выполнение команды с аргументами после ее имени
+}
+
+// commands команды клиента по имени.
+var commands = map[string]command{
+	"login": {usage: "login [флаги авторизации]", about: "проверить учетные данные", run: (*App).login},
+	"list":  {usage: "list [--type тип] [--json]", about: "вывести список записей", run: (*App).list},
+	"get":   {usage: "get <имя|id> [--type тип] [--field поле] [--json]", about: "вывести запись или одно ее поле", run: (*App).get},
+	"add":   {usage: "add <password|card|text|binary> --name имя [поля] [--json]", about: "добавить запись", run: (*App).add},
+	"edit":  {usage: "edit <имя|id> [--type тип] [поля] [--json]", about: "изменить поля записи", run: (*App).edit},
+	"rm":    {usage: "rm <имя|id> [--type тип] [--json]", about: "удалить запись", run: (*App).rm},
+	"sync":  {usage: "sync [--json]", about: "синхронизировать записи с сервером", run: (*App).sync},
+}
+
+// commandOrder порядок вывода команд в справке.
+var commandOrder = []string{"login", "list", "get", "add", "edit", "rm", "sync"}
+
+// errUsage ошибка неверных аргументов команды.
+var errUsage = errors.New("invalid command arguments")
+
+// App структура выполняет команды клиента через переданного клиента gRPC.
+type App struct {
+	sndr         *sender.GophKeeperClient
+	stdin        *bufio.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+	getenv       func(string) string
+	terminal     bool                   //Стандартный ввод подключен к терминалу, учетные данные можно запросить у пользователя
+	readTerminal func() (string, error) //Чтение строки из терминала без отображения вводимых символов
+}
+
+// NewApp функция создает исполнитель команд, работающий со стандартными потоками процесса и переменными окружения.
+func NewApp(sndr *sender.GophKeeperClient) *App {
+	fd := int(os.Stdin.Fd())
+	return &App{
+		sndr:     sndr,
+		stdin:    bufio.NewReader(os.Stdin),
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+		getenv:   os.Getenv,
+		terminal: term.IsTerminal(fd),
+		readTerminal: func() (string, error) {
+			bz, err := term.ReadPassword(fd)
+			return string(bz), err
+		},
+	}
+}
+
+// IsCommand функция сообщает, что аргумент является именем команды клиента.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok || name == "help" || name == "-h" || name == "--help"
+}
+
+// Run метод выполняет команду, переданную в аргументах командной строки, и возвращает код завершения процесса.
+func (a *App) Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.usage()
+		return ExitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "Неизвестная команда %s\n", args[0])
+		a.usage()
+		return ExitUsage
+	}
+	err := cmd.run(a, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(a.stderr, "Использование: gophkeeper %s\n", cmd.usage)
+		return ExitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(a.stderr, "Ошибка: %s\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// usage метод выводит справку по командам клиента.
+func (a *App) usage() {
+	fmt.Fprintln(a.stderr, "Использование: gophkeeper <команда> [аргументы]. Без команды запускается интерактивное меню.")
+	fmt.Fprintln(a.stderr, "Команды:")
+	for _, name := range commandOrder {
+		fmt.Fprintf(a.stderr, "  %-60s %s\n", commands[name].usage, commands[name].about)
+	}
+	fmt.Fprintln(a.stderr, "Флаги авторизации: --login логин, --password-stdin, --totp код.")
+	fmt.Fprintf(a.stderr, "Учетные данные также читаются из переменных окружения %s, %s, %s или запрашиваются в терминале.\n", EnvLogin, EnvPassword, EnvTOTP)
+}
+
+// authFlags структура хранит флаги авторизации, общие для всех команд.
+type authFlags struct {
+	login         string
+	passwordStdin bool
+	totp          string
+	json          bool
+}
+
+// newFlagSet метод создает набор флагов команды с флагами авторизации и вывода.
+func (a *App) newFlagSet(name string, auth *authFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&auth.login, "login", "", "логин пользователя, по умолчанию "+EnvLogin)
+	fs.BoolVar(&auth.passwordStdin, "password-stdin", false, "прочитать пароль пользователя из первой строки стандартного ввода")
+	fs.StringVar(&auth.totp, "totp", "", "код двухфакторной аутентификации, по умолчанию "+EnvTOTP)
+	fs.BoolVar(&auth.json, "json", false, "вывести результат в формате JSON")
+	return fs
+}
+
+// parseArgs функция разбирает флаги команды, которые могут стоять как до, так и после позиционных аргументов.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		err := fs.Parse(args)
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// authenticate метод открывает сессию на сервере и авторизует пользователя.
+// Возвращаемая функция завершает сессию.
+func (a *App) authenticate(auth authFlags) (func(), error) {
+	login := auth.login
+	if login == "" {
+		login = a.getenv(EnvLogin)
+	}
+	if login == "" && a.terminal {
+		fmt.Fprint(a.stderr, "Логин: ")
+		line, err := a.readLine()
+		if err != nil {
+			return nil, err
+		}
+		login = line
+	}
+	if login == "" {
+		return nil, fmt.Errorf("%w: set --login or %s", gkerrors.ErrNoCredentials, EnvLogin)
+	}
+	password, err := a.readSecret("Пароль: ", auth.passwordStdin, EnvPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сообщения клиента gRPC выводятся в поток ошибок, чтобы не смешиваться с результатом команды
+	a.sndr.Out = a.stderr
+	err = a.sndr.ReqSessionID()
+	if err != nil {
+		return nil, err
+	}
+	err = a.sndr.UserLogin(login, password)
+	if errors.Is(err, gkerrors.ErrTOTPRequired) {
+		code := auth.totp
+		if code == "" {
+			code, err = a.readSecret("Код двухфакторной аутентификации: ", false, EnvTOTP)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = a.sndr.UserLoginTOTP(login, password, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a.sndr.UserLogOut, nil
+}
+
+// readSecret метод читает секрет из стандартного ввода, переменной окружения env или терминала без отображения символов.
+func (a *App) readSecret(prompt string, fromStdin bool, env string) (string, error) {
+	if fromStdin {
+		return a.readLine()
+	}
+	if secret := a.getenv(env); secret != "" {
+		return secret, nil
+	}
+	if !a.terminal {
+		return "", fmt.Errorf("%w: set %s or use stdin", gkerrors.ErrNoCredentials, env)
+	}
+	fmt.Fprint(a.stderr, prompt)
+	secret, err := a.readTerminal()
+	fmt.Fprintln(a.stderr)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", gkerrors.ErrNoCredentials
+	}
+	return secret, nil
+}
+
+// readLine метод читает одну строку стандартного ввода без символов перевода строки.
+func (a *App) readLine() (string, error) {
+	line, err := a.stdin.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("%w: stdin is empty", gkerrors.ErrNoCredentials)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// newTestApp функция создает исполнитель команд без подключения к серверу с заданными стандартным вводом и окружением.
+func newTestApp(stdin string, env map[string]string) (*App, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	return &App{
+		stdin:  bufio.NewReader(strings.NewReader(stdin)),
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(key string) string { return env[key] },
+	}, stdout, stderr
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "Справка", args: []string{"help"}, want: ExitOK},
+		{name: "Неизвестная команда", args: []string{"unknown"}, want: ExitUsage},
+		{name: "get без имени записи", args: []string{"get"}, want: ExitUsage},
+		{name: "get с неизвестным типом", args: []string{"get", "mail", "--type", "mail"}, want: ExitUsage},
+		{name: "add без имени записи", args: []string{"add", "password"}, want: ExitUsage},
+		{name: "add двоичных данных без файла", args: []string{"add", "binary"}, want: ExitUsage},
+		{name: "edit без изменяемых полей", args: []string{"edit", "mail"}, want: ExitUsage},
+		{name: "Неизвестный флаг", args: []string{"list", "--unknown"}, want: ExitUsage},
+		{name: "Справка по команде", args: []string{"list", "-h"}, want: ExitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, stdout, _ := newTestApp("", nil)
+			require.Equal(t, tt.want, app.Run(tt.args))
+			require.Empty(t, stdout.String())
+		})
+	}
+	require.True(t, IsCommand("get"))
+	require.False(t, IsCommand("unknown"))
+}
+
+func TestParseArgs(t *testing.T) {
+	var auth authFlags
+	var field string
+	app, _, _ := newTestApp("", nil)
+	fs := app.newFlagSet("get", &auth)
+	fs.StringVar(&field, "field", "", "")
+	positional, err := parseArgs(fs, []string{"--login", "user", "mail", "--field", "pass", "--json"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"mail"}, positional)
+	require.Equal(t, "user", auth.login)
+	require.Equal(t, "pass", field)
+	require.True(t, auth.json)
+
+	_, err = parseArgs(fs, []string{"-h"})
+	require.ErrorIs(t, err, flag.ErrHelp)
+	_, err = parseArgs(fs, []string{"--field"})
+	require.ErrorIs(t, err, errUsage)
+}
+
+func TestReadSecret(t *testing.T) {
+	// Пароль пользователя и пароль записи читаются из последовательных строк стандартного ввода
+	app, _, _ := newTestApp("password\r\nsecret", map[string]string{EnvPassword: "env password"})
+	secret, err := app.readSecret("", true, EnvPassword)
+	require.NoError(t, err)
+	require.Equal(t, "password", secret)
+	secret, err = app.readSecret("", true, EnvSecret)
+	require.NoError(t, err)
+	require.Equal(t, "secret", secret)
+	_, err = app.readSecret("", true, EnvSecret)
+	require.ErrorIs(t, err, gkerrors.ErrNoCredentials)
+
+	// Без стандартного ввода секрет читается из переменной окружения
+	secret, err = app.readSecret("", false, EnvPassword)
+	require.NoError(t, err)
+	require.Equal(t, "env password", secret)
+
+	// Без терминала и переменной окружения секрет не запрашивается
+	_, err = app.readSecret("", false, EnvTOTP)
+	require.ErrorIs(t, err, gkerrors.ErrNoCredentials)
+
+	// В терминале секрет запрашивается у пользователя
+	app.terminal = true
+	app.readTerminal = func() (string, error) { return "123456", nil }
+	secret, err = app.readSecret("Код: ", false, EnvTOTP)
+	require.NoError(t, err)
+	require.Equal(t, "123456", secret)
+
+	// Без логина авторизация не начинается
+	app.terminal = false
+	_, err = app.authenticate(authFlags{})
+	require.ErrorIs(t, err, gkerrors.ErrNoCredentials)
+}
+
+func TestFindEntry(t *testing.T) {
+	strg := storage.NewUserStorage()
+	strg.AddUsersPassword(&storage.Password{Name: "mail", Login: "user", Pass: "secret"})
+	strg.AddUsersText(&storage.Text{Name: "mail", Data: "note"})
+	strg.AddUsersCard(&storage.Card{Name: "bank", CardNumber: "1234"})
+	strg.AddUsersBinary(&storage.Binary{Name: "key.bin", Data: []byte{0, 1, 2}})
+
+	list := entries(strg)
+	require.Len(t, list, 4)
+	require.Equal(t, records.TypeBinary, list[0].Type)
+	require.Equal(t, records.TypeText, list[3].Type)
+
+	// Одинаковые имена различаются по типу или идентификатору
+	_, err := findEntry(strg, "mail", "")
+	require.ErrorIs(t, err, gkerrors.ErrRecordAmbiguous)
+	e, err := findEntry(strg, "mail", records.TypePassword)
+	require.NoError(t, err)
+	val, err := fieldValue(e, "pass")
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(val))
+	text := strg.SliceUsersTexts()[0]
+	e, err = findEntry(strg, text.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, "note", e.Fields["data"])
+
+	_, err = findEntry(strg, "bank", records.TypeText)
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchRecord)
+
+	// Двоичные данные выводятся без кодирования
+	e, err = findEntry(strg, "key.bin", "")
+	require.NoError(t, err)
+	val, err = fieldValue(e, "data")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 2}, val)
+	require.Equal(t, "AAEC", e.Fields["data"])
+	_, err = fieldValue(e, "number")
+	require.Error(t, err)
+}
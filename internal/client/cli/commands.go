@@ -0,0 +1,435 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"gophkeeper/internal/client/storage"
+	"gophkeeper/internal/records"
+)
+
+// fieldFlags структура хранит флаги полей записи для команд add и edit.
+type fieldFlags struct {
+	name      string
+	login     string
+	comment   string
+	number    string
+	data      string
+	file      string
+	passStdin bool
+	setPass   bool
+}
+
+// addFieldFlags функция добавляет в набор флаги полей записи.
+func addFieldFlags(fs *flag.FlagSet, fields *fieldFlags) {
+	fs.StringVar(&fields.name, "name", "", "имя записи")
+	fs.StringVar(&fields.login, "user", "", "логин в записи с паролем")
+	fs.StringVar(&fields.comment, "comment", "", "примечание")
+	fs.StringVar(&fields.number, "number", "", "номер карты")
+	fs.StringVar(&fields.data, "data", "", "текст записи, - для чтения из стандартного ввода")
+	fs.StringVar(&fields.file, "file", "", "путь к файлу двоичной записи, файл больше 64kB загружается на сервер вложением")
+	fs.BoolVar(&fields.passStdin, "pass-stdin", false, "прочитать пароль записи из стандартного ввода, по умолчанию "+EnvSecret)
+	fs.BoolVar(&fields.setPass, "set-pass", false, "изменить пароль записи командой edit")
+}
+
+// session метод авторизует пользователя и получает с сервера его записи.
+// Возвращаемая функция завершает сессию.
+func (a *App) session(auth authFlags) (func(), error) {
+	logout, err := a.authenticate(auth)
+	if err != nil {
+		return nil, err
+	}
+	_, err = a.sndr.Sync()
+	if err != nil {
+		logout()
+		return nil, err
+	}
+	return logout, nil
+}
+
+// output метод выводит результат команды в формате JSON или текстом.
+func (a *App) output(asJSON bool, v any, text string) error {
+	if asJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	_, err := fmt.Fprintln(a.stdout, text)
+	return err
+}
+
+// changeResult структура описывает результат команд, изменяющих записи.
+type changeResult struct {
+	ID        string `json:"id"`        //Идентификатор измененной записи
+	Conflicts int    `json:"conflicts"` //Количество конфликтов при синхронизации с сервером
+}
+
+// saveChanges метод отправляет изменения на сервер и выводит результат.
+func (a *App) saveChanges(asJSON bool, id, text string) error {
+	conflicts, err := a.sndr.Sync()
+	if err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		text = fmt.Sprintf("%s, конфликтов при синхронизации: %d", text, conflicts)
+	}
+	return a.output(asJSON, changeResult{ID: id, Conflicts: conflicts}, text)
+}
+
+// login команда проверяет учетные данные пользователя.
+func (a *App) login(args []string) error {
+	var auth authFlags
+	fs := a.newFlagSet("login", &auth)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return errUsage
+	}
+	logout, err := a.authenticate(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+	return a.output(auth.json, map[string]bool{"authenticated": true}, "Авторизация выполнена")
+}
+
+// list команда выводит список записей без значений полей.
+func (a *App) list(args []string) error {
+	var auth authFlags
+	var recordType string
+	fs := a.newFlagSet("list", &auth)
+	fs.StringVar(&recordType, "type", "", "тип записей: password, card, text или binary")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 || !validType(recordType) {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+
+	list := make([]entry, 0)
+	for _, e := range entries(a.sndr.Strg) {
+		if recordType != "" && e.Type != recordType {
+			continue
+		}
+		e.Fields = nil
+		list = append(list, e)
+	}
+	if auth.json {
+		return a.output(true, list, "")
+	}
+	for _, e := range list {
+		fmt.Fprintf(a.stdout, "%s\t%s\t%s\n", e.Type, e.ID, e.Name)
+	}
+	return nil
+}
+
+// get команда выводит запись или значение одного ее поля.
+func (a *App) get(args []string) error {
+	var auth authFlags
+	var recordType, field string
+	fs := a.newFlagSet("get", &auth)
+	fs.StringVar(&recordType, "type", "", "тип записи: password, card, text или binary")
+	fs.StringVar(&field, "field", "", "вывести только значение поля")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || !validType(recordType) {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+
+	e, err := findEntry(a.sndr.Strg, positional[0], recordType)
+	if err != nil {
+		return err
+	}
+	if field != "" {
+		val, err := fieldValue(e, field)
+		if err != nil {
+			return err
+		}
+		binary := e.Type == records.TypeBinary && field == "data"
+		if binary && e.Fields["attachment"] != "" {
+			return a.attachmentData(e, auth.json)
+		}
+		if auth.json {
+			value := string(val)
+			if binary {
+				value = e.Fields[field]
+			}
+			return a.output(true, map[string]string{"value": value}, "")
+		}
+		if binary {
+			_, err = a.stdout.Write(val)
+			return err
+		}
+		_, err = fmt.Fprintln(a.stdout, string(val))
+		return err
+	}
+	if auth.json {
+		return a.output(true, e, "")
+	}
+	fmt.Fprintf(a.stdout, "id: %s\ntype: %s\nname: %s\n", e.ID, e.Type, e.Name)
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(a.stdout, "%s: %s\n", name, e.Fields[name])
+	}
+	return nil
+}
+
+// add команда добавляет запись и сохраняет ее на сервере.
+func (a *App) add(args []string) error {
+	var auth authFlags
+	var fields fieldFlags
+	fs := a.newFlagSet("add", &auth)
+	addFieldFlags(fs, &fields)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || positional[0] == "" || !validType(positional[0]) {
+		return errUsage
+	}
+	recordType := positional[0]
+	if fields.name == "" && recordType != records.TypeBinary || fields.file == "" && recordType == records.TypeBinary {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+
+	var id string
+	switch recordType {
+	case records.TypePassword:
+		pass := storage.Password{Name: fields.name, Login: fields.login, Comment: fields.comment}
+		pass.Pass, err = a.readSecret("Пароль записи: ", fields.passStdin, EnvSecret)
+		if err != nil {
+			return err
+		}
+		a.sndr.Strg.AddUsersPassword(&pass)
+		id = pass.ID
+	case records.TypeCard:
+		card := storage.Card{Name: fields.name, CardNumber: fields.number, Comment: fields.comment}
+		a.sndr.Strg.AddUsersCard(&card)
+		id = card.ID
+	case records.TypeText:
+		text := storage.Text{Name: fields.name, Comment: fields.comment}
+		text.Data, err = a.textData(fields.data)
+		if err != nil {
+			return err
+		}
+		a.sndr.Strg.AddUsersText(&text)
+		id = text.ID
+	case records.TypeBinary:
+		binary := storage.Binary{Name: fields.name, Comment: fields.comment}
+		err = a.sndr.ReadBinary(fields.file, &binary)
+		if err != nil {
+			return err
+		}
+		a.sndr.Strg.AddUsersBinary(&binary)
+		id = binary.ID
+	}
+	return a.saveChanges(auth.json, id, "Запись добавлена: "+id)
+}
+
+// edit команда изменяет поля записи, переданные флагами, и сохраняет запись на сервере.
+func (a *App) edit(args []string) error {
+	var auth authFlags
+	var fields fieldFlags
+	var recordType string
+	fs := a.newFlagSet("edit", &auth)
+	fs.StringVar(&recordType, "type", "", "тип записи: password, card, text или binary")
+	addFieldFlags(fs, &fields)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	if fields.passStdin {
+		set["set-pass"] = true
+	}
+	changes := 0
+	for _, name := range []string{"name", "user", "comment", "number", "data", "file", "set-pass"} {
+		if set[name] {
+			changes++
+		}
+	}
+	if len(positional) != 1 || !validType(recordType) || changes == 0 {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+
+	e, err := findEntry(a.sndr.Strg, positional[0], recordType)
+	if err != nil {
+		return err
+	}
+	switch e.Type {
+	case records.TypePassword:
+		pass := *a.sndr.Strg.StringUsersPassword(e.index)
+		setString(set, "name", &pass.Name, fields.name)
+		setString(set, "user", &pass.Login, fields.login)
+		setString(set, "comment", &pass.Comment, fields.comment)
+		if set["set-pass"] {
+			pass.Pass, err = a.readSecret("Пароль записи: ", fields.passStdin, EnvSecret)
+			if err != nil {
+				return err
+			}
+		}
+		a.sndr.Strg.EditUsersPassword(e.index, &pass)
+	case records.TypeCard:
+		card := *a.sndr.Strg.StringUsersCard(e.index)
+		setString(set, "name", &card.Name, fields.name)
+		setString(set, "number", &card.CardNumber, fields.number)
+		setString(set, "comment", &card.Comment, fields.comment)
+		a.sndr.Strg.EditUsersCard(e.index, &card)
+	case records.TypeText:
+		text := *a.sndr.Strg.StringUsersText(e.index)
+		setString(set, "name", &text.Name, fields.name)
+		setString(set, "comment", &text.Comment, fields.comment)
+		if set["data"] {
+			text.Data, err = a.textData(fields.data)
+			if err != nil {
+				return err
+			}
+		}
+		a.sndr.Strg.EditUsersText(e.index, &text)
+	case records.TypeBinary:
+		binary := *a.sndr.Strg.StringUsersBinary(e.index)
+		if set["file"] {
+			err = a.sndr.ReadBinary(fields.file, &binary)
+			if err != nil {
+				return err
+			}
+		}
+		setString(set, "name", &binary.Name, fields.name)
+		setString(set, "comment", &binary.Comment, fields.comment)
+		a.sndr.Strg.EditUsersBinary(e.index, &binary)
+	}
+	return a.saveChanges(auth.json, e.ID, "Запись изменена: "+e.ID)
+}
+
+// rm команда удаляет запись на сервере.
+func (a *App) rm(args []string) error {
+	var auth authFlags
+	var recordType string
+	fs := a.newFlagSet("rm", &auth)
+	fs.StringVar(&recordType, "type", "", "тип записи: password, card, text или binary")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || !validType(recordType) {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+
+	e, err := findEntry(a.sndr.Strg, positional[0], recordType)
+	if err != nil {
+		return err
+	}
+	switch e.Type {
+	case records.TypePassword:
+		a.sndr.Strg.DeleteUsersPassword(e.index)
+	case records.TypeCard:
+		a.sndr.Strg.DeleteUsersCard(e.index)
+	case records.TypeText:
+		a.sndr.Strg.DeleteUsersText(e.index)
+	case records.TypeBinary:
+		a.sndr.Strg.DeleteUsersBinary(e.index)
+	}
+	return a.saveChanges(auth.json, e.ID, "Запись удалена: "+e.ID)
+}
+
+// syncResult структура описывает результат команды sync.
+type syncResult struct {
+	Records  int   `json:"records"`  //Количество записей пользователя
+	Revision int64 `json:"revision"` //Ревизия записей на сервере
+}
+
+// sync команда синхронизирует записи с сервером и выводит их количество и ревизию.
+func (a *App) sync(args []string) error {
+	var auth authFlags
+	fs := a.newFlagSet("sync", &auth)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return errUsage
+	}
+	logout, err := a.session(auth)
+	if err != nil {
+		return err
+	}
+	defer logout()
+	res := syncResult{Records: len(entries(a.sndr.Strg)), Revision: a.sndr.Strg.Revision}
+	return a.output(auth.json, res, fmt.Sprintf("Записи синхронизированы: %d, ревизия %d", res.Records, res.Revision))
+}
+
+// setString функция присваивает полю значение флага, если флаг передан.
+func setString(set map[string]bool, name string, field *string, value string) {
+	if set[name] {
+		*field = value
+	}
+}
+
+// textData метод возвращает текст записи из флага или, если флаг равен "-", из стандартного ввода.
+func (a *App) textData(data string) (string, error) {
+	if data != "-" {
+		return data, nil
+	}
+	bz, err := io.ReadAll(a.stdin)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// attachmentData метод скачивает с сервера вложение двоичной записи и выводит его без кодирования
+// или, с флагом --json, в кодировке base64.
+func (a *App) attachmentData(e entry, jsonOut bool) error {
+	binary := *a.sndr.Strg.StringUsersBinary(e.index)
+	if !jsonOut {
+		return a.sndr.WriteBinary(binary, a.stdout)
+	}
+	var buf bytes.Buffer
+	err := a.sndr.WriteBinary(binary, &buf)
+	if err != nil {
+		return err
+	}
+	return a.output(true, map[string]string{"value": base64.StdEncoding.EncodeToString(buf.Bytes())}, "")
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// entry структура описывает запись пользователя любого типа для вывода командами.
+type entry struct {
+	ID     string            `json:"id"`               //Идентификатор записи
+	Type   string            `json:"type"`             //Тип записи
+	Name   string            `json:"name"`             //Имя записи
+	Fields map[string]string `json:"fields,omitempty"` //Поля записи, двоичные данные в кодировке base64, вложения - ссылкой на сервер
+	index  int               //Номер записи в разделе хранилища
+	raw    []byte            //Двоичные данные записи без кодирования
+}
+
+// recordTypes допустимые типы записей.
+var recordTypes = []string{records.TypePassword, records.TypeCard, records.TypeText, records.TypeBinary}
+
+// validType функция проверяет тип записи, пустой тип означает любой.
+func validType(recordType string) bool {
+	if recordType == "" {
+		return true
+	}
+	for _, t := range recordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+// entries функция собирает записи пользователя всех типов, упорядоченные по типу и имени.
+func entries(strg *storage.UserStorage) []entry {
+	list := make([]entry, 0)
+	for i, val := range strg.SliceUsersPasswords() {
+		list = append(list, entry{ID: val.ID, Type: records.TypePassword, Name: val.Name, index: i,
+			Fields: map[string]string{"login": val.Login, "pass": val.Pass, "comment": val.Comment}})
+	}
+	for i, val := range strg.SliceUsersCards() {
+		list = append(list, entry{ID: val.ID, Type: records.TypeCard, Name: val.Name, index: i,
+			Fields: map[string]string{"number": val.CardNumber, "comment": val.Comment}})
+	}
+	for i, val := range strg.SliceUsersTexts() {
+		list = append(list, entry{ID: val.ID, Type: records.TypeText, Name: val.Name, index: i,
+			Fields: map[string]string{"data": val.Data, "comment": val.Comment}})
+	}
+	for i, val := range strg.SliceUsersBinaries() {
+		e := entry{ID: val.ID, Type: records.TypeBinary, Name: val.Name, index: i, raw: val.Data,
+			Fields: map[string]string{"data": base64.StdEncoding.EncodeToString(val.Data), "comment": val.Comment}}
+		if val.Attachment != "" {
+			e.Fields["attachment"], e.Fields["hash"], e.Fields["size"] = val.Attachment, val.Hash, strconv.FormatInt(val.Size, 10)
+		}
+		list = append(list, e)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Type != list[j].Type {
+			return list[i].Type < list[j].Type
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// findEntry функция ищет запись по идентификатору или имени, при необходимости только среди записей типа recordType.
+// Если имя совпадает у нескольких записей, возвращается ошибка со списком их идентификаторов.
+func findEntry(strg *storage.UserStorage, key, recordType string) (entry, error) {
+	var found []entry
+	for _, e := range entries(strg) {
+		if recordType != "" && e.Type != recordType {
+			continue
+		}
+		if e.ID == key {
+			return e, nil
+		}
+		if e.Name == key {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return entry{}, fmt.Errorf("%w: %s", gkerrors.ErrNoSuchRecord, key)
+	case 1:
+		return found[0], nil
+	}
+	ids := make([]string, len(found))
+	for i, e := range found {
+		ids[i] = e.Type + " " + e.ID
+	}
+	return entry{}, fmt.Errorf("%w: %s", gkerrors.ErrRecordAmbiguous, strings.Join(ids, ", "))
+}
+
+// fieldValue функция возвращает значение поля записи. Для двоичных данных возвращаются данные без кодирования.
+func fieldValue(e entry, field string) ([]byte, error) {
+	if field == "name" {
+		return []byte(e.Name), nil
+	}
+	if field == "id" {
+		return []byte(e.ID), nil
+	}
+	val, ok := e.Fields[field]
+	if !ok {
+		return nil, fmt.Errorf("%s record has no field %s", e.Type, field)
+	}
+	if e.Type == records.TypeBinary && field == "data" {
+		return e.raw, nil
+	}
+	return []byte(val), nil
+}
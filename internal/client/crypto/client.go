@@ -1,46 +1,81 @@
 package crypto
 
 import (
-	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"crypto/rsa"
-	"crypto/sha256"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+	"gophkeeper/internal/sign"
 )
 
+// userDataVersion версия формата конверта зашифрованных данных пользователя.
+const userDataVersion byte = 1
+
+// userDataOverhead размер служебных данных конверта: байт версии формата, nonce и тег аутентификации AES-GCM.
+const userDataOverhead = 1 + 12 + 16
+
 // UserSession структура для хранения данных одной сессии.
 type UserSession struct {
-	sessionID       string
-	userID          string
-	serverPublicKey *rsa.PublicKey
-	privateKey      *rsa.PrivateKey
-	symmetricalKey  string
+	sessionID      string
+	userID         string
+	keyPair        handshake.KeyPair //Эфемерная пара ключей X25519 для установления сессии
+	keys           handshake.Keys    //Ключи шифрования и подписи сессии
+	symmetricalKey []byte            //Ключ шифрования данных пользователя
+	legacyKey      []byte            //Ключ данных, созданный до перехода на случайные 256-битные ключи, только для расшифровки
+	keyParams      *KeyParams
+	master         *MasterKey    //Мастер-ключ, хранится только до удаления устаревшего ключа данных
+	wrappedKey     []byte        //Ключи данных, зашифрованные мастер-ключом, для расшифровки локального кэша
+	nonce          atomic.Uint64 //Номер последнего подписанного запроса
 }
 
 // NewUserSession функция генерирует структуру хранения ключей сессии.
 func NewUserSession() (*UserSession, error) {
-	key, err := generateKeys()
+	keyPair, err := handshake.NewKeyPair()
 	if err != nil {
 		return nil, err
 	}
-	return &UserSession{privateKey: key}, nil
+	return &UserSession{keyPair: keyPair}, nil
 }
 
-// RefreshToken метод обновляет структуру хранения ключей сессии.
+// RefreshToken метод обновляет структуру хранения ключей сессии: затирает ключи предыдущей сессии и генерирует новую эфемерную пару ключей X25519.
+// Структура изменяется на месте, так как ее используют перехватчик запросов и клиент.
 func (u *UserSession) RefreshToken() error {
-	var err error
-	u = nil
-	u, err = NewUserSession()
+	u.ClearKeys()
+	keyPair, err := handshake.NewKeyPair()
 	if err != nil {
 		return err
 	}
+	u.keyPair = keyPair
 	return nil
 }
 
-// GetPublicKey метод возвращает публичный ключ пользователя.
-func (u *UserSession) GetPublicKey() rsa.PublicKey {
-	return u.privateKey.PublicKey
+// ClearKeys метод перезаписывает нулями ключи сессии, ключи данных и зашифрованные ключи данных и удаляет их из структуры.
+func (u *UserSession) ClearKeys() {
+	u.keyPair.Clear()
+	u.keys.Clear()
+	for _, key := range [][]byte{u.symmetricalKey, u.legacyKey, u.wrappedKey} {
+		handshake.Zero(key)
+	}
+	if u.master != nil {
+		handshake.Zero(u.master.wrapKey)
+	}
+	u.sessionID, u.userID = "", ""
+	u.keyPair, u.keys = handshake.KeyPair{}, handshake.Keys{}
+	u.symmetricalKey, u.legacyKey, u.master = nil, nil, nil
+	u.keyParams, u.wrappedKey = nil, nil
+	u.nonce.Store(0)
+}
+
+// GetPublicKey метод возвращает эфемерный открытый ключ X25519 пользователя.
+func (u *UserSession) GetPublicKey() []byte {
+	return u.keyPair.Public
 }
 
 // GetSessionID метод возвращает идентификатор сессии пользователя.
@@ -48,104 +83,201 @@ func (u *UserSession) GetSessionID() string {
 	return u.sessionID
 }
 
-// WriteSessionID метод сохраняет идентификатор сессии пользователя и открытый ключ сервера.
-func (u *UserSession) WriteSessionID(sessionID string, serverPublicKey *rsa.PublicKey) {
+// WriteSessionID метод сохраняет идентификатор сессии пользователя и вычисляет ключи сессии по открытому ключу сервера.
+func (u *UserSession) WriteSessionID(sessionID string, serverPublicKey []byte) error {
 	u.sessionID = sessionID
-	if serverPublicKey != nil { // сделал проверку для редактирования sessionID в тестах
-		u.serverPublicKey = serverPublicKey
+	if serverPublicKey == nil { // сделал проверку для редактирования sessionID в тестах
+		return nil
+	}
+	keys, err := u.keyPair.ClientKeys(serverPublicKey)
+	if err != nil {
+		return err
 	}
+	u.keys = keys
+	return nil
 }
 
-// WriteUserID метод сохраняет идентификатор пользователя и ключ для шифрования данных.
-func (u *UserSession) WriteUserID(userID, symKey string) {
-	if userID != "" {
-		u.userID = userID
-	}
-	if symKey != "" {
-		u.symmetricalKey = symKey
+// WriteUserID метод сохраняет идентификатор пользователя.
+func (u *UserSession) WriteUserID(userID string) {
+	u.userID = userID
+}
+
+// WriteKeyParams метод сохраняет параметры вычисления мастер-ключа пользователя.
+func (u *UserSession) WriteKeyParams(params KeyParams) {
+	u.keyParams = &params
+}
+
+// GetKeyParams метод возвращает параметры вычисления мастер-ключа пользователя.
+// Второе значение false, если пользователь не авторизован или его ключ данных создан сервером.
+func (u *UserSession) GetKeyParams() (KeyParams, bool) {
+	if u.keyParams == nil {
+		return KeyParams{}, false
 	}
+	return *u.keyParams, true
+}
+
+// WriteWrappedKey метод сохраняет ключи данных пользователя, зашифрованные мастер-ключом.
+func (u *UserSession) WriteWrappedKey(wrapped []byte) {
+	u.wrappedKey = wrapped
+}
+
+// GetWrappedKey метод возвращает ключи данных пользователя, зашифрованные мастер-ключом, или nil, если они неизвестны.
+func (u *UserSession) GetWrappedKey() []byte {
+	return u.wrappedKey
 }
 
 // EncryptData метод зашифровывает сообщение перед отправкой
 func (u *UserSession) EncryptData(message string, label []byte) ([]byte, error) {
-	hash := sha256.New()
-	ciphertext, err := rsa.EncryptOAEP(hash, rand.Reader, u.serverPublicKey, []byte(message), label)
-	if err != nil {
-		return nil, err
-	}
-	return ciphertext, nil
+	return handshake.Seal(u.keys.ClientKey, []byte(message), label)
 }
 
 // DecryptData метод расшифровывает данные сервера
 func (u *UserSession) DecryptData(messageBZ, label []byte) (string, error) {
-	hash := sha256.New()
-	message, err := rsa.DecryptOAEP(hash, rand.Reader, u.privateKey, messageBZ, label)
+	message, err := handshake.Open(u.keys.ServerKey, messageBZ, label)
 	if err != nil {
 		return "", err
 	}
 	return string(message), nil
 }
 
-// UserSign метод создает подпись клиента для отправки сообщений
-func (u *UserSession) UserSign() ([]byte, error) {
-	var opts rsa.PSSOptions
-	opts.SaltLength = rsa.PSSSaltLengthAuto
-	newhash := crypto.SHA256
-	pssh := newhash.New()
-	pssh.Write([]byte(``))
-	hashed := pssh.Sum(nil)
-	sign, err := rsa.SignPSS(rand.Reader, u.privateKey, newhash, hashed, &opts)
+// SignRequest метод создает подпись клиента для отправки запроса.
+// Подпись вычисляется от имени метода, сообщения запроса, времени подписи и номера запроса, который увеличивается с каждым запросом.
+func (u *UserSession) SignRequest(method string, req proto.Message) ([]byte, int64, uint64, error) {
+	timestamp := time.Now().UnixMilli()
+	nonce := u.nonce.Add(1)
+	hashed, err := sign.RequestDigest(method, u.sessionID, timestamp, nonce, req)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, err
 	}
-	return sign, nil
+	return handshake.Sign(u.keys.ClientSign, hashed), timestamp, nonce, nil
 }
 
 // CheckSign метод проверяет подпись сервера
 func (u *UserSession) CheckSign(serverSignBZ []byte) error {
-	var opts rsa.PSSOptions
-	opts.SaltLength = rsa.PSSSaltLengthAuto
-	newhash := crypto.SHA256
-	pssh := newhash.New()
-	pssh.Write([]byte(``))
-	hashed := pssh.Sum(nil)
-	err := rsa.VerifyPSS(u.serverPublicKey, newhash, hashed, serverSignBZ, &opts)
-	if err != nil {
-		return err
+	if !handshake.Verify(u.keys.ServerSign, []byte(``), serverSignBZ) {
+		return gkerrors.ErrSignIncorrect
 	}
 	return nil
 }
 
-// EncryptUserData метод зашифровывает данные пользователя
+// EncryptUserData метод зашифровывает данные пользователя.
+// Для каждого сообщения генерируется случайный nonce, результат упаковывается в конверт: байт версии формата, nonce, шифротекст с тегом.
 func (u *UserSession) EncryptUserData(jsonBZ []byte) ([]byte, error) {
-	aesblock, err := aes.NewCipher([]byte(u.symmetricalKey[:len(u.symmetricalKey)-12]))
+	return u.sealUserData(jsonBZ, nil)
+}
+
+// EncryptAttachmentChunk метод зашифровывает часть вложения с номером index в конверт формата данных пользователя.
+// Номер части входит в дополнительные аутентифицируемые данные, поэтому переставленная, пропущенная
+// или взятая из другого места часть не расшифровывается.
+func (u *UserSession) EncryptAttachmentChunk(chunk []byte, index uint64) ([]byte, error) {
+	return u.sealUserData(chunk, attachmentAAD(index))
+}
+
+// DecryptAttachmentChunk метод расшифровывает часть вложения с номером index.
+func (u *UserSession) DecryptAttachmentChunk(messageBZ []byte, index uint64) ([]byte, error) {
+	if u.symmetricalKey == nil && u.legacyKey == nil {
+		return nil, gkerrors.ErrNotAuth
+	}
+	aad := attachmentAAD(index)
+	if u.symmetricalKey != nil {
+		chunk, err := openUserData(u.symmetricalKey, messageBZ, aad)
+		if err == nil || u.legacyKey == nil {
+			return chunk, err
+		}
+	}
+	return openUserData(u.legacyKey[:symmetricalKeyLen], messageBZ, aad)
+}
+
+// attachmentAAD функция формирует дополнительные аутентифицируемые данные части вложения с номером index.
+func attachmentAAD(index uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte("gophkeeper attachment chunk "), index)
+}
+
+// sealUserData метод зашифровывает данные пользователя с дополнительными аутентифицируемыми данными aad.
+func (u *UserSession) sealUserData(jsonBZ, aad []byte) ([]byte, error) {
+	key := u.symmetricalKey
+	if key == nil && u.legacyKey != nil {
+		key = u.legacyKey[:symmetricalKeyLen]
+	}
+	aesgcm, err := userDataCipher(key)
 	if err != nil {
 		return nil, err
 	}
-	aesgcm, err := cipher.NewGCM(aesblock)
+	nonce := make([]byte, aesgcm.NonceSize())
+	_, err = rand.Read(nonce)
 	if err != nil {
 		return nil, err
 	}
-	nonce := u.symmetricalKey[len(u.symmetricalKey)-12:]
-	messageBZ := aesgcm.Seal(nil, []byte(nonce), jsonBZ, nil)
-	return messageBZ, nil
+	messageBZ := make([]byte, 0, 1+len(nonce)+len(jsonBZ)+aesgcm.Overhead())
+	messageBZ = append(messageBZ, userDataVersion)
+	messageBZ = append(messageBZ, nonce...)
+	return aesgcm.Seal(messageBZ, nonce, jsonBZ, aad), nil
+}
+
+// EncryptedSize функция возвращает размер конверта, в который EncryptUserData упаковывает n байт данных.
+func EncryptedSize(n int) int64 {
+	return int64(n + userDataOverhead)
 }
 
 // DecryptUserData метод расшифровывает данные пользователя
 func (u *UserSession) DecryptUserData(messageBZ []byte) ([]byte, error) {
-	aesblock, err := aes.NewCipher([]byte(u.symmetricalKey[:len(u.symmetricalKey)-12]))
+	jsonBZ, _, err := u.DecryptUserDataVersion(messageBZ)
+	return jsonBZ, err
+}
+
+// DecryptUserDataVersion метод расшифровывает данные пользователя в конверте с версией формата или в устаревшем формате
+// с постоянным nonce. Второе значение true, если данные в устаревшем формате или зашифрованы устаревшим ключом
+// и их нужно зашифровать повторно.
+func (u *UserSession) DecryptUserDataVersion(messageBZ []byte) ([]byte, bool, error) {
+	if u.symmetricalKey == nil && u.legacyKey == nil {
+		return nil, false, gkerrors.ErrNotAuth
+	}
+	if u.symmetricalKey != nil {
+		jsonBZ, err := openUserData(u.symmetricalKey, messageBZ, nil)
+		if err == nil {
+			return jsonBZ, false, nil
+		}
+		if u.legacyKey == nil {
+			return nil, false, err
+		}
+	}
+	jsonBZ, err := openUserData(u.legacyKey[:symmetricalKeyLen], messageBZ, nil)
+	if err == nil {
+		return jsonBZ, u.symmetricalKey != nil, nil
+	}
+	// Данные, зашифрованные до введения конверта, могут случайно начинаться с байта версии
+	aesgcm, err := userDataCipher(u.legacyKey[:symmetricalKeyLen])
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	aesgcm, err := cipher.NewGCM(aesblock)
+	jsonBZ, err = aesgcm.Open(nil, u.legacyKey[symmetricalKeyLen:], messageBZ, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	return jsonBZ, true, nil
+}
+
+// openUserData функция расшифровывает данные пользователя в конверте с версией формата
+// и проверяет дополнительные аутентифицируемые данные aad.
+func openUserData(key, messageBZ, aad []byte) ([]byte, error) {
+	aesgcm, err := userDataCipher(key)
 	if err != nil {
 		return nil, err
 	}
+	if len(messageBZ) < 1+aesgcm.NonceSize()+aesgcm.Overhead() || messageBZ[0] != userDataVersion {
+		return nil, gkerrors.ErrKeyIncorrect
+	}
+	return aesgcm.Open(nil, messageBZ[1:1+aesgcm.NonceSize()], messageBZ[1+aesgcm.NonceSize():], aad)
+}
 
-	nonce := u.symmetricalKey[len(u.symmetricalKey)-12:]
-	jsonBZ, err := aesgcm.Open(nil, []byte(nonce), messageBZ, nil)
+// userDataCipher функция создает шифр AES-GCM на ключе шифрования данных пользователя.
+func userDataCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != symmetricalKeyLen {
+		return nil, gkerrors.ErrNotAuth
+	}
+	aesblock, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
-	return jsonBZ, nil
+	return cipher.NewGCM(aesblock)
 }
@@ -0,0 +1,163 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserData(t *testing.T) {
+	symKey, err := NewSymmetricalKey()
+	require.NoError(t, err)
+	require.Len(t, symKey, 32)
+	u := &UserSession{}
+	require.NoError(t, u.WriteSymmetricalKey(symKey))
+
+	first, err := u.EncryptUserData([]byte("data"))
+	require.NoError(t, err)
+	second, err := u.EncryptUserData([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, userDataVersion, first[0])
+	require.Equal(t, EncryptedSize(4), int64(len(first)))
+	require.NotEqual(t, first[1:13], second[1:13])
+
+	data, legacy, err := u.DecryptUserDataVersion(first)
+	require.NoError(t, err)
+	require.False(t, legacy)
+	require.Equal(t, []byte("data"), data)
+
+	first[len(first)-1] ^= 1
+	_, err = u.DecryptUserData(first)
+	require.Error(t, err)
+
+	_, err = (&UserSession{}).EncryptUserData([]byte("data"))
+	require.Error(t, err)
+}
+
+func TestLegacyKey(t *testing.T) {
+	// Ключ, созданный сервером, и данные, зашифрованные им с постоянным nonce
+	legacyKey := []byte("1234567890abcdefghijklmnopqrstuvwxyzABCDEFGH")
+	aesblock, err := aes.NewCipher(legacyKey[:32])
+	require.NoError(t, err)
+	aesgcm, err := cipher.NewGCM(aesblock)
+	require.NoError(t, err)
+	old := aesgcm.Seal(nil, legacyKey[32:], []byte("old data"), nil)
+
+	u := &UserSession{}
+	require.NoError(t, u.WriteSymmetricalKey(legacyKey))
+	require.True(t, u.LegacySymmetricalKey())
+	legacyEnvelope, err := u.EncryptUserData([]byte("envelope data"))
+	require.NoError(t, err)
+
+	// Замена ключа, устаревший ключ остается для расшифровки
+	keyBZ, err := u.RotatedSymmetricalKey()
+	require.NoError(t, err)
+	require.NoError(t, u.WriteSymmetricalKey(keyBZ))
+	require.False(t, u.LegacySymmetricalKey())
+
+	data, legacy, err := u.DecryptUserDataVersion(old)
+	require.NoError(t, err)
+	require.True(t, legacy)
+	require.Equal(t, []byte("old data"), data)
+	data, legacy, err = u.DecryptUserDataVersion(legacyEnvelope)
+	require.NoError(t, err)
+	require.True(t, legacy)
+	require.Equal(t, []byte("envelope data"), data)
+
+	fresh, err := u.EncryptUserData([]byte("new data"))
+	require.NoError(t, err)
+	data, legacy, err = u.DecryptUserDataVersion(fresh)
+	require.NoError(t, err)
+	require.False(t, legacy)
+	require.Equal(t, []byte("new data"), data)
+
+	// Ключи сохраняются на сервере зашифрованными мастер-ключом вместе
+	params, err := NewKeyParams()
+	require.NoError(t, err)
+	params.Memory = 1024
+	master := DeriveMasterKey("123", params)
+	wrapped, err := u.WrapSymmetricalKey(master)
+	require.NoError(t, err)
+	unwrapped, err := master.Unwrap(wrapped)
+	require.NoError(t, err)
+	require.Equal(t, keyBZ, unwrapped)
+	_, err = DeriveMasterKey("456", params).Unwrap(wrapped)
+	require.Error(t, err)
+
+	// После повторного шифрования данных устаревший ключ удаляется, и данные, зашифрованные им, не принимаются
+	_, _, ok := u.CurrentKeyWithoutLegacy()
+	require.False(t, ok)
+	u.KeepMasterKey(master)
+	kept, current, ok := u.CurrentKeyWithoutLegacy()
+	require.True(t, ok)
+	require.Equal(t, master, kept)
+	require.Equal(t, keyBZ[:32], current)
+	require.NoError(t, u.WriteSymmetricalKey(current))
+	require.False(t, u.HasLegacyKey())
+	_, _, ok = u.CurrentKeyWithoutLegacy()
+	require.False(t, ok)
+	_, _, err = u.DecryptUserDataVersion(old)
+	require.Error(t, err)
+	_, _, err = u.DecryptUserDataVersion(legacyEnvelope)
+	require.Error(t, err)
+	data, legacy, err = u.DecryptUserDataVersion(fresh)
+	require.NoError(t, err)
+	require.False(t, legacy)
+	require.Equal(t, []byte("new data"), data)
+
+	require.Error(t, u.WriteSymmetricalKey([]byte("short")))
+}
+
+func TestRefreshToken(t *testing.T) {
+	u, err := NewUserSession()
+	require.NoError(t, err)
+	symKey, err := NewSymmetricalKey()
+	require.NoError(t, err)
+	require.NoError(t, u.WriteSymmetricalKey(symKey))
+	require.NoError(t, u.WriteSessionID("session", nil))
+	u.WriteUserID("user")
+	wrapped := []byte("wrapped")
+	u.WriteWrappedKey(wrapped)
+	u.WriteKeyParams(KeyParams{Salt: []byte("salt")})
+	public := append([]byte(nil), u.GetPublicKey()...)
+
+	// Новая сессия получает новую эфемерную пару ключей, данные предыдущей сессии сбрасываются
+	require.NoError(t, u.RefreshToken())
+	require.NotEqual(t, public, u.GetPublicKey())
+	require.Empty(t, u.GetSessionID())
+	require.Nil(t, u.GetWrappedKey())
+	_, ok := u.GetKeyParams()
+	require.False(t, ok)
+	_, err = u.EncryptUserData([]byte("data"))
+	require.Error(t, err)
+	// Ключ данных и зашифрованный ключ данных перезаписаны нулями
+	require.Equal(t, make([]byte, len(symKey)), symKey)
+	require.Equal(t, make([]byte, len(wrapped)), wrapped)
+}
+
+func TestAttachmentChunk(t *testing.T) {
+	u, err := NewUserSession()
+	require.NoError(t, err)
+	symKey, err := NewSymmetricalKey()
+	require.NoError(t, err)
+	require.NoError(t, u.WriteSymmetricalKey(symKey))
+
+	chunk, err := u.EncryptAttachmentChunk([]byte("chunk data"), 1)
+	require.NoError(t, err)
+	require.Len(t, chunk, int(EncryptedSize(len("chunk data"))))
+	data, err := u.DecryptAttachmentChunk(chunk, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("chunk data"), data)
+
+	// Часть с другим номером и данные записи не расшифровываются как часть вложения
+	_, err = u.DecryptAttachmentChunk(chunk, 0)
+	require.Error(t, err)
+	_, err = u.DecryptUserData(chunk)
+	require.Error(t, err)
+	record, err := u.EncryptUserData([]byte("chunk data"))
+	require.NoError(t, err)
+	_, err = u.DecryptAttachmentChunk(record, 0)
+	require.Error(t, err)
+}
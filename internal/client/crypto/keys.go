@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"crypto/rand"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// Длины ключей шифрования данных пользователя.
+const (
+	symmetricalKeyLen = 32      //Случайный 256-битный ключ
+	legacyKeyLen      = 32 + 12 //Ключ и постоянный nonce, созданные до перехода на случайные 256-битные ключи
+)
+
+// NewSymmetricalKey функция генерирует случайный 256-битный ключ шифрования данных пользователя.
+func NewSymmetricalKey() ([]byte, error) {
+	key := make([]byte, symmetricalKeyLen)
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WriteSymmetricalKey метод сохраняет ключи шифрования данных пользователя.
+// Ключи передаются одним массивом: текущий 256-битный ключ, за которым может следовать устаревший ключ,
+// оставленный для расшифровки данных, еще не зашифрованных повторно. Массив может состоять только из устаревшего ключа.
+func (u *UserSession) WriteSymmetricalKey(keyBZ []byte) error {
+	switch len(keyBZ) {
+	case symmetricalKeyLen:
+		u.symmetricalKey, u.legacyKey, u.master = keyBZ, nil, nil
+	case symmetricalKeyLen + legacyKeyLen:
+		u.symmetricalKey, u.legacyKey = keyBZ[:symmetricalKeyLen], keyBZ[symmetricalKeyLen:]
+	case legacyKeyLen:
+		u.symmetricalKey, u.legacyKey = nil, keyBZ
+	default:
+		return gkerrors.ErrKeyIncorrect
+	}
+	return nil
+}
+
+// LegacySymmetricalKey метод сообщает, что у пользователя есть только устаревший ключ шифрования данных и его нужно заменить.
+func (u *UserSession) LegacySymmetricalKey() bool {
+	return u.symmetricalKey == nil && u.legacyKey != nil
+}
+
+// HasLegacyKey метод сообщает, что вместе с ключом шифрования данных хранится устаревший ключ.
+func (u *UserSession) HasLegacyKey() bool {
+	return u.legacyKey != nil
+}
+
+// KeepMasterKey метод сохраняет мастер-ключ, пока у пользователя есть устаревший ключ данных.
+// Мастер-ключ нужен, чтобы после повторного шифрования всех данных сохранить на сервере только текущий ключ.
+func (u *UserSession) KeepMasterKey(master MasterKey) {
+	if u.legacyKey != nil {
+		u.master = &master
+	}
+}
+
+// CurrentKeyWithoutLegacy метод возвращает мастер-ключ и текущий ключ шифрования данных без устаревшего ключа
+// в формате WriteSymmetricalKey. Второе значение false, если устаревшего ключа нет или мастер-ключ неизвестен.
+func (u *UserSession) CurrentKeyWithoutLegacy() (MasterKey, []byte, bool) {
+	if u.legacyKey == nil || u.symmetricalKey == nil || u.master == nil {
+		return MasterKey{}, nil, false
+	}
+	return *u.master, append([]byte(nil), u.symmetricalKey...), true
+}
+
+// RotatedSymmetricalKey метод генерирует новый 256-битный ключ шифрования данных и возвращает его вместе с устаревшим ключом
+// в формате WriteSymmetricalKey. Ключи сессии не меняются до сохранения нового ключа на сервере.
+func (u *UserSession) RotatedSymmetricalKey() ([]byte, error) {
+	key, err := NewSymmetricalKey()
+	if err != nil {
+		return nil, err
+	}
+	return append(key, u.legacyKey...), nil
+}
+
+// WrapSymmetricalKey метод зашифровывает ключи шифрования данных пользователя мастер-ключом.
+func (u *UserSession) WrapSymmetricalKey(master MasterKey) ([]byte, error) {
+	keyBZ := make([]byte, 0, len(u.symmetricalKey)+len(u.legacyKey))
+	keyBZ = append(keyBZ, u.symmetricalKey...)
+	keyBZ = append(keyBZ, u.legacyKey...)
+	return master.Wrap(keyBZ)
+}
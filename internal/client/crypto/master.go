@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+
+	"golang.org/x/crypto/argon2"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// KeyParams структура хранит параметры вычисления мастер-ключа из пароля пользователя.
+// Параметры хранятся на сервере и передаются клиенту перед авторизацией.
+type KeyParams struct {
+	Salt    []byte //Соль пользователя
+	Time    uint32 //Количество проходов argon2id
+	Memory  uint32 //Объем памяти argon2id, в килобайтах
+	Threads uint8  //Количество потоков argon2id
+}
+
+// NewKeyParams функция генерирует параметры вычисления мастер-ключа нового пользователя со случайной солью.
+func NewKeyParams() (KeyParams, error) {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return KeyParams{}, err
+	}
+	return KeyParams{Salt: salt, Time: 3, Memory: 64 * 1024, Threads: 4}, nil
+}
+
+// MasterKey структура хранит ключи, вычисленные из пароля пользователя.
+// Ключ аутентификации передается серверу вместо пароля, ключ шифрования не покидает клиента.
+type MasterKey struct {
+	AuthKey string
+	wrapKey []byte
+}
+
+// DeriveMasterKey функция вычисляет из пароля пользователя ключ аутентификации и ключ шифрования ключа данных.
+// Ключи вычисляются как две независимые половины результата argon2id, поэтому сервер по ключу аутентификации не может получить ключ шифрования.
+func DeriveMasterKey(password string, params KeyParams) MasterKey {
+	key := argon2.IDKey([]byte(password), params.Salt, params.Time, params.Memory, params.Threads, 64)
+	return MasterKey{AuthKey: hex.EncodeToString(key[32:]), wrapKey: key[:32]}
+}
+
+// Wrap метод зашифровывает ключ данных пользователя мастер-ключом для хранения на сервере.
+func (m MasterKey) Wrap(symKey []byte) ([]byte, error) {
+	aesgcm, err := m.cipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aesgcm.Seal(nonce, nonce, symKey, nil), nil
+}
+
+// Unwrap метод расшифровывает полученный с сервера ключ данных пользователя.
+func (m MasterKey) Unwrap(wrapped []byte) ([]byte, error) {
+	aesgcm, err := m.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aesgcm.NonceSize() {
+		return nil, gkerrors.ErrWrongPassword
+	}
+	symKey, err := aesgcm.Open(nil, wrapped[:aesgcm.NonceSize()], wrapped[aesgcm.NonceSize():], nil)
+	if err != nil {
+		return nil, gkerrors.ErrWrongPassword
+	}
+	return symKey, nil
+}
+
+// cipher метод создает шифр AES-GCM на ключе шифрования мастер-ключа.
+func (m MasterKey) cipher() (cipher.AEAD, error) {
+	aesblock, err := aes.NewCipher(m.wrapKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(aesblock)
+}
@@ -1,8 +1,6 @@
 package crypto
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
 	"strconv"
 
 	"github.com/rs/zerolog/log"
@@ -32,12 +30,3 @@ func LynnCheckOrder(lynn []byte) bool {
 	}
 	return sum%10 == 0
 }
-
-// generateKeys функция генерирует несимметричный ключ сессии пользователя
-func generateKeys() (*rsa.PrivateKey, error) {
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, err
-	}
-	return privateKey, nil
-}
@@ -3,11 +3,15 @@ package interceptor
 import (
 	"context"
 	"encoding/hex"
+	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"gophkeeper/internal/client/crypto"
 )
@@ -25,13 +29,101 @@ func (client *AuthClient) Unary() grpc.UnaryClientInterceptor {
 		if strings.Contains(method, "NewSessionID") {
 			return invoker(ctx, method, req, reply, cc, opts...)
 		}
-		userSign, err := client.rsa.UserSign()
+		message, ok := req.(proto.Message)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "request is not a protobuf message")
+		}
+		ctx, err := client.sign(ctx, method, message)
 		if err != nil {
-			log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
 			return err
 		}
-		ctx = metadata.AppendToOutgoingContext(ctx, "userSession", client.rsa.GetSessionID())
-		ctx = metadata.AppendToOutgoingContext(ctx, "userSign", hex.EncodeToString(userSign))
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// Stream метод подписывает первое сообщение потокового запроса. Подпись передается в метаданных,
+// которые отправляются при открытии потока, поэтому поток открывается при отправке первого сообщения.
+func (client *AuthClient) Stream() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &signedStream{ctx: ctx, desc: desc, cc: cc, method: method, streamer: streamer, opts: opts, client: client}, nil
+	}
+}
+
+// sign метод подписывает запрос и добавляет подпись в метаданные исходящего контекста.
+func (client *AuthClient) sign(ctx context.Context, method string, message proto.Message) (context.Context, error) {
+	userSign, timestamp, nonce, err := client.rsa.SignRequest(method, message)
+	if err != nil {
+		log.Error().Err(err).Msg("SignRequest signing error")
+		return ctx, err
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "userSession", client.rsa.GetSessionID())
+	ctx = metadata.AppendToOutgoingContext(ctx, "userSign", hex.EncodeToString(userSign))
+	ctx = metadata.AppendToOutgoingContext(ctx, "userTime", strconv.FormatInt(timestamp, 10))
+	ctx = metadata.AppendToOutgoingContext(ctx, "userNonce", strconv.FormatUint(nonce, 10))
+	return ctx, nil
+}
+
+// signedStream структура потока, открываемого при отправке первого подписанного сообщения.
+type signedStream struct {
+	ctx      context.Context
+	desc     *grpc.StreamDesc
+	cc       *grpc.ClientConn
+	method   string
+	streamer grpc.Streamer
+	opts     []grpc.CallOption
+	client   *AuthClient
+	stream   grpc.ClientStream //Открытый поток, nil до отправки первого сообщения
+}
+
+func (s *signedStream) SendMsg(m interface{}) error {
+	if s.stream == nil {
+		message, ok := m.(proto.Message)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "request is not a protobuf message")
+		}
+		ctx, err := s.client.sign(s.ctx, s.method, message)
+		if err != nil {
+			return err
+		}
+		s.stream, err = s.streamer(ctx, s.desc, s.cc, s.method, s.opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return s.stream.SendMsg(m)
+}
+
+func (s *signedStream) RecvMsg(m interface{}) error {
+	if s.stream == nil {
+		return status.Error(codes.FailedPrecondition, "stream is not opened")
+	}
+	return s.stream.RecvMsg(m)
+}
+
+func (s *signedStream) Header() (metadata.MD, error) {
+	if s.stream == nil {
+		return nil, status.Error(codes.FailedPrecondition, "stream is not opened")
+	}
+	return s.stream.Header()
+}
+
+func (s *signedStream) Trailer() metadata.MD {
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.Trailer()
+}
+
+func (s *signedStream) CloseSend() error {
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.CloseSend()
+}
+
+func (s *signedStream) Context() context.Context {
+	if s.stream == nil {
+		return s.ctx
+	}
+	return s.stream.Context()
+}
@@ -3,7 +3,7 @@ package menu
 import (
 	"errors"
 	"fmt"
-	"os"
+	"strconv"
 
 	"gophkeeper/internal/client/crypto"
 	"gophkeeper/internal/client/sender"
@@ -86,10 +86,46 @@ func printStringUserData(i dataType, number int, sndr sender.GophKeeperClient) {
 			fmt.Println("В базе нет строки с таким номером!")
 			return
 		}
-		fmt.Printf(`Данные строки:
+		if val.Attachment != "" {
+			fmt.Printf(`Данные строки:
+		Имя: %s размер файла: %d байт, хранится на сервере вложением Примечание %s
+		`, val.Name, val.Size, val.Comment)
+		} else {
+			fmt.Printf(`Данные строки:
 		Имя: %s размер данных: %d символов Примечание %s
 		`, val.Name, len(val.Data), val.Comment)
+		}
+		if confirm("Сохранить данные в файл? (Y/N)") {
+			saveUsersBinary(*val, &sndr)
+		}
+	}
+}
+
+// summaryUserData метод возвращает краткое описание записи без секретных данных и без запросов к пользователю.
+// Второе значение false, если записи с таким номером нет.
+func summaryUserData(i dataType, number int, sndr sender.GophKeeperClient) (string, bool) {
+	switch i {
+	case usersPasswords:
+		if val := sndr.Strg.StringUsersPassword(number); val != nil {
+			return fmt.Sprintf("Имя: %s Логин: %s Примечание: %s", val.Name, val.Login, val.Comment), true
+		}
+	case usersCards:
+		if val := sndr.Strg.StringUsersCard(number); val != nil {
+			return fmt.Sprintf("Имя: %s Примечание: %s", val.Name, val.Comment), true
+		}
+	case usersTexts:
+		if val := sndr.Strg.StringUsersText(number); val != nil {
+			return fmt.Sprintf("Имя: %s Примечание: %s", val.Name, val.Comment), true
+		}
+	case usersBinaries:
+		if val := sndr.Strg.StringUsersBinary(number); val != nil {
+			if val.Attachment != "" {
+				return fmt.Sprintf("Имя: %s размер файла: %d байт, хранится на сервере вложением Примечание: %s", val.Name, val.Size, val.Comment), true
+			}
+			return fmt.Sprintf("Имя: %s размер данных: %d символов Примечание: %s", val.Name, len(val.Data), val.Comment), true
+		}
 	}
+	return "", false
 }
 
 // AddUserData метод добавляет новую запись в соответствующем разделе.
@@ -112,7 +148,9 @@ func addUsersData(i dataType, sndr sender.GophKeeperClient) {
 		fmt.Println("Данные успешно добавлены")
 	case usersBinaries:
 		var binary = storage.Binary{}
-		inputUsersBinaries(&binary)
+		if !inputUsersBinaries(&binary, &sndr) {
+			return
+		}
 		sndr.Strg.AddUsersBinary(&binary)
 		fmt.Println("Данные успешно добавлены")
 	}
@@ -158,12 +196,112 @@ func editUsersData(i dataType, v int, sndr sender.GophKeeperClient) {
 			return
 		}
 		var binary = storage.Binary{}
-		inputUsersBinaries(&binary)
+		if !inputUsersBinaries(&binary, &sndr) {
+			return
+		}
 		sndr.Strg.EditUsersBinary(v, &binary)
 		fmt.Println("Данные успешно изменены")
 	}
 }
 
+// deleteUsersData метод перемещает запись соответствующего раздела в корзину после подтверждения пользователя.
+func deleteUsersData(i dataType, sndr sender.GophKeeperClient) {
+	fmt.Println("Введите номер удаляемой записи")
+	var act string
+	fmt.Scanln(&act)
+	v, err := strconv.Atoi(act)
+	if err != nil || v < 1 {
+		fmt.Println("Команда не распознана")
+		return
+	}
+	summary, ok := summaryUserData(i, v-1, sndr)
+	if !ok {
+		fmt.Println("В базе нет строки с таким номером!")
+		return
+	}
+	fmt.Println("Удаляемая запись:", summary)
+	if !confirm("Удалить запись? Y - да, N - нет") {
+		return
+	}
+	var deleted bool
+	switch i {
+	case usersPasswords:
+		deleted = sndr.Strg.DeleteUsersPassword(v - 1)
+	case usersCards:
+		deleted = sndr.Strg.DeleteUsersCard(v - 1)
+	case usersTexts:
+		deleted = sndr.Strg.DeleteUsersText(v - 1)
+	case usersBinaries:
+		deleted = sndr.Strg.DeleteUsersBinary(v - 1)
+	}
+	if !deleted {
+		fmt.Println("В базе нет строки с таким номером!")
+		return
+	}
+	fmt.Println("Запись перемещена в корзину. До сохранения данных на сервер ее можно восстановить")
+}
+
+// trashMenu метод выводит содержимое корзины и позволяет восстановить записи или очистить корзину.
+func trashMenu(sndr sender.GophKeeperClient) {
+	for {
+		trash := sndr.Strg.SliceUsersTrash()
+		if len(trash) == 0 {
+			fmt.Println("Корзина пуста")
+			return
+		}
+		for i, val := range trash {
+			fmt.Printf("Номер: %d, Имя: %s\n", i+1, val.Name)
+		}
+		fmt.Println("Для восстановления введите номер записи\nДля очистки корзины введите E, или введите 0 для возврата в предыдущее меню")
+		var act string
+		fmt.Scanln(&act)
+		switch act {
+		case "0":
+			return
+		case "E", "e":
+			if confirm("Очистить корзину? Записи будут удалены на сервере при следующем сохранении. Y - да, N - нет") {
+				sndr.Strg.EmptyUsersTrash()
+				fmt.Println("Корзина очищена")
+				return
+			}
+		default:
+			v, err := strconv.Atoi(act)
+			if err != nil {
+				fmt.Println("Команда не распознана")
+				break
+			}
+			restored, err := sndr.Strg.RestoreUsersTrash(v - 1)
+			if err != nil {
+				fmt.Println("Ошибка восстановления записи")
+				log.Error().Err(err).Msg("RestoreUsersTrash error")
+				break
+			}
+			if !restored {
+				fmt.Println("В корзине нет строки с таким номером!")
+				break
+			}
+			fmt.Println("Запись восстановлена")
+		}
+	}
+}
+
+// confirm метод запрашивает у пользователя подтверждение действия.
+func confirm(question string) bool {
+	for {
+		fmt.Println(question)
+		var act string
+		fmt.Scanln(&act)
+		switch act {
+		case "Y", "y", "Yes", "yes":
+			return true
+		case "N", "n", "No", "no":
+			return false
+		default:
+			fmt.Println("Команда не распознана")
+		}
+	}
+}
+
 // inputUsersPasswords метод взаимодействует с пользователем для ввода данных в записи паролей.
 func inputUsersPasswords(pass *storage.Password) {
 	fmt.Print("Введите имя записи: ")
@@ -203,43 +341,44 @@ func inputUsersTexts(text *storage.Text) {
 }
 
 // inputUsersBinaries метод взаимодействует с пользователем для ввода данных в записи двоичных данных.
-func inputUsersBinaries(binary *storage.Binary) {
+// Файл больше 64кБ загружается на сервер вложением. Возвращает false, если файл не удалось прочитать или загрузить.
+func inputUsersBinaries(binary *storage.Binary, sndr *sender.GophKeeperClient) bool {
 	var file string
-	fmt.Print("Введите путь к файлу, размер файла не должен превышать 64kB: ")
+	fmt.Print("Введите путь к файлу, файлы больше 64kB хранятся на сервере вложением: ")
 	fmt.Scanln(&file)
-	var err error
-	binary.Name, binary.Data, err = readUserFile(file)
-	if errors.Is(err, gkerrors.ErrTooBig) {
-		fmt.Println("Размер файла превышает допустимые 64кБ")
-		return
+	err := sndr.ReadBinary(file, binary)
+	if errors.Is(err, gkerrors.ErrQuotaExceeded) {
+		fmt.Println("Превышен допустимый объем вложений на сервере")
+		return false
 	}
 	if err != nil {
-		fmt.Println("Ошибка чтения файла")
-		return
+		log.Error().Err(err).Msg("ReadBinary error")
+		fmt.Println("Ошибка чтения или загрузки файла")
+		return false
 	}
 	fmt.Print("Введите примечание: ")
 	fmt.Scanln(&binary.Comment)
+	return true
 }
 
-// readFile метод считывает данные из файла
-func readUserFile(path string) (string, []byte, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return "", nil, err
-	}
-	fi, err := file.Stat()
-	if err != nil {
-		return "", nil, err
-	}
-	if fi.Size() > 65536 {
-		return "", nil, gkerrors.ErrTooBig
+// saveUsersBinary метод сохраняет содержимое записи двоичных данных в файл, указанный пользователем.
+func saveUsersBinary(binary storage.Binary, sndr *sender.GophKeeperClient) {
+	var file string
+	fmt.Print("Введите путь к файлу: ")
+	fmt.Scanln(&file)
+	if file == "" {
+		file = binary.Name
 	}
-	defer file.Close()
-	var fileBZ = make([]byte, fi.Size())
-	_, err = file.Read(fileBZ)
-	if err != nil {
-		log.Error().Err(err).Msg("readFile reading file err")
-		return "", nil, err
+	err := sndr.SaveBinaryFile(binary, file)
+	switch {
+	case err == nil:
+		fmt.Println("Файл сохранен")
+	case errors.Is(err, gkerrors.ErrNoSuchAttachment):
+		fmt.Println("Вложение не найдено на сервере")
+	case errors.Is(err, gkerrors.ErrAttachmentHash):
+		fmt.Println("Содержимое вложения повреждено: хэш не совпадает")
+	default:
+		log.Error().Err(err).Msg("SaveBinaryFile error")
+		fmt.Println("Ошибка сохранения файла")
 	}
-	return fi.Name(), fileBZ, nil
 }
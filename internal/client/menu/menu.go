@@ -2,26 +2,32 @@
 package menu
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"gophkeeper/internal/client/sender"
+	gkerrors "gophkeeper/internal/errors"
 )
 
-// EnteringMenu функция запрашивает сессию перед началом аутентификации пользователя
+// EnteringMenu функция запрашивает сессию перед началом аутентификации пользователя.
+// Пока соединение не установлено, пользователь может открыть локальную копию своих данных.
 func EnteringMenu(sndr sender.GophKeeperClient) bool {
 	err := sndr.ReqSessionID()
 	if err != nil {
 		log.Error().Err(err).Msg("Get sessionID error")
-		fmt.Println("Соединение с сервером не установлено, попробовать еще раз?")
+		fmt.Println("Соединение с сервером не установлено, попробовать еще раз? Для работы с локальной копией данных нажмите O")
 		for {
 			var act string
-			fmt.Print("Введите команду Yes или No: ")
+			fmt.Print("Введите команду Yes, No или O: ")
 			fmt.Scanln(&act)
 			switch act {
 			case "Y", "y", "Yes", "yes":
@@ -30,7 +36,12 @@ func EnteringMenu(sndr sender.GophKeeperClient) bool {
 					return true
 				}
 				log.Error().Err(err).Msg("Get sessionID error")
-				fmt.Println("Соединение с сервером не установлено, попробовать еще раз?")
+				fmt.Println("Соединение с сервером не установлено, попробовать еще раз? Для работы с локальной копией данных нажмите O")
+			case "O", "o":
+				if offlineMenu(sndr) {
+					return true
+				}
+				fmt.Println("Соединение с сервером не установлено, попробовать еще раз? Для работы с локальной копией данных нажмите O")
 			case "N", "n", "Q", "q", "No", "no":
 				return false
 			default:
@@ -117,14 +128,17 @@ func register(sndr sender.GophKeeperClient) bool {
 func login(sndr sender.GophKeeperClient) bool {
 	login, pass := enterLogin()
 	err := sndr.UserLogin(login, pass)
+	if errors.Is(err, gkerrors.ErrTOTPRequired) {
+		err = sndr.UserLoginTOTP(login, pass, enterTOTPCode())
+	}
 	st, ok := status.FromError(err)
 	if ok {
-		if st.Code() == codes.NotFound {
-			fmt.Println("Пользователя с таким логином не существует. Попробуйте ввести данные еще раз")
+		if st.Code() == codes.PermissionDenied {
+			fmt.Println("Неверный логин, пароль или код подтверждения. Попробуйте ввести данные еще раз")
 			return true
 		}
-		if st.Code() == codes.PermissionDenied {
-			fmt.Println("Неверный пароль. Попробуйте ввести данные еще раз")
+		if st.Code() == codes.ResourceExhausted {
+			fmt.Println("Слишком много неудачных попыток входа. Вход временно заблокирован, попробуйте позже")
 			return true
 		}
 		if st.Code() == codes.Unauthenticated {
@@ -163,24 +177,123 @@ func login(sndr sender.GophKeeperClient) bool {
 		fmt.Println("Неизвестная ошибка при авторизации пользователя")
 		return false
 	}
+	if sndr.Strg.ChangedCount() > 0 {
+		syncData(sndr)
+	}
 	return mainMenu(sndr)
 }
 
+// offlineMenu функция меню работы с локальной копией данных без соединения с сервером.
+// Изменения сохраняются в локальном кэше и отправляются на сервер синхронизацией после входа.
+// Возвращает true, если соединение с сервером восстановлено.
+func offlineMenu(sndr sender.GophKeeperClient) bool {
+	login, pass := enterLogin()
+	err := sndr.UnlockOffline(login, pass)
+	if errors.Is(err, gkerrors.ErrNoCache) {
+		fmt.Println("Локальная копия данных пользователя не найдена")
+		return false
+	}
+	if errors.Is(err, gkerrors.ErrWrongPassword) {
+		fmt.Println("Неверный пароль")
+		return false
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("UnlockOffline error")
+		fmt.Println("Ошибка чтения локальной копии данных")
+		return false
+	}
+	fmt.Println("Открыта локальная копия данных. Изменения будут отправлены на сервер при синхронизации после входа")
+	for {
+		var act string
+		fmt.Println(`Введите команду:
+		V - посмотреть пользовательские данные
+		E - отредактировать или добавить новые данные;
+		Y - подключиться к серверу;
+		Q - закрыть локальную копию данных`)
+		fmt.Scanln(&act)
+		switch act {
+		case "V", "v":
+			viewData(sndr)
+		case "E", "e":
+			editData(sndr)
+			saveCache(sndr)
+		case "Y", "y":
+			err = sndr.ReqSessionID()
+			if err != nil {
+				log.Error().Err(err).Msg("Get sessionID error")
+				fmt.Println("Соединение с сервером не установлено")
+				continue
+			}
+			sndr.CloseOffline()
+			fmt.Println("Войдите, чтобы отправить изменения на сервер")
+			return true
+		case "Q", "q":
+			sndr.CloseOffline()
+			return false
+		default:
+			fmt.Println("Команда не распознана")
+		}
+	}
+}
+
+// saveCache функция сохраняет изменения данных в локальном кэше.
+func saveCache(sndr sender.GophKeeperClient) {
+	err := sndr.SaveCache()
+	if err != nil {
+		log.Error().Err(err).Msg("SaveCache error")
+		fmt.Println("Ошибка сохранения локальной копии данных")
+	}
+}
+
 // mainMenu функция основного меню клиента
 func mainMenu(sndr sender.GophKeeperClient) bool {
 	fmt.Println("Добро пожаловать в основное меню")
+	// busy захвачен, пока выполняется команда пользователя. Уведомление, пришедшее во время
+	// ожидания ввода команды, загружает новые данные сразу, иначе загрузка откладывается до завершения команды
+	var (
+		busy    sync.Mutex
+		pending atomic.Pointer[sender.ChangeEvent]
+	)
+	busy.Lock()
+	stopWatch := sndr.StartWatch(func(event sender.ChangeEvent) {
+		if event.Kind != sender.ChangeSaved {
+			fmt.Fprintln(sndr.Out, event)
+			return
+		}
+		if !busy.TryLock() {
+			pending.Store(&event)
+			fmt.Fprintf(sndr.Out, "%s. Новая версия данных будет загружена после завершения команды\n", event)
+			return
+		}
+		defer busy.Unlock()
+		fmt.Fprintln(sndr.Out, event)
+		if sndr.IsNewer(event) {
+			pullChanges(sndr)
+		}
+	})
+	defer stopWatch()
 	for {
+		if event := pending.Swap(nil); event != nil && sndr.IsNewer(*event) {
+			pullChanges(sndr)
+		}
+		busy.Unlock()
 		var act string
 		fmt.Println(`Введите команду:
 		C - Проверить актуальный статус данных
 		D - скачать пользовательские данные;
 		S - сохранить данные на сервер;
+		Y - синхронизировать измененные записи с сервером;
 		V - посмотреть пользовательские данные
 		E - отредактировать или добавить новые данные;
 		U - изменить пароль;
+		H - просмотреть историю версий и восстановить данные;
+		A - просмотреть активные сессии и завершить сессию на другом устройстве;
+		T - включить или отключить двухфакторную аутентификацию;
+		X - удалить учетную запись и все данные на сервере;
 		L - разлогиниться;
 		Q - завершить работу`)
 		fmt.Scanln(&act)
+		busy.Lock()
 		switch act {
 		case "C", "c":
 			err := sndr.CheckTimeStamp()
@@ -196,6 +309,11 @@ func mainMenu(sndr sender.GophKeeperClient) bool {
 			}
 		case "D", "d":
 			err := sndr.Download()
+			if errors.Is(err, gkerrors.ErrRecordTypeUnknown) {
+				fmt.Println("Данные на сервере содержат записи неизвестного типа, обновите клиент")
+				log.Error().Err(err).Msg("Download error")
+				continue
+			}
 			st, ok := status.FromError(err)
 			if ok && st.Code() == codes.Unauthenticated {
 				fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
@@ -215,7 +333,8 @@ func mainMenu(sndr sender.GophKeeperClient) bool {
 					continue
 				}
 				if st.Code() == codes.FailedPrecondition {
-					fmt.Printf("Время последнего сохранения на сервере и клиенте не совпадают")
+					fmt.Println("Версии данных на сервере и клиенте не совпадают, выполняется слияние данных")
+					mergeData(sndr)
 					continue
 				}
 				if st.Code() == codes.Unauthenticated {
@@ -229,12 +348,25 @@ func mainMenu(sndr sender.GophKeeperClient) bool {
 				continue
 			}
 			fmt.Println("Данные успешно сохранены на сервере")
+		case "Y", "y":
+			syncData(sndr)
 		case "V", "v":
 			viewData(sndr)
 		case "E", "e":
 			editData(sndr)
+			saveCache(sndr)
 		case "U", "u":
 			editPassword(sndr)
+		case "H", "h":
+			restoreData(sndr)
+		case "A", "a":
+			revokeSessions(sndr)
+		case "T", "t":
+			totpMenu(sndr)
+		case "X", "x":
+			if deleteAccount(sndr) {
+				return true
+			}
 		case "L", "l":
 			sndr.UserLogOut()
 			return true
@@ -247,6 +379,365 @@ func mainMenu(sndr sender.GophKeeperClient) bool {
 	}
 }
 
+// pullChanges функция загружает с сервера данные, сохраненные другой сессией.
+// При несинхронизированных локальных изменениях загрузка остается за пользователем, чтобы конфликты разрешались явно.
+func pullChanges(sndr sender.GophKeeperClient) {
+	if sndr.Strg.ChangedCount() > 0 {
+		fmt.Println("На сервере есть более новая версия данных. Синхронизируйте локальные изменения командой Y, чтобы получить ее")
+		return
+	}
+	syncData(sndr)
+}
+
+// syncData функция синхронизирует измененные записи с сервером.
+func syncData(sndr sender.GophKeeperClient) {
+	conflicts, err := sndr.Sync()
+	if errors.Is(err, gkerrors.ErrRecordTypeUnknown) {
+		fmt.Println("Данные на сервере содержат записи неизвестного типа, обновите клиент")
+		log.Error().Err(err).Msg("Sync error")
+		return
+	}
+	st, ok := status.FromError(err)
+	if ok && st.Code() == codes.PermissionDenied {
+		fmt.Println("Данные на сервере заблокированы на изменение другим пользователем")
+		return
+	}
+	if ok && st.Code() == codes.Unauthenticated {
+		fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+		return
+	}
+	if err != nil {
+		fmt.Println("Произошла ошибка в процессе синхронизации данных")
+		log.Error().Err(err).Msg("Sync error")
+		return
+	}
+	if conflicts > 0 {
+		fmt.Printf("Данные синхронизированы, обнаружено конфликтов: %d. Локальные версии сохранены с отметкой \"(конфликт)\"\n", conflicts)
+		return
+	}
+	fmt.Println("Данные успешно синхронизированы с сервером")
+}
+
+// restoreData функция меню просмотра истории версий данных и восстановления выбранной версии
+func restoreData(sndr sender.GophKeeperClient) {
+	history, err := sndr.ListHistory()
+	st, ok := status.FromError(err)
+	if ok && st.Code() == codes.Unauthenticated {
+		fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+		return
+	}
+	if err != nil {
+		fmt.Println("Произошла ошибка в процессе получения истории версий")
+		log.Error().Err(err).Msg("ListHistory error")
+		return
+	}
+	if len(history) == 0 {
+		fmt.Println("На сервере нет сохраненных предыдущих версий данных")
+		return
+	}
+	fmt.Println("Сохраненные предыдущие версии данных:")
+	for i, version := range history {
+		fmt.Printf("%d. Версия %d, сохранена %s\n", i+1, version.Version, version.TimeStamp)
+	}
+	for {
+		fmt.Println("Для восстановления введите номер версии, или введите 0 для возврата в предыдущее меню")
+		var act string
+		fmt.Scanln(&act)
+		if act == "0" {
+			return
+		}
+		i, err := strconv.Atoi(act)
+		if err != nil || i < 1 || i > len(history) {
+			fmt.Println("Команда не распознана")
+			continue
+		}
+		err = sndr.RestoreVersion(history[i-1].Version)
+		st, ok := status.FromError(err)
+		if ok {
+			if st.Code() == codes.PermissionDenied {
+				fmt.Println("Данные на сервере заблокированы на изменение другим пользователем")
+				return
+			}
+			if st.Code() == codes.FailedPrecondition {
+				fmt.Println("Версии данных на сервере и клиенте не совпадают. Скачайте актуальные данные и повторите восстановление")
+				return
+			}
+			if st.Code() == codes.Unauthenticated {
+				fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+				return
+			}
+		}
+		if err != nil {
+			fmt.Println("Произошла ошибка в процессе восстановления данных")
+			log.Error().Err(err).Msg("RestoreVersion error")
+			return
+		}
+		fmt.Printf("Версия %d восстановлена, текущие данные сохранены в истории\n", history[i-1].Version)
+		return
+	}
+}
+
+// revokeSessions функция меню просмотра активных сессий пользователя и завершения сессий на других устройствах
+func revokeSessions(sndr sender.GophKeeperClient) {
+	for {
+		sessions, err := sndr.ListSessions()
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.Unauthenticated {
+			fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+			return
+		}
+		if err != nil {
+			fmt.Println("Произошла ошибка в процессе получения списка сессий")
+			log.Error().Err(err).Msg("ListSessions error")
+			return
+		}
+		fmt.Println("Активные сессии:")
+		for i, session := range sessions {
+			current := ""
+			if session.Current {
+				current = " (текущая)"
+			}
+			fmt.Printf("%d. Устройство %s, создана %s, последний запрос %s, версия клиента %s, адрес %s%s\n",
+				i+1, session.DeviceName, session.Created, session.LastSeen, session.ClientVersion, session.RemoteAddr, current)
+		}
+		fmt.Println("Для завершения сессии введите ее номер, или введите 0 для возврата в предыдущее меню")
+		var act string
+		fmt.Scanln(&act)
+		if act == "0" {
+			return
+		}
+		i, err := strconv.Atoi(act)
+		if err != nil || i < 1 || i > len(sessions) {
+			fmt.Println("Команда не распознана")
+			continue
+		}
+		if sessions[i-1].Current {
+			fmt.Println("Текущая сессия завершается командой L основного меню")
+			continue
+		}
+		err = sndr.RevokeSession(sessions[i-1].SessionID)
+		st, ok = status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			fmt.Println("Сессия уже завершена")
+			continue
+		}
+		if ok && st.Code() == codes.Unauthenticated {
+			fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+			return
+		}
+		if err != nil {
+			fmt.Println("Произошла ошибка в процессе завершения сессии")
+			log.Error().Err(err).Msg("RevokeSession error")
+			return
+		}
+		fmt.Printf("Сессия %d завершена\n", i)
+	}
+}
+
+// deleteAccountConfirmation слово, которое пользователь вводит для подтверждения удаления учетной записи
+const deleteAccountConfirmation = "УДАЛИТЬ"
+
+// deleteAccount функция меню удаления учетной записи пользователя со всеми данными.
+// Возвращает true, если учетная запись удалена и сессия завершена.
+func deleteAccount(sndr sender.GophKeeperClient) bool {
+	fmt.Println("Учетная запись, все данные, история версий и сессии на всех устройствах будут удалены без возможности восстановления")
+	fmt.Printf("Для подтверждения введите %s, для отмены нажмите Enter\n", deleteAccountConfirmation)
+	var act string
+	fmt.Scanln(&act)
+	if act != deleteAccountConfirmation {
+		fmt.Println("Удаление учетной записи отменено")
+		return false
+	}
+	var password string
+	for {
+		fmt.Println("Введите текущий пароль")
+		fmt.Scanln(&password)
+		if password == "" {
+			fmt.Println("Пароль не может быть пустым")
+			continue
+		}
+		break
+	}
+	err := sndr.DeleteAccount(password)
+	st, ok := status.FromError(err)
+	if ok {
+		if st.Code() == codes.PermissionDenied {
+			fmt.Println("Неверный пароль")
+			return false
+		}
+		if st.Code() == codes.ResourceExhausted {
+			fmt.Println("Слишком много неудачных попыток ввода пароля. Удаление временно заблокировано, попробуйте позже")
+			return false
+		}
+		if st.Code() == codes.Unauthenticated {
+			fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+			return false
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAccount error")
+		fmt.Println("Произошла ошибка при удалении учетной записи")
+		return false
+	}
+	fmt.Println("Учетная запись и все данные удалены")
+	return true
+}
+
+// totpMenu функция меню включения и отключения двухфакторной аутентификации
+func totpMenu(sndr sender.GophKeeperClient) {
+	for {
+		var act string
+		fmt.Println(`Введите команду:
+		E - включить двухфакторную аутентификацию;
+		D - отключить двухфакторную аутентификацию;
+		R - вернуться в основное меню`)
+		fmt.Scanln(&act)
+		switch act {
+		case "E", "e":
+			enableTOTP(sndr)
+			return
+		case "D", "d":
+			err := sndr.DisableTOTP(enterTOTPCode())
+			st, ok := status.FromError(err)
+			if ok && st.Code() == codes.PermissionDenied {
+				fmt.Println("Неверный код подтверждения")
+				return
+			}
+			if ok && st.Code() == codes.ResourceExhausted {
+				fmt.Println("Слишком много неудачных попыток ввода кода. Отключение временно заблокировано, попробуйте позже")
+				return
+			}
+			if ok && st.Code() == codes.Unauthenticated {
+				fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+				return
+			}
+			if errors.Is(err, gkerrors.ErrTOTPNotEnabled) {
+				fmt.Println("Двухфакторная аутентификация не включена")
+				return
+			}
+			if err != nil {
+				fmt.Println("Произошла ошибка при отключении двухфакторной аутентификации")
+				log.Error().Err(err).Msg("DisableTOTP error")
+				return
+			}
+			fmt.Println("Двухфакторная аутентификация отключена")
+			return
+		case "R", "r":
+			return
+		default:
+			fmt.Println("Команда не распознана")
+		}
+	}
+}
+
+// enableTOTP функция меню получения секрета двухфакторной аутентификации и его подтверждения кодом
+func enableTOTP(sndr sender.GophKeeperClient) {
+	setup, err := sndr.EnableTOTP()
+	st, ok := status.FromError(err)
+	if ok && st.Code() == codes.Unauthenticated {
+		fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+		return
+	}
+	if errors.Is(err, gkerrors.ErrTOTPEnabled) {
+		fmt.Println("Двухфакторная аутентификация уже включена")
+		return
+	}
+	if err != nil {
+		fmt.Println("Произошла ошибка при получении секрета двухфакторной аутентификации")
+		log.Error().Err(err).Msg("EnableTOTP error")
+		return
+	}
+	fmt.Println("Добавьте секрет в приложение-аутентификатор по ссылке или введите его вручную")
+	fmt.Println("Ссылка:", setup.URI)
+	fmt.Println("Секрет:", setup.Secret)
+	fmt.Println("Сохраните коды восстановления, каждый из них можно использовать для входа один раз вместо кода из приложения:")
+	for _, code := range setup.RecoveryCodes {
+		fmt.Println(code)
+	}
+	for {
+		err = sndr.ConfirmTOTP(enterTOTPCode())
+		if errors.Is(err, gkerrors.ErrTOTPCodeIncorrect) {
+			fmt.Println("Неверный код, проверьте время на устройстве и попробуйте еще раз")
+			if confirm("Повторить ввод кода? Y - да, N - нет") {
+				continue
+			}
+			fmt.Println("Двухфакторная аутентификация не включена")
+			return
+		}
+		st, ok = status.FromError(err)
+		if ok && st.Code() == codes.ResourceExhausted {
+			fmt.Println("Слишком много неудачных попыток ввода кода. Включение временно заблокировано, попробуйте позже")
+			return
+		}
+		if ok && st.Code() == codes.Unauthenticated {
+			fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+			return
+		}
+		if err != nil {
+			fmt.Println("Произошла ошибка при включении двухфакторной аутентификации")
+			log.Error().Err(err).Msg("ConfirmTOTP error")
+			return
+		}
+		fmt.Println("Двухфакторная аутентификация включена")
+		return
+	}
+}
+
+// enterTOTPCode функция запрашивает у пользователя код из приложения-аутентификатора или код восстановления
+func enterTOTPCode() string {
+	var code string
+	for {
+		fmt.Println("Введите код из приложения-аутентификатора или код восстановления")
+		fmt.Scanln(&code)
+		if code == "" {
+			fmt.Println("Код не может быть пустым")
+			continue
+		}
+		return code
+	}
+}
+
+// mergeData функция меню слияния локальных данных с данными сервера и повторного сохранения
+func mergeData(sndr sender.GophKeeperClient) {
+	res, err := sndr.MergeServerData()
+	if err != nil {
+		fmt.Println("Произошла ошибка в процессе получения данных для слияния")
+		log.Error().Err(err).Msg("MergeServerData error")
+		return
+	}
+	for i := range res.Conflicts {
+		conflict := &res.Conflicts[i]
+		if conflict.Field == "" {
+			fmt.Printf("Конфликт записи \"%s\":\n", conflict.Name)
+		} else {
+			fmt.Printf("Конфликт в поле %s записи \"%s\":\n", conflict.Field, conflict.Name)
+		}
+		fmt.Printf("1 - оставить локальное значение: %s\n2 - принять значение с сервера: %s\n", conflict.Local, conflict.Server)
+		for {
+			var act string
+			fmt.Scanln(&act)
+			if act == "1" || act == "2" {
+				conflict.UseServer = act == "2"
+				break
+			}
+			fmt.Println("Команда не распознана, введите 1 или 2")
+		}
+	}
+	err = sndr.Strg.ApplyMerge(res)
+	if err != nil {
+		fmt.Println("Произошла ошибка в процессе слияния данных")
+		log.Error().Err(err).Msg("ApplyMerge error")
+		return
+	}
+	err = sndr.SaveData()
+	if err != nil {
+		fmt.Println("Произошла ошибка в процессе сохранения объединенных данных")
+		log.Error().Err(err).Msg("SaveData after merge error")
+		return
+	}
+	fmt.Println("Данные объединены и успешно сохранены на сервере")
+}
+
 // enterLogin функция меню ввода и проверки имени пользователя и пароля
 func enterLogin() (string, string) {
 	var login, pass string
@@ -381,10 +872,29 @@ func viewData(sndr sender.GophKeeperClient) {
 // editData функция меню для редактирования и создания новых записей пользователя
 func editData(sndr sender.GophKeeperClient) {
 	err := sndr.LockUserData()
+	if errors.Is(err, gkerrors.ErrLocked) && confirmTakeOver() {
+		err = sndr.TakeOverLock()
+	}
 	if err != nil {
 		fmt.Println("Ошибка блокировки данных. Возвращаю в предыдущее меню")
 		return
 	}
+	stopRenewal := sndr.StartLockRenewal()
+	before, err := sndr.Strg.ExportRecords()
+	if err != nil {
+		log.Error().Err(err).Msg("editData ExportRecords error")
+	}
+	defer func() {
+		stopRenewal()
+		after, err := sndr.Strg.ExportRecords()
+		if err != nil || !reflect.DeepEqual(before, after) {
+			return
+		}
+		err = sndr.ReleaseLock()
+		if err != nil {
+			log.Error().Err(err).Msg("editData ReleaseLock error")
+		}
+	}()
 	passwords, cards, texts, binaries := sndr.Strg.ListUserData()
 	fmt.Printf(`В базе содержится следующее количество записей:
 	Количество сохраненных паролей: %d
@@ -399,6 +909,7 @@ func editData(sndr sender.GophKeeperClient) {
 			C - карты;
 			T - тексты;
 			B - бинарные данные;
+			K - корзина удаленных записей;
 			R - вернуться в предыдущее меню.`)
 		fmt.Scanln(&act)
 		switch act {
@@ -406,12 +917,14 @@ func editData(sndr sender.GophKeeperClient) {
 			printSliceUserData(usersPasswords, sndr)
 		loop_P:
 			for {
-				fmt.Println("для добавления новой записи введите N\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
+				fmt.Println("для добавления новой записи введите N\nДля удаления записи введите D\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
 				var act string
 				fmt.Scanln(&act)
 				switch act {
 				case "N", "n":
 					addUsersData(usersPasswords, sndr)
+				case "D", "d":
+					deleteUsersData(usersPasswords, sndr)
 				case "0":
 					break loop_P
 				default:
@@ -427,12 +940,14 @@ func editData(sndr sender.GophKeeperClient) {
 			printSliceUserData(usersCards, sndr)
 		loop_N:
 			for {
-				fmt.Println("для добавления новой записи введите N\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
+				fmt.Println("для добавления новой записи введите N\nДля удаления записи введите D\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
 				var act string
 				fmt.Scanln(&act)
 				switch act {
 				case "N", "n":
 					addUsersData(usersCards, sndr)
+				case "D", "d":
+					deleteUsersData(usersCards, sndr)
 				case "0":
 					break loop_N
 				default:
@@ -448,12 +963,14 @@ func editData(sndr sender.GophKeeperClient) {
 			printSliceUserData(usersTexts, sndr)
 		loop_T:
 			for {
-				fmt.Println("для добавления новой записи введите N\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
+				fmt.Println("для добавления новой записи введите N\nДля удаления записи введите D\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
 				var act string
 				fmt.Scanln(&act)
 				switch act {
 				case "N", "n":
 					addUsersData(usersTexts, sndr)
+				case "D", "d":
+					deleteUsersData(usersTexts, sndr)
 				case "0":
 					break loop_T
 				default:
@@ -469,12 +986,14 @@ func editData(sndr sender.GophKeeperClient) {
 			printSliceUserData(usersTexts, sndr)
 		loop_B:
 			for {
-				fmt.Println("для добавления новой записи введите N\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
+				fmt.Println("для добавления новой записи введите N\nДля удаления записи введите D\nДля редактирования введите номер записи, или введите 0 для возврата в предыдущее меню")
 				var act string
 				fmt.Scanln(&act)
 				switch act {
 				case "N", "n":
 					addUsersData(usersBinaries, sndr)
+				case "D", "d":
+					deleteUsersData(usersBinaries, sndr)
 				case "0":
 					break loop_B
 				default:
@@ -486,6 +1005,8 @@ func editData(sndr sender.GophKeeperClient) {
 					editUsersData(usersBinaries, i, sndr)
 				}
 			}
+		case "K", "k":
+			trashMenu(sndr)
 		case "R", "r":
 			return
 		default:
@@ -494,6 +1015,24 @@ func editData(sndr sender.GophKeeperClient) {
 	}
 }
 
+// confirmTakeOver функция запрашивает подтверждение на перехват блокировки данных у другой сессии пользователя
+func confirmTakeOver() bool {
+	fmt.Println("Если блокировка осталась на другом вашем устройстве, ее можно перехватить. Несохраненные изменения на том устройстве не удастся сохранить без слияния. Перехватить блокировку?")
+	for {
+		var act string
+		fmt.Print("Введите команду Yes или No: ")
+		fmt.Scanln(&act)
+		switch act {
+		case "Y", "y", "Yes", "yes":
+			return true
+		case "N", "n", "No", "no":
+			return false
+		default:
+			fmt.Println("Команда не распознана")
+		}
+	}
+}
+
 func editPassword(sndr sender.GophKeeperClient) {
 	var oldPassword, newPassword, checkPassword string
 	for {
@@ -527,10 +1066,14 @@ func editPassword(sndr sender.GophKeeperClient) {
 	true, err := sndr.ChangePassword(oldPassword, newPassword)
 	st, ok := status.FromError(err)
 	if ok {
-		if st.Code() == codes.InvalidArgument {
+		if st.Code() == codes.PermissionDenied {
 			fmt.Println("Неверный пароль")
 			return
 		}
+		if st.Code() == codes.ResourceExhausted {
+			fmt.Println("Слишком много неудачных попыток ввода пароля. Смена пароля временно заблокирована, попробуйте позже")
+			return
+		}
 		if st.Code() == codes.Unauthenticated {
 			fmt.Println("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
 			return
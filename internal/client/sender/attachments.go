@@ -0,0 +1,269 @@
+package sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	"gophkeeper/internal/client/crypto"
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// MaxInlineSize максимальный размер файла, сохраняемого в самой записи двоичных данных.
+// Файлы большего размера загружаются на сервер вложением.
+const MaxInlineSize = 65536
+
+// attachmentChunkSize размер части файла, зашифровываемой и передаваемой одним сообщением потока.
+const attachmentChunkSize = 256 * 1024
+
+// ReadBinary метод считывает файл path в запись двоичных данных. Файл размером до MaxInlineSize сохраняется в записи,
+// файл большего размера загружается на сервер вложением, и запись хранит его идентификатор, хэш и размер.
+// Если имя записи не задано, используется имя файла. При превышении квоты вложений возвращается ошибка ErrQuotaExceeded.
+func (c *GophKeeperClient) ReadBinary(path string, binary *storage.Binary) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fs.ErrInvalid
+	}
+	if fi.Size() <= MaxInlineSize {
+		binary.Data, err = os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		binary.Attachment, binary.Hash, binary.Size = "", "", 0
+	} else {
+		binary.Attachment, binary.Hash, err = c.UploadAttachment(path, fi.Size())
+		if err != nil {
+			return err
+		}
+		binary.Data, binary.Size = nil, fi.Size()
+	}
+	if binary.Name == "" {
+		binary.Name = filepath.Base(path)
+	}
+	return nil
+}
+
+// WriteBinary метод записывает содержимое записи двоичных данных в w.
+// Содержимое вложения скачивается с сервера и записывается в w только после проверки хэша:
+// при несовпадении возвращается ошибка ErrAttachmentHash.
+func (c *GophKeeperClient) WriteBinary(binary storage.Binary, w io.Writer) error {
+	if binary.Attachment == "" {
+		_, err := w.Write(binary.Data)
+		return err
+	}
+	return c.DownloadAttachment(binary.Attachment, binary.Hash, w)
+}
+
+// SaveBinaryFile метод сохраняет содержимое записи двоичных данных в файл path.
+// Файл создается только после успешной проверки содержимого.
+func (c *GophKeeperClient) SaveBinaryFile(binary storage.Binary, path string) error {
+	file, err := os.OpenFile(path+".part", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if binary.Attachment == "" {
+		_, err = file.Write(binary.Data)
+	} else {
+		err = c.downloadAttachment(binary.Attachment, binary.Hash, file)
+	}
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err != nil {
+		os.Remove(path + ".part")
+		return err
+	}
+	return os.Rename(path+".part", path)
+}
+
+// UploadAttachment метод загружает на сервер файл path размером size байт вложением.
+// Файл зашифровывается ключом данных частями по attachmentChunkSize байт. Возвращает идентификатор вложения и хэш SHA-256 содержимого.
+func (c *GophKeeperClient) UploadAttachment(path string, size int64) (string, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.cc.UploadAttachment(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	var request = pb.UploadAttachmentRequest{SessionID: c.rsa.GetSessionID(), Size: encryptedSize(size)}
+	err = stream.Send(&request)
+	hash := sha256.New()
+	in := io.LimitReader(file, size)
+	buf := make([]byte, attachmentChunkSize)
+	var read int64
+	var index uint64
+	for err == nil && read < size {
+		var n int
+		n, err = io.ReadFull(in, buf)
+		if errors.Is(err, io.ErrUnexpectedEOF) && read+int64(n) == size {
+			err = nil
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				err = gkerrors.ErrAttachmentSize
+			}
+			return "", "", err
+		}
+		read += int64(n)
+		hash.Write(buf[:n])
+		var chunk []byte
+		chunk, err = c.rsa.EncryptAttachmentChunk(buf[:n], index)
+		if err != nil {
+			return "", "", err
+		}
+		index++
+		err = stream.Send(&pb.UploadAttachmentRequest{Data: chunk})
+	}
+	// При ошибке сервер завершает поток, и Send возвращает io.EOF: причина ошибки передается в ответе
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", "", err
+	}
+	responce, err := stream.CloseAndRecv()
+	if status.Code(err) == codes.ResourceExhausted {
+		return "", "", gkerrors.ErrQuotaExceeded
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return "", "", gkerrors.ErrSignIncorrect
+	}
+	log.Debug().Msgf("attachment uploaded, used %d of %d bytes", responce.Used, responce.Quota)
+	return responce.AttachmentID, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// DownloadAttachment метод скачивает вложение id с сервера, расшифровывает его части и записывает их в w.
+// Расшифрованное содержимое сохраняется во временный файл и передается в w только после проверки хэша:
+// если хэш не совпадает с hash, возвращается ошибка ErrAttachmentHash, и в w ничего не записывается.
+func (c *GophKeeperClient) DownloadAttachment(id, hash string, w io.Writer) error {
+	file, err := os.CreateTemp("", "gophkeeper-attachment-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	err = c.downloadAttachment(id, hash, file)
+	if err != nil {
+		return err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// downloadAttachment метод скачивает вложение id с сервера, расшифровывает его части, записывает их в w
+// и проверяет хэш содержимого. Части расшифровываются с проверкой их номера.
+func (c *GophKeeperClient) downloadAttachment(id, hash string, w io.Writer) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var request = pb.DownloadAttachmentRequest{SessionID: c.rsa.GetSessionID(), AttachmentID: id}
+	stream, err := c.cc.DownloadAttachment(ctx, &request)
+	if err != nil {
+		return err
+	}
+	sum := sha256.New()
+	var index uint64
+	for first := true; ; first = false {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if status.Code(err) == codes.NotFound {
+			return gkerrors.ErrNoSuchAttachment
+		}
+		if err != nil {
+			return err
+		}
+		if first && c.rsa.CheckSign(chunk.Sign) != nil {
+			return gkerrors.ErrSignIncorrect
+		}
+		data, err := c.rsa.DecryptAttachmentChunk(chunk.Data, index)
+		if err != nil {
+			return err
+		}
+		index++
+		sum.Write(data)
+		_, err = w.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+	if hex.EncodeToString(sum.Sum(nil)) != hash {
+		return gkerrors.ErrAttachmentHash
+	}
+	return nil
+}
+
+// DeleteAttachment метод удаляет вложение на сервере.
+func (c *GophKeeperClient) DeleteAttachment(id string) error {
+	var request = pb.DeleteAttachmentRequest{SessionID: c.rsa.GetSessionID(), AttachmentID: id}
+	responce, err := c.cc.DeleteAttachment(context.Background(), &request)
+	if status.Code(err) == codes.NotFound {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	return nil
+}
+
+// releaseAttachments метод после обмена данными с сервером удаляет вложения, на которые ссылались записи
+// при предыдущем обмене или до текущего обмена, но больше не ссылается ни одна запись. Ошибка удаления не прерывает работу.
+func (c *GophKeeperClient) releaseAttachments(before map[string]bool) {
+	after := c.Strg.Attachments()
+	for id := range c.attachments {
+		before[id] = true
+	}
+	for id := range before {
+		if after[id] {
+			continue
+		}
+		err := c.DeleteAttachment(id)
+		if err != nil && !errors.Is(err, gkerrors.ErrNoSuchAttachment) {
+			log.Error().Err(err).Msg("DeleteAttachment error")
+		}
+	}
+	for id := range c.attachments {
+		delete(c.attachments, id)
+	}
+	for id := range after {
+		c.attachments[id] = true
+	}
+}
+
+// encryptedSize функция возвращает общий размер зашифрованных частей файла размером size байт.
+func encryptedSize(size int64) int64 {
+	chunks := size / attachmentChunkSize
+	total := chunks * crypto.EncryptedSize(attachmentChunkSize)
+	if rest := size % attachmentChunkSize; rest > 0 {
+		total += crypto.EncryptedSize(int(rest))
+	}
+	return total
+}
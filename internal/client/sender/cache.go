@@ -0,0 +1,211 @@
+package sender
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"gophkeeper/internal/client/crypto"
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// cacheFile структура локального кэша данных пользователя.
+// Записи хранятся зашифрованными ключом данных, ключ данных - зашифрованным мастер-ключом,
+// поэтому для расшифровки кэша без соединения с сервером нужен пароль пользователя.
+// Логин в кэше не хранится: файл кэша находится по хэшу логина.
+type cacheFile struct {
+	KeyParams  crypto.KeyParams `json:"key_params"`
+	WrappedKey []byte           `json:"wrapped_key"`
+	TimeStamp  time.Time        `json:"time_stamp"`
+	Version    int64            `json:"version"`
+	Revision   int64            `json:"revision"`
+	Records    []byte           `json:"records"` //Упакованные зашифрованные записи с несинхронизированными изменениями
+	Base       []byte           `json:"base"`    //Упакованная зашифрованная последняя синхронизированная копия записей
+	Changed    []string         `json:"changed"` //Очередь записей, измененных после последней синхронизации
+}
+
+// SaveCache метод сохраняет данные пользователя и очередь несинхронизированных изменений в локальный кэш.
+// Кэш не сохраняется, если каталог кэша не задан или ключ данных пользователя не зашифрован мастер-ключом.
+func (c *GophKeeperClient) SaveCache() error {
+	if c.CacheDir == "" || c.login == "" {
+		return nil
+	}
+	params, ok := c.rsa.GetKeyParams()
+	wrapped := c.rsa.GetWrappedKey()
+	if !ok || wrapped == nil {
+		return nil
+	}
+	snap, err := c.Strg.Snapshot()
+	if err != nil {
+		return err
+	}
+	err = c.encryptRecords(snap.Records)
+	if err != nil {
+		return err
+	}
+	err = c.encryptRecords(snap.Base)
+	if err != nil {
+		return err
+	}
+	cache := cacheFile{KeyParams: params, WrappedKey: wrapped, TimeStamp: snap.TimeStamp,
+		Version: snap.Version, Revision: snap.Revision, Changed: snap.Changed}
+	cache.Records, err = records.Pack(snap.Records)
+	if err != nil {
+		return err
+	}
+	cache.Base, err = records.Pack(snap.Base)
+	if err != nil {
+		return err
+	}
+	jsonBZ, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(c.CacheDir, 0700)
+	if err != nil {
+		return err
+	}
+	path := c.cachePath(c.login)
+	err = os.WriteFile(path+".tmp", jsonBZ, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// UnlockOffline метод расшифровывает локальный кэш данных пользователя паролем без соединения с сервером.
+// Изменения, выполненные после расшифровки, сохраняются в кэше и отправляются на сервер при следующей синхронизации.
+// Если кэш не найден, возвращается ошибка ErrNoCache, если пароль неверный - ErrWrongPassword.
+func (c *GophKeeperClient) UnlockOffline(login, pass string) error {
+	cache, err := c.readCache(login)
+	if err != nil {
+		return err
+	}
+	keyBZ, err := crypto.DeriveMasterKey(pass, cache.KeyParams).Unwrap(cache.WrappedKey)
+	if err != nil {
+		return err
+	}
+	err = c.rsa.WriteSymmetricalKey(keyBZ)
+	if err != nil {
+		return err
+	}
+	c.rsa.WriteKeyParams(cache.KeyParams)
+	c.rsa.WriteWrappedKey(cache.WrappedKey)
+	err = c.restoreCache(cache)
+	if err != nil {
+		log.Error().Err(err).Msg("UnlockOffline restoreCache error")
+		return err
+	}
+	c.login = login
+	return nil
+}
+
+// CloseOffline метод завершает работу без соединения с сервером: сохраняет изменения в локальном кэше и очищает расшифрованные данные.
+func (c *GophKeeperClient) CloseOffline() {
+	c.updateCache()
+	c.login = ""
+	*c.Strg = *storage.NewUserStorage()
+}
+
+// loadCache метод после авторизации восстанавливает из локального кэша изменения, выполненные без соединения с сервером.
+// Восстановленные изменения отправляются на сервер обычной синхронизацией. Если кэш не был обновлен после
+// предыдущей отправки, изменение, уже сохраненное на сервере, принимается синхронизацией без конфликта.
+func (c *GophKeeperClient) loadCache(login string) {
+	c.login = login
+	cache, err := c.readCache(login)
+	if err != nil {
+		if !errors.Is(err, gkerrors.ErrNoCache) {
+			log.Error().Err(err).Msg("loadCache readCache error")
+		}
+		return
+	}
+	if len(cache.Changed) == 0 {
+		return
+	}
+	strg := c.Strg
+	c.Strg = storage.NewUserStorage()
+	err = c.restoreCache(cache)
+	if err != nil {
+		log.Error().Err(err).Msg("loadCache restoreCache error")
+		c.Strg = strg
+		return
+	}
+	fmt.Fprintf(c.Out, "Найдены изменения, выполненные без соединения с сервером: %d. Они будут отправлены при синхронизации\n", len(cache.Changed))
+}
+
+// removeCache метод удаляет локальный кэш данных пользователя.
+func (c *GophKeeperClient) removeCache() {
+	if c.CacheDir == "" || c.login == "" {
+		return
+	}
+	err := os.Remove(c.cachePath(c.login))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Error().Err(err).Msg("removeCache error")
+	}
+}
+
+// updateCache метод обновляет локальный кэш после обмена данными с сервером. Ошибка сохранения кэша не прерывает работу.
+func (c *GophKeeperClient) updateCache() {
+	err := c.SaveCache()
+	if err != nil {
+		log.Error().Err(err).Msg("SaveCache error")
+	}
+}
+
+// readCache метод читает локальный кэш данных пользователя.
+func (c *GophKeeperClient) readCache(login string) (cacheFile, error) {
+	if c.CacheDir == "" {
+		return cacheFile{}, gkerrors.ErrNoCache
+	}
+	jsonBZ, err := os.ReadFile(c.cachePath(login))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cacheFile{}, gkerrors.ErrNoCache
+	}
+	if err != nil {
+		return cacheFile{}, err
+	}
+	var cache cacheFile
+	err = json.Unmarshal(jsonBZ, &cache)
+	if err != nil {
+		return cacheFile{}, err
+	}
+	return cache, nil
+}
+
+// restoreCache метод расшифровывает записи кэша ключом данных сессии и восстанавливает их в хранилище.
+func (c *GophKeeperClient) restoreCache(cache cacheFile) error {
+	recs, _, err := records.Unpack(cache.Records)
+	if err != nil {
+		return err
+	}
+	base, _, err := records.Unpack(cache.Base)
+	if err != nil {
+		return err
+	}
+	_, err = c.decryptRecords(recs)
+	if err != nil {
+		return err
+	}
+	_, err = c.decryptRecords(base)
+	if err != nil {
+		return err
+	}
+	return c.Strg.RestoreSnapshot(storage.Snapshot{TimeStamp: cache.TimeStamp, Version: cache.Version, Revision: cache.Revision,
+		Records: recs, Base: base, Changed: cache.Changed})
+}
+
+// cachePath метод возвращает путь к файлу кэша пользователя. Имя файла не раскрывает логин пользователя.
+func (c *GophKeeperClient) cachePath(login string) string {
+	hash := sha256.Sum256([]byte(login))
+	return filepath.Join(c.CacheDir, hex.EncodeToString(hash[:])+".json")
+}
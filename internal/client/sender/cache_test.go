@@ -0,0 +1,91 @@
+package sender
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gophkeeper/internal/client/crypto"
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// newCacheClient функция создает клиента без соединения с сервером с ключом данных, зашифрованным мастер-ключом пароля pass.
+func newCacheClient(t *testing.T, dir, pass string) *GophKeeperClient {
+	rsa, err := crypto.NewUserSession()
+	require.NoError(t, err)
+	params, err := crypto.NewKeyParams()
+	require.NoError(t, err)
+	symKey, err := crypto.NewSymmetricalKey()
+	require.NoError(t, err)
+	wrapped, err := crypto.DeriveMasterKey(pass, params).Wrap(symKey)
+	require.NoError(t, err)
+	require.NoError(t, rsa.WriteSymmetricalKey(symKey))
+	rsa.WriteKeyParams(params)
+	rsa.WriteWrappedKey(wrapped)
+	client := NewGophKeeperClient(nil, rsa, storage.NewUserStorage())
+	client.CacheDir = dir
+	client.Out = &bytes.Buffer{}
+	return &client
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	online := newCacheClient(t, dir, "secret")
+
+	// Без авторизованного пользователя кэш не сохраняется
+	require.NoError(t, online.SaveCache())
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	online.login = "user"
+	online.Strg.AddUsersPassword(&storage.Password{Name: "mail", Pass: "p@ss"})
+	online.Strg.Revision = 7
+	require.NoError(t, online.SaveCache())
+	jsonBZ, err := os.ReadFile(online.cachePath("user"))
+	require.NoError(t, err)
+	require.NotContains(t, string(jsonBZ), "p@ss")
+	require.NotContains(t, string(jsonBZ), "mail")
+	require.NotContains(t, string(jsonBZ), `"user"`)
+
+	// Расшифровка кэша без соединения с сервером
+	offline := NewGophKeeperClient(nil, mustSession(t), storage.NewUserStorage())
+	offline.CacheDir = dir
+	require.ErrorIs(t, offline.UnlockOffline("other", "secret"), gkerrors.ErrNoCache)
+	require.ErrorIs(t, offline.UnlockOffline("user", "wrong"), gkerrors.ErrWrongPassword)
+	require.NoError(t, offline.UnlockOffline("user", "secret"))
+	require.Equal(t, online.Strg.Passwords, offline.Strg.Passwords)
+	require.Equal(t, int64(7), offline.Strg.Revision)
+	require.Equal(t, 1, offline.Strg.ChangedCount())
+
+	// Изменения без соединения сохраняются в кэше и закрываются вместе с локальной копией
+	offline.Strg.AddUsersText(&storage.Text{Name: "note", Data: "text"})
+	offline.CloseOffline()
+	require.Empty(t, offline.Strg.Passwords)
+	require.Empty(t, offline.login)
+
+	// После авторизации изменения восстанавливаются для отправки синхронизацией
+	online.Strg = storage.NewUserStorage()
+	online.loadCache("user")
+	require.Len(t, online.Strg.Passwords, 1)
+	require.Len(t, online.Strg.Texts, 1)
+	changed, err := online.Strg.ChangedRecords()
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	require.Contains(t, online.Out.(*bytes.Buffer).String(), "изменения, выполненные без соединения с сервером: 2")
+
+	// Кэш удаляется вместе с учетной записью
+	online.removeCache()
+	_, err = os.Stat(online.cachePath("user"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// mustSession функция создает сессию клиента без ключей данных.
+func mustSession(t *testing.T) *crypto.UserSession {
+	rsa, err := crypto.NewUserSession()
+	require.NoError(t, err)
+	return rsa
+}
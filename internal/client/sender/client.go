@@ -3,34 +3,46 @@
 package sender
 
 import (
-	"bytes"
 	"context"
-	"crypto/rsa"
-	"encoding/gob"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "gophkeeper/api/grpc/proto"
 	"gophkeeper/internal/client/crypto"
 	"gophkeeper/internal/client/storage"
 	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
 )
 
 // GophKeeperClient поддерживает все необходимые методы клиента.
 type GophKeeperClient struct {
 	// pb.GophKeeperClient
-	cc   pb.GophKeeperClient
-	rsa  *crypto.UserSession
-	Strg *storage.UserStorage
+	cc      pb.GophKeeperClient
+	rsa     *crypto.UserSession
+	Strg    *storage.UserStorage
+	Version string    //Версия приложения клиента, передается серверу при запросе сессии
+	Device  string    //Имя устройства клиента, передается серверу при запросе сессии
+	Out     io.Writer //Вывод сообщений пользователю, по умолчанию стандартный вывод
+	// CacheDir каталог локального кэша данных пользователя для работы без соединения с сервером, пустой - кэш не сохраняется
+	CacheDir string
+	login    string //Логин авторизованного пользователя, используется для локального кэша
+	// attachments вложения, на которые ссылались записи при последнем обмене данными с сервером
+	attachments map[string]bool
+	pulled      bool       //После входа получены все записи сервера, записи в устаревшем формате помечены измененными
+	lock        *lockState //Блокировка данных текущей сессией, общая для копий клиента и фонового продления
 }
 
 // NewGophKeeperClient генерирует структуру для gRPC клиента.
 func NewGophKeeperClient(cc grpc.ClientConnInterface, rsa *crypto.UserSession, strg *storage.UserStorage) GophKeeperClient {
 	cci := pb.NewGophKeeperClient(cc)
-	return GophKeeperClient{cc: cci, rsa: rsa, Strg: strg}
+	return GophKeeperClient{cc: cci, rsa: rsa, Strg: strg, Out: os.Stdout, attachments: make(map[string]bool), lock: &lockState{}}
 }
 
 // RefreshToken метод обновляет ключи сессии
@@ -38,53 +50,47 @@ func (c *GophKeeperClient) RefreshToken() error {
 	return c.rsa.RefreshToken()
 }
 
-// ReqSessionID метод запрашивает у сервера сессию и обменивается открытыми ключами
+// ReqSessionID метод запрашивает у сервера сессию, обменивается эфемерными ключами X25519 и вычисляет ключи сессии
 func (c GophKeeperClient) ReqSessionID() error {
-	var publicKeyBZ bytes.Buffer
-	publicKey := c.rsa.GetPublicKey()
-	enc := gob.NewEncoder(&publicKeyBZ)
-	err := enc.Encode(&publicKey)
+	var request = pb.NewSessionIDRequest{UserPublicKeyBZ: c.rsa.GetPublicKey(), ClientVersion: c.Version, DeviceName: c.Device}
+	responce, err := c.cc.NewSessionID(context.Background(), &request)
 	if err != nil {
-		log.Error().Err(err).Msg("ReqSessionID encoding publicKey error")
+		log.Error().Err(err).Msg("ReqSessionID NewSessionID error")
 		return err
 	}
-	var request = pb.NewSessionIDRequest{UserPublicKeyBZ: publicKeyBZ.Bytes()}
-	responce, err := c.cc.NewSessionID(context.Background(), &request)
+	err = c.rsa.WriteSessionID(responce.SessionID, responce.PublicKeyBZ)
 	if err != nil {
-		log.Error().Err(err).Msg("ReqSessionID encoding publicKey error")
+		log.Error().Err(err).Msg("ReqSessionID key exchange error")
 		return err
 	}
-	sessionID := responce.SessionID
-	var buf bytes.Buffer
-	_, err = buf.Write(responce.PublicKeyBZ)
+	return nil
+}
+
+// RegisterUser метод формирует и отправляет запрос на регистрацию нового пользователя.
+// Ключ данных создается на клиенте и передается серверу только зашифрованным мастер-ключом, вычисленным из пароля.
+func (c *GophKeeperClient) RegisterUser(login, pass string) error {
+	params, err := crypto.NewKeyParams()
 	if err != nil {
-		log.Error().Err(err).Msg("NewSessionID writing to buf error")
+		log.Error().Err(err).Msg("RegisterUser NewKeyParams error")
 		return err
 	}
-	var serverPublicKey = rsa.PublicKey{}
-	dec := gob.NewDecoder(&buf)
-	err = dec.Decode(&serverPublicKey)
+	master := crypto.DeriveMasterKey(pass, params)
+	symKey, err := crypto.NewSymmetricalKey()
 	if err != nil {
-		log.Error().Err(err).Msg("NewSessionID decoding publicKey error")
+		log.Error().Err(err).Msg("RegisterUser NewSymmetricalKey error")
 		return err
 	}
-	c.rsa.WriteSessionID(sessionID, &serverPublicKey)
-	return nil
-}
-
-// RegisterUser метод формирует и отправляет запрос на регистрацию нового пользователя
-func (c *GophKeeperClient) RegisterUser(login, pass string) error {
-	message, err := c.rsa.EncryptData(login+","+pass, []byte("login"))
+	wrapped, err := master.Wrap(symKey)
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptData error")
+		log.Error().Err(err).Msg("RegisterUser Wrap error")
 		return err
 	}
-	var request = pb.NewUserRequest{SessionID: c.rsa.GetSessionID(), NewUser: message}
-	request.UserSign, err = c.rsa.UserSign()
+	message, err := c.rsa.EncryptData(login+","+master.AuthKey, []byte("login"))
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
+		log.Error().Err(err).Msg("RegisterUser EncryptData error")
 		return err
 	}
+	var request = pb.NewUserRequest{SessionID: c.rsa.GetSessionID(), NewUser: message, MasterKey: masterKey(params, wrapped)}
 	responce, err := c.cc.NewUser(context.Background(), &request)
 	if err != nil {
 		return err
@@ -96,32 +102,61 @@ func (c *GophKeeperClient) RegisterUser(login, pass string) error {
 	if err != nil {
 		return err
 	}
-	symKey, err := c.rsa.DecryptData(responce.SymKey, []byte("key"))
+	c.rsa.WriteUserID(userID)
+	err = c.rsa.WriteSymmetricalKey(symKey)
 	if err != nil {
 		return err
 	}
-	c.rsa.WriteUserID(userID, symKey)
+	c.rsa.WriteKeyParams(params)
+	c.rsa.WriteWrappedKey(wrapped)
 	c.Strg.TimeStamp, err = time.Parse(time.RFC3339, responce.TimeStamp)
 	if err != nil {
 		return err
 	}
+	c.Strg.Version = 0
+	c.login = login
 	return nil
 }
 
-// UserLogin метод формирует и отправляет запрос на авторизацию пользователя
+// UserLogin метод формирует и отправляет запрос на авторизацию пользователя.
+// Ключ данных расшифровывается мастер-ключом, вычисленным из пароля по параметрам, полученным с сервера.
+// Устаревший ключ данных, в том числе созданный сервером, после авторизации заменяется новым случайным ключом.
+// Устаревший ключ сохраняется на сервере вместе с новым, зашифрованным мастер-ключом, для расшифровки старых данных.
+// Изменения, выполненные без соединения с сервером и сохраненные в локальном кэше, восстанавливаются для отправки при синхронизации.
+// Если у пользователя включена двухфакторная аутентификация, возвращается ошибка ErrTOTPRequired.
 func (c *GophKeeperClient) UserLogin(login, pass string) error {
-	message, err := c.rsa.EncryptData(login+","+pass, []byte("login"))
+	return c.UserLoginTOTP(login, pass, "")
+}
+
+// UserLoginTOTP метод формирует и отправляет запрос на авторизацию пользователя с кодом двухфакторной аутентификации.
+// Вместо кода из приложения-аутентификатора можно передать один из кодов восстановления.
+func (c *GophKeeperClient) UserLoginTOTP(login, pass, code string) error {
+	params, legacy, err := c.keyParams(login)
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptData error")
 		return err
 	}
-	var request = pb.LoginUserRequest{SessionID: c.rsa.GetSessionID(), LoginUser: message}
-	request.UserSign, err = c.rsa.UserSign()
+	var master crypto.MasterKey
+	authKey := pass
+	if !legacy {
+		master = crypto.DeriveMasterKey(pass, params)
+		authKey = master.AuthKey
+	}
+	message, err := c.rsa.EncryptData(login+","+authKey, []byte("login"))
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
+		log.Error().Err(err).Msg("RegisterUser EncryptData error")
 		return err
 	}
+	var request = pb.LoginUserRequest{SessionID: c.rsa.GetSessionID(), LoginUser: message}
+	if code != "" {
+		request.TotpCode, err = c.rsa.EncryptData(code, []byte("totpCode"))
+		if err != nil {
+			return err
+		}
+	}
 	responce, err := c.cc.LoginUser(context.Background(), &request)
+	if status.Code(err) == codes.FailedPrecondition {
+		return gkerrors.ErrTOTPRequired
+	}
 	if err != nil {
 		return err
 	}
@@ -132,19 +167,116 @@ func (c *GophKeeperClient) UserLogin(login, pass string) error {
 	if err != nil {
 		return err
 	}
-	c.rsa.WriteUserID(userID, "")
+	var symKey []byte
+	if legacy {
+		var key string
+		key, err = c.rsa.DecryptData(responce.SymKey, []byte("key"))
+		symKey = []byte(key)
+	} else {
+		symKey, err = master.Unwrap(responce.GetMasterKey().GetWrappedKey())
+	}
+	if err != nil {
+		return err
+	}
+	err = c.rsa.WriteSymmetricalKey(symKey)
+	if err != nil {
+		return err
+	}
+	c.rsa.WriteUserID(userID)
+	c.pulled = false
+	if !legacy {
+		c.rsa.WriteKeyParams(params)
+		c.rsa.WriteWrappedKey(responce.GetMasterKey().GetWrappedKey())
+		c.rsa.KeepMasterKey(master)
+	}
+	if c.rsa.LegacySymmetricalKey() {
+		err = c.rotateLegacyKey(authKey, pass)
+		if err != nil {
+			log.Error().Err(err).Msg("UserLogin rotateLegacyKey error")
+		}
+	}
+	c.loadCache(login)
 	return nil
 }
 
-// CheckTimeStamp метод запрашивает и сравнивает время последнего сохранения данных пользователя.
-func (c *GophKeeperClient) CheckTimeStamp() error {
-	var request = pb.TimeStampRequest{SessionID: c.rsa.GetSessionID()}
-	var err error
-	request.UserSign, err = c.rsa.UserSign()
+// keyParams метод запрашивает на сервере параметры вычисления мастер-ключа пользователя.
+// Второе значение true, если ключ данных пользователя создан сервером и пароль передается без преобразования.
+func (c *GophKeeperClient) keyParams(login string) (crypto.KeyParams, bool, error) {
+	message, err := c.rsa.EncryptData(login, []byte("kdfLogin"))
+	if err != nil {
+		log.Error().Err(err).Msg("keyParams EncryptData error")
+		return crypto.KeyParams{}, false, err
+	}
+	var request = pb.KeyParamsRequest{SessionID: c.rsa.GetSessionID(), Login: message}
+	responce, err := c.cc.KeyParams(context.Background(), &request)
+	if err != nil {
+		return crypto.KeyParams{}, false, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return crypto.KeyParams{}, false, gkerrors.ErrSignIncorrect
+	}
+	if responce.Legacy {
+		return crypto.KeyParams{}, true, nil
+	}
+	key := responce.GetMasterKey()
+	if key == nil || len(key.Salt) == 0 || key.Time == 0 || key.Memory == 0 || key.Threads == 0 || key.Threads > 255 {
+		return crypto.KeyParams{}, false, gkerrors.ErrKeyParamsIncorrect
+	}
+	return crypto.KeyParams{Salt: key.Salt, Time: key.Time, Memory: key.Memory, Threads: uint8(key.Threads)}, false, nil
+}
+
+// rotateLegacyKey метод заменяет устаревший ключ данных новым случайным ключом и сохраняет оба ключа на сервере,
+// зашифровав их мастер-ключом, вычисленным из того же пароля с новой солью.
+// Данные, зашифрованные устаревшим ключом, зашифровываются новым ключом при следующем сохранении или синхронизации.
+func (c *GophKeeperClient) rotateLegacyKey(authKey, pass string) error {
+	keyBZ, err := c.rsa.RotatedSymmetricalKey()
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
 		return err
 	}
+	status, err := c.changePassword(authKey, pass, keyBZ)
+	if err != nil {
+		return err
+	}
+	if !status {
+		return gkerrors.ErrWrongPassword
+	}
+	return nil
+}
+
+// retireLegacyKey метод удаляет устаревший ключ данных, когда все данные пользователя зашифрованы текущим ключом:
+// после сохранения всех данных или после синхронизации, если все записи сервера получены и записи в устаревшем формате отправлены повторно.
+// На сервере сохраняется только текущий ключ, зашифрованный тем же мастер-ключом, и данные, зашифрованные
+// устаревшим ключом, больше не принимаются. Ошибка удаления не прерывает работу, удаление повторяется при следующем обмене.
+func (c *GophKeeperClient) retireLegacyKey(saved bool) {
+	if !c.rsa.HasLegacyKey() || !saved && (!c.pulled || c.Strg.ChangedCount() > 0) {
+		return
+	}
+	master, keyBZ, ok := c.rsa.CurrentKeyWithoutLegacy()
+	if !ok {
+		return
+	}
+	params, ok := c.rsa.GetKeyParams()
+	if !ok {
+		return
+	}
+	status, err := c.saveMasterKey(master.AuthKey, params, master, keyBZ)
+	if err != nil {
+		log.Error().Err(err).Msg("retireLegacyKey saveMasterKey error")
+		return
+	}
+	if !status {
+		log.Error().Msg("retireLegacyKey key is not saved")
+	}
+}
+
+// masterKey функция формирует параметры мастер-ключа и зашифрованный им ключ данных для отправки на сервер.
+func masterKey(params crypto.KeyParams, wrapped []byte) *pb.MasterKey {
+	return &pb.MasterKey{Salt: params.Salt, Time: params.Time, Memory: params.Memory, Threads: uint32(params.Threads), WrappedKey: wrapped}
+}
+
+// CheckTimeStamp метод запрашивает и сравнивает версию и время последнего сохранения данных пользователя.
+func (c *GophKeeperClient) CheckTimeStamp() error {
+	var request = pb.TimeStampRequest{SessionID: c.rsa.GetSessionID()}
 	responce, err := c.cc.TimeStamp(context.Background(), &request)
 	if err != nil {
 		return err
@@ -152,29 +284,33 @@ func (c *GophKeeperClient) CheckTimeStamp() error {
 	if c.rsa.CheckSign(responce.Sign) != nil {
 		return gkerrors.ErrSignIncorrect
 	}
-	if responce.TimeStamp != c.Strg.TimeStamp.Format(time.RFC3339) {
-		fmt.Printf("Время последнего сохранения на сервере и клиенте не совпадают. На сервере = %s, на клиенте = %s\n", responce.TimeStamp, c.Strg.TimeStamp.Format(time.RFC3339))
+	if responce.Version != c.Strg.Version {
+		fmt.Fprintf(c.Out, "Версии данных на сервере и клиенте не совпадают. На сервере = %d (сохранено %s), на клиенте = %d (сохранено %s)\n", responce.Version, responce.TimeStamp, c.Strg.Version, c.Strg.TimeStamp.Format(time.RFC3339))
 	} else {
-		fmt.Println("Время последнего сохранения на сервере и клиенте совпадают")
+		fmt.Fprintln(c.Out, "Версии данных на сервере и клиенте совпадают")
 	}
 	if responce.Locked {
-		fmt.Printf("Данные на сервере заблокированы на изменение другим пользователем до: %s\n", responce.TimeLocked)
+		fmt.Fprintf(c.Out, "Данные на сервере заблокированы на изменение до: %s. %s\n", responce.TimeLocked, lockOwnerString(responce.Owner))
 	}
 	return nil
 }
 
 // LockUserData метод запрашивает на сервере временную блокировку на изменение данных клиента другими пользователями.
 func (c *GophKeeperClient) LockUserData() error {
-	if c.Strg.Locked && c.Strg.TimeLocked.After(time.Now()) {
+	if locked, timeLocked := c.LockState(); locked && timeLocked.After(time.Now()) {
 		return nil
 	}
-	var request = pb.DataLockRequest{SessionID: c.rsa.GetSessionID()}
-	var err error
-	request.UserSign, err = c.rsa.UserSign()
-	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
-		return err
-	}
+	return c.dataLock(false)
+}
+
+// TakeOverLock метод перехватывает блокировку данных, установленную другой сессией пользователя, например на забытом устройстве.
+func (c *GophKeeperClient) TakeOverLock() error {
+	return c.dataLock(true)
+}
+
+// dataLock метод отправляет на сервер запрос на блокировку данных текущей сессией.
+func (c *GophKeeperClient) dataLock(takeOver bool) error {
+	var request = pb.DataLockRequest{SessionID: c.rsa.GetSessionID(), TakeOver: takeOver}
 	responce, err := c.cc.DataLock(context.Background(), &request)
 	if err != nil {
 		return err
@@ -183,27 +319,22 @@ func (c *GophKeeperClient) LockUserData() error {
 		return gkerrors.ErrSignIncorrect
 	}
 	if !responce.Locked {
-		fmt.Printf("Данные на сервере заблокированы на изменение другим пользователем до: %s\n", responce.TimeLocked)
+		fmt.Fprintf(c.Out, "Данные на сервере заблокированы на изменение другой сессией до: %s. %s\n", responce.TimeLocked, lockOwnerString(responce.Owner))
 		return gkerrors.ErrLocked
 	}
-	c.Strg.TimeLocked, err = time.Parse(time.RFC3339, responce.TimeLocked)
+	timeLocked, err := time.Parse(time.RFC3339, responce.TimeLocked)
 	if err != nil {
 		return err
 	}
-	c.Strg.Locked = true
-	fmt.Printf("Данные на сервере успешно заблокированы на изменение до: %s\n", responce.TimeLocked)
+	c.setLock(true, timeLocked)
+	fmt.Fprintf(c.Out, "Данные на сервере успешно заблокированы на изменение до: %s\n", responce.TimeLocked)
 	return nil
 }
 
 // Download метод запрашивает на сервере сохраненные данные пользователя.
 func (c *GophKeeperClient) Download() error {
+	attachments := c.Strg.Attachments()
 	var request = pb.UserDataRequest{SessionID: c.rsa.GetSessionID()}
-	var err error
-	request.UserSign, err = c.rsa.UserSign()
-	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
-		return err
-	}
 	responce, err := c.cc.UserData(context.Background(), &request)
 	if err != nil {
 		return err
@@ -211,53 +342,105 @@ func (c *GophKeeperClient) Download() error {
 	if c.rsa.CheckSign(responce.Sign) != nil {
 		return gkerrors.ErrSignIncorrect
 	}
-	symKey, err := c.rsa.DecryptData(responce.SymKey, []byte("key"))
-	if err != nil {
-		return err
-	}
-	c.rsa.WriteUserID("", symKey)
 	if len(responce.UserData) == 0 {
 		c.Strg.TimeStamp, err = time.Parse(time.RFC3339, responce.TimeStamp)
 		if err != nil {
 			return err
 		}
-		fmt.Println("На сервере нет сохраненных данных клиента")
+		c.Strg.Version = responce.Version
+		c.updateCache()
+		c.releaseAttachments(attachments)
+		fmt.Fprintln(c.Out, "На сервере нет сохраненных данных клиента")
 		return nil
 	}
-	jsonBZ, err := c.rsa.DecryptUserData(responce.UserData)
+	err = c.importUserData(c.Strg, responce)
 	if err != nil {
-		log.Error().Err(err).Msg("Download DecryptUserData err")
 		return err
 	}
+	c.pulled = true
+	c.Strg.Version = responce.Version
+	c.updateCache()
+	c.releaseAttachments(attachments)
+	fmt.Fprintln(c.Out, "Данные успешно скачаны с сервера")
+	if responce.Locked {
+		fmt.Fprintf(c.Out, "Данные на сервере заблокированы на изменение другим пользователем до: %s\n", responce.TimeLocked)
+	}
+	return nil
+}
 
-	err = c.Strg.ImportUserData(jsonBZ, responce.TimeStamp)
+// MergeServerData метод запрашивает на сервере актуальные данные пользователя и выполняет их трехстороннее слияние с локальными данными.
+// Конфликты, требующие решения пользователя, возвращаются в результате слияния.
+func (c *GophKeeperClient) MergeServerData() (*storage.MergeResult, error) {
+	var request = pb.UserDataRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.UserData(context.Background(), &request)
 	if err != nil {
+		return nil, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return nil, gkerrors.ErrSignIncorrect
+	}
+	server := storage.NewUserStorage()
+	server.TimeStamp, err = time.Parse(time.RFC3339, responce.TimeStamp)
+	if err != nil {
+		return nil, err
+	}
+	server.Version = responce.Version
+	if len(responce.UserData) != 0 {
+		err = c.importUserData(server, responce)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Strg.Merge(server)
+}
+
+// importUserData метод расшифровывает полученные с сервера данные пользователя и сохраняет их в хранилище.
+// Записи, зашифрованные в устаревшем формате, помечаются измененными и зашифровываются повторно при следующем сохранении или синхронизации.
+func (c *GophKeeperClient) importUserData(strg *storage.UserStorage, responce *pb.UserDataResponce) error {
+	recs, packed, err := records.Unpack(responce.UserData)
+	if err != nil {
+		log.Error().Err(err).Msg("importUserData Unpack err")
 		return err
 	}
-	fmt.Println("Данные успешно скачаны с сервера")
-	if responce.Locked {
-		fmt.Printf("Данные на сервере заблокированы на изменение другим пользователем до: %s\n", responce.TimeLocked)
+	if packed {
+		legacy, err := c.decryptRecords(recs)
+		if err != nil {
+			log.Error().Err(err).Msg("importUserData decryptRecords err")
+			return err
+		}
+		err = strg.ImportRecords(recs, responce.TimeStamp)
+		if err != nil {
+			return err
+		}
+		strg.MarkChanged(legacy...)
+		return nil
 	}
-	return nil
+	jsonBZ, err := c.rsa.DecryptUserData(responce.UserData)
+	if err != nil {
+		log.Error().Err(err).Msg("importUserData DecryptUserData err")
+		return err
+	}
+	return strg.ImportUserData(jsonBZ, responce.TimeStamp)
 }
 
 // SaveData метод отправляет на сервер данные пользователя для сохранения.
+// Каждая запись пользователя зашифровывается отдельно, записи упаковываются в единый массив данных.
 func (c *GophKeeperClient) SaveData() error {
-	jsonBZ, err := c.Strg.ExportUserData()
+	attachments := c.Strg.Attachments()
+	recs, err := c.Strg.ExportRecords()
 	if err != nil {
 		return err
 	}
-	messageBZ, err := c.rsa.EncryptUserData(jsonBZ)
+	err = c.encryptRecords(recs)
 	if err != nil {
-		log.Error().Err(err).Msg("SaveData EncryptUserData err")
+		log.Error().Err(err).Msg("SaveData encryptRecords err")
 		return err
 	}
-	var request = pb.UpdateDataRequest{SessionID: c.rsa.GetSessionID(), TimeStamp: c.Strg.TimeStamp.Format(time.RFC3339), UserData: messageBZ}
-	request.UserSign, err = c.rsa.UserSign()
+	messageBZ, err := records.Pack(recs)
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
 		return err
 	}
+	var request = pb.UpdateDataRequest{SessionID: c.rsa.GetSessionID(), TimeStamp: c.Strg.TimeStamp.Format(time.RFC3339), Version: c.Strg.Version, UserData: messageBZ}
 	log.Debug().Msgf("Данные для сохранения на сервер отправлены")
 	responce, err := c.cc.UpdateData(context.Background(), &request)
 	if err != nil {
@@ -270,57 +453,137 @@ func (c *GophKeeperClient) SaveData() error {
 	if err != nil {
 		return err
 	}
-	c.Strg.Locked = false
+	c.Strg.Version = responce.Version
+	c.setLock(false, time.Time{})
+	c.Strg.ResetSync()
+	c.Strg.EmptyUsersTrash()
+	err = c.Strg.SaveBase()
+	if err != nil {
+		return err
+	}
+	c.updateCache()
+	c.releaseAttachments(attachments)
+	c.retireLegacyKey(true)
 	return nil
 }
 
 // UserLogOut метод очищает данные пользовательской сессии и отправляет на сервер запрос на удаление сессии.
+// Несинхронизированные изменения перед очисткой сохраняются в локальном кэше.
 func (c *GophKeeperClient) UserLogOut() {
+	c.updateCache()
+	c.login = ""
+	if locked, _ := c.LockState(); locked {
+		err := c.ReleaseLock()
+		if err != nil {
+			log.Error().Err(err).Msg("UserLogOut ReleaseLock error")
+		}
+	}
 	var request = pb.LogOutRequest{SessionID: c.rsa.GetSessionID()}
-	var err error
-	var noSend bool
-	request.UserSign, err = c.rsa.UserSign()
+	responce, err := c.cc.LogOut(context.Background(), &request)
+	// Ключи сессии сбрасываются после отправки запроса, так как запрос подписывается ключом текущей сессии
+	c.clearUser()
 	if err != nil {
-		log.Error().Err(err).Msg("UserLogOut EncryptOAEP signing error")
-		noSend = true
+		log.Error().Err(err).Msg("UserLogOut LogOut error")
+		return
+	}
+	if !responce.Status {
+		fmt.Fprintln(c.Out, "Не удалось удалить сессию на сервере")
 	}
-	err = c.rsa.RefreshToken()
+}
+
+// DeleteAccount метод отправляет на сервер запрос на удаление учетной записи пользователя со всеми его данными.
+// После удаления сессия клиента завершается, локальные данные пользователя очищаются.
+func (c *GophKeeperClient) DeleteAccount(password string) error {
+	authKey := password
+	params, ok := c.rsa.GetKeyParams()
+	if ok {
+		authKey = crypto.DeriveMasterKey(password, params).AuthKey
+	}
+	message, err := c.rsa.EncryptData(authKey, []byte("deletePass"))
 	if err != nil {
-		log.Error().Err(err).Msg("UserLogOut RefreshToken error")
+		log.Error().Err(err).Msg("DeleteAccount EncryptData error")
+		return err
 	}
-	c.Strg = nil
-	c.Strg = storage.NewUserStorage()
-	if noSend {
-		return
+	var request = pb.DeleteAccountRequest{SessionID: c.rsa.GetSessionID(), Password: message}
+	responce, err := c.cc.DeleteAccount(context.Background(), &request)
+	if err != nil {
+		return err
 	}
-	responce, err := c.cc.LogOut(context.Background(), &request)
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	if responce.AttachmentsKept {
+		fmt.Fprintln(c.Out, "Учетная запись удалена, но файлы вложений не удалось удалить с сервера")
+	}
+	c.removeCache()
+	c.clearUser()
+	return nil
+}
+
+// clearUser метод завершает сессию на стороне клиента: затирает ключи сессии и ключи данных пользователя
+// и удаляет из памяти расшифрованные данные пользователя.
+func (c *GophKeeperClient) clearUser() {
+	err := c.rsa.RefreshToken()
 	if err != nil {
-		log.Error().Err(err).Msg("UserLogOut LogOut error")
-		return
+		log.Error().Err(err).Msg("clearUser RefreshToken error")
 	}
-	if !responce.Status {
-		fmt.Println("Не удалось удалить сессию на сервере")
+	c.login = ""
+	c.pulled = false
+	c.setLock(false, time.Time{})
+	c.Strg = storage.NewUserStorage()
+	for id := range c.attachments {
+		delete(c.attachments, id)
 	}
 }
 
 // ChangePassword метод зашифровывает  отправляет на сервер запрос на изменение пароля пользователя.
+// Ключ данных не меняется, на сервер передается ключ данных, зашифрованный новым мастер-ключом.
 func (c *GophKeeperClient) ChangePassword(oldPassword, newPassword string) (bool, error) {
-	old, err := c.rsa.EncryptData(oldPassword, []byte("oldPass"))
+	oldAuthKey := oldPassword
+	params, ok := c.rsa.GetKeyParams()
+	if ok {
+		oldAuthKey = crypto.DeriveMasterKey(oldPassword, params).AuthKey
+	}
+	return c.changePassword(oldAuthKey, newPassword, nil)
+}
+
+// changePassword метод вычисляет новый мастер-ключ, зашифровывает им ключи данных и отправляет на сервер запрос на изменение пароля.
+// Если передан новый набор ключей данных, он зашифровывается вместо текущего и сохраняется в сессии после успешного ответа сервера.
+func (c *GophKeeperClient) changePassword(oldAuthKey, newPassword string, keyBZ []byte) (bool, error) {
+	params, err := crypto.NewKeyParams()
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptData error")
+		log.Error().Err(err).Msg("ChangePassword NewKeyParams error")
+		return false, err
+	}
+	return c.saveMasterKey(oldAuthKey, params, crypto.DeriveMasterKey(newPassword, params), keyBZ)
+}
+
+// saveMasterKey метод зашифровывает мастер-ключом ключи данных и отправляет на сервер запрос на изменение пароля
+// с новыми параметрами мастер-ключа. Если передан новый набор ключей данных, он зашифровывается вместо текущего
+// и сохраняется в сессии после успешного ответа сервера.
+func (c *GophKeeperClient) saveMasterKey(oldAuthKey string, params crypto.KeyParams, master crypto.MasterKey, keyBZ []byte) (bool, error) {
+	var err error
+	var wrapped []byte
+	if keyBZ != nil {
+		wrapped, err = master.Wrap(keyBZ)
+	} else {
+		wrapped, err = c.rsa.WrapSymmetricalKey(master)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("ChangePassword WrapSymmetricalKey error")
 		return false, err
 	}
-	new, err := c.rsa.EncryptData(newPassword, []byte("newPass"))
+	old, err := c.rsa.EncryptData(oldAuthKey, []byte("oldPass"))
 	if err != nil {
 		log.Error().Err(err).Msg("RegisterUser EncryptData error")
 		return false, err
 	}
-	var request = pb.ChangePasswordRequest{SessionID: c.rsa.GetSessionID(), OldPassword: old, NewPassword: new}
-	request.UserSign, err = c.rsa.UserSign()
+	new, err := c.rsa.EncryptData(master.AuthKey, []byte("newPass"))
 	if err != nil {
-		log.Error().Err(err).Msg("RegisterUser EncryptOAEP signing error")
+		log.Error().Err(err).Msg("RegisterUser EncryptData error")
 		return false, err
 	}
+	var request = pb.ChangePasswordRequest{SessionID: c.rsa.GetSessionID(), OldPassword: old, NewPassword: new, MasterKey: masterKey(params, wrapped)}
 	responce, err := c.cc.ChangePassword(context.Background(), &request)
 	if err != nil {
 		return false, err
@@ -328,5 +591,17 @@ func (c *GophKeeperClient) ChangePassword(oldPassword, newPassword string) (bool
 	if c.rsa.CheckSign(responce.Sign) != nil {
 		return false, gkerrors.ErrSignIncorrect
 	}
+	if responce.Status {
+		c.rsa.WriteKeyParams(params)
+		c.rsa.WriteWrappedKey(wrapped)
+		if keyBZ != nil {
+			err = c.rsa.WriteSymmetricalKey(keyBZ)
+			if err != nil {
+				return false, err
+			}
+		}
+		c.rsa.KeepMasterKey(master)
+		c.updateCache()
+	}
 	return responce.Status, nil
 }
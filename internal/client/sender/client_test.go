@@ -2,6 +2,7 @@ package sender
 
 import (
 	"testing"
+	"time"
 
 	"gophkeeper/internal/client/config"
 	"gophkeeper/internal/client/crypto"
@@ -62,6 +63,10 @@ func TestClient(t *testing.T) {
 	// Блокировка данных
 	checkDataLock(t, &client)
 
+	// Продление и снятие блокировки данных
+	checkRenewLock(t, &client)
+	checkReleaseLock(t, &client)
+
 	// Сохранение данных
 	checkSaveData(t, &client)
 
@@ -321,6 +326,107 @@ func checkDataLock(t *testing.T, client *GophKeeperClient) {
 	}
 }
 
+func checkRenewLock(t *testing.T, client *GophKeeperClient) {
+	tests := []struct {
+		name      string
+		sessionID string
+		isError   bool
+		errCode   codes.Code
+		err       error
+	}{
+		{
+			name:      "Продление блокировки. Ошибка",
+			sessionID: "9876543210",
+			isError:   true,
+			errCode:   codes.Unauthenticated,
+		},
+		{
+			name:      "Продление блокировки. Нет подписи сервера",
+			sessionID: "147852369",
+			isError:   true,
+			err:       gkerrors.ErrSignIncorrect,
+		},
+		{
+			name:      "Продление блокировки. Блокировка снята",
+			sessionID: "123654789",
+			isError:   true,
+			err:       gkerrors.ErrNotLocked,
+		},
+		{
+			name:      "Продление блокировки. Успешно",
+			sessionID: "123654890",
+			isError:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.rsa.WriteSessionID(tt.sessionID, nil)
+			err := client.RenewLock()
+			if tt.isError {
+				st, ok := status.FromError(err)
+				if ok {
+					require.Equal(t, tt.errCode, st.Code())
+				} else {
+					require.Equal(t, tt.err, err)
+				}
+			} else {
+				require.NoError(t, err)
+				locked, timeLocked := client.LockState()
+				require.True(t, locked)
+				require.True(t, timeLocked.After(time.Now().Add(time.Minute)))
+			}
+		})
+	}
+}
+
+func checkReleaseLock(t *testing.T, client *GophKeeperClient) {
+	tests := []struct {
+		name      string
+		sessionID string
+		isError   bool
+		errCode   codes.Code
+		err       error
+	}{
+		{
+			name:      "Снятие блокировки. Ошибка",
+			sessionID: "9876543210",
+			isError:   true,
+			errCode:   codes.Unauthenticated,
+		},
+		{
+			name:      "Снятие блокировки. Нет подписи сервера",
+			sessionID: "147852369",
+			isError:   true,
+			err:       gkerrors.ErrSignIncorrect,
+		},
+		{
+			name:      "Снятие блокировки. Успешно",
+			sessionID: "123654890",
+			isError:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.rsa.WriteSessionID(tt.sessionID, nil)
+			err := client.ReleaseLock()
+			if tt.isError {
+				st, ok := status.FromError(err)
+				if ok {
+					require.Equal(t, tt.errCode, st.Code())
+				} else {
+					require.Equal(t, tt.err, err)
+				}
+			} else {
+				require.NoError(t, err)
+				locked, _ := client.LockState()
+				require.False(t, locked)
+			}
+		})
+	}
+}
+
 func checkSaveData(t *testing.T, client *GophKeeperClient) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,57 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// HistoryVersion структура описывает сохраненную на сервере предыдущую версию данных пользователя.
+type HistoryVersion struct {
+	Version   int64  //Версия данных
+	TimeStamp string //Отметка времени сохранения версии данных
+}
+
+// ListHistory метод запрашивает на сервере список сохраненных предыдущих версий данных пользователя.
+func (c *GophKeeperClient) ListHistory() ([]HistoryVersion, error) {
+	var request = pb.ListHistoryRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.ListHistory(context.Background(), &request)
+	if err != nil {
+		return nil, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return nil, gkerrors.ErrSignIncorrect
+	}
+	history := make([]HistoryVersion, 0, len(responce.Versions))
+	for _, version := range responce.Versions {
+		history = append(history, HistoryVersion{Version: version.Version, TimeStamp: version.TimeStamp})
+	}
+	return history, nil
+}
+
+// RestoreVersion метод запрашивает на сервере восстановление предыдущей версии данных пользователя
+// и скачивает восстановленные данные.
+func (c *GophKeeperClient) RestoreVersion(version int64) error {
+	var request = pb.RestoreVersionRequest{SessionID: c.rsa.GetSessionID(), Version: version, CurrentVersion: c.Strg.Version}
+	responce, err := c.cc.RestoreVersion(context.Background(), &request)
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	c.Strg.Version = responce.Version
+	c.Strg.TimeStamp, err = time.Parse(time.RFC3339, responce.TimeStamp)
+	if err != nil {
+		return err
+	}
+	c.setLock(false, time.Time{})
+	// Вложения, снова сохраняемые сервером после восстановления, освобождаются после загрузки данных,
+	// если на них не ссылается ни одна восстановленная запись
+	for _, id := range responce.Attachments {
+		c.attachments[id] = true
+	}
+	return c.Download()
+}
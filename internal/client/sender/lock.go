@@ -0,0 +1,128 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// minRenewInterval минимальный интервал между запросами на продление блокировки данных.
+const minRenewInterval = 10 * time.Second
+
+// lockState структура состояния блокировки данных на сервере текущей сессией.
+// Состояние изменяет фоновое продление блокировки, поэтому доступ к нему защищен мьютексом.
+type lockState struct {
+	mu         sync.Mutex
+	locked     bool
+	timeLocked time.Time
+}
+
+// LockState метод возвращает признак блокировки данных на сервере текущей сессией и время окончания блокировки.
+func (c *GophKeeperClient) LockState() (bool, time.Time) {
+	c.lock.mu.Lock()
+	defer c.lock.mu.Unlock()
+	return c.lock.locked, c.lock.timeLocked
+}
+
+// setLock метод сохраняет состояние блокировки данных на сервере текущей сессией.
+func (c *GophKeeperClient) setLock(locked bool, timeLocked time.Time) {
+	c.lock.mu.Lock()
+	defer c.lock.mu.Unlock()
+	c.lock.locked, c.lock.timeLocked = locked, timeLocked
+}
+
+// ReleaseLock метод снимает на сервере блокировку на изменение данных, установленную текущей сессией.
+func (c *GophKeeperClient) ReleaseLock() error {
+	var request = pb.ReleaseLockRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.ReleaseLock(context.Background(), &request)
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	c.setLock(false, time.Time{})
+	return nil
+}
+
+// RenewLock метод продлевает на сервере блокировку на изменение данных, установленную текущей сессией.
+func (c *GophKeeperClient) RenewLock() error {
+	var request = pb.RenewLockRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.RenewLock(context.Background(), &request)
+	if status.Code(err) == codes.FailedPrecondition {
+		c.setLock(false, time.Time{})
+		return gkerrors.ErrNotLocked
+	}
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	timeLocked, err := time.Parse(time.RFC3339, responce.TimeLocked)
+	if err != nil {
+		return err
+	}
+	c.setLock(responce.Locked, timeLocked)
+	return nil
+}
+
+// StartLockRenewal метод запускает фоновое продление блокировки данных, пока пользователь редактирует данные.
+// Блокировка продлевается, когда до ее окончания остается половина срока.
+// Возвращаемая функция останавливает продление и дожидается завершения фоновой горутины.
+func (c *GophKeeperClient) StartLockRenewal() func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, timeLocked := c.LockState()
+			interval := time.Until(timeLocked) / 2
+			if interval < minRenewInterval {
+				interval = minRenewInterval
+			}
+			timer := time.NewTimer(interval)
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			err := c.RenewLock()
+			if err == gkerrors.ErrNotLocked {
+				fmt.Fprintln(c.Out, "Блокировка данных на сервере снята, сохранение изменений может завершиться конфликтом")
+				return
+			}
+			if err != nil {
+				log.Error().Err(err).Msg("RenewLock error")
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// lockOwnerString функция формирует для пользователя описание сессии, установившей блокировку данных.
+func lockOwnerString(owner *pb.LockOwner) string {
+	if owner == nil {
+		return "Сведения о сессии, установившей блокировку, отсутствуют"
+	}
+	if owner.Current {
+		return fmt.Sprintf("Блокировка установлена текущей сессией %s", owner.TimeAcquired)
+	}
+	device := owner.DeviceName
+	if device == "" {
+		device = "неизвестное устройство"
+	}
+	return fmt.Sprintf("Блокировка установлена %s с устройства %s, версия клиента %s", owner.TimeAcquired, device, owner.ClientVersion)
+}
@@ -0,0 +1,240 @@
+package sender
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	pb "gophkeeper/api/grpc/proto"
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// CreateRecord метод зашифровывает и отправляет на сервер новую запись пользователя.
+func (c *GophKeeperClient) CreateRecord(rec records.Record) (records.Record, error) {
+	var err error
+	rec.Data, err = c.rsa.EncryptUserData(rec.Data)
+	if err != nil {
+		log.Error().Err(err).Msg("CreateRecord EncryptUserData err")
+		return records.Record{}, err
+	}
+	var request = pb.CreateRecordRequest{SessionID: c.rsa.GetSessionID(), Record: recordToPB(rec)}
+	responce, err := c.cc.CreateRecord(context.Background(), &request)
+	if err != nil {
+		return records.Record{}, err
+	}
+	return c.recordFromResponce(responce)
+}
+
+// UpdateRecord метод зашифровывает и отправляет на сервер измененную запись пользователя.
+// Версия записи должна совпадать с версией, на основе которой запись изменена.
+func (c *GophKeeperClient) UpdateRecord(rec records.Record) (records.Record, error) {
+	var err error
+	rec.Data, err = c.rsa.EncryptUserData(rec.Data)
+	if err != nil {
+		log.Error().Err(err).Msg("UpdateRecord EncryptUserData err")
+		return records.Record{}, err
+	}
+	var request = pb.UpdateRecordRequest{SessionID: c.rsa.GetSessionID(), Record: recordToPB(rec)}
+	responce, err := c.cc.UpdateRecord(context.Background(), &request)
+	if err != nil {
+		return records.Record{}, err
+	}
+	return c.recordFromResponce(responce)
+}
+
+// DeleteRecord метод отправляет на сервер запрос на удаление записи пользователя.
+func (c *GophKeeperClient) DeleteRecord(recordID string, version int64) error {
+	var request = pb.DeleteRecordRequest{SessionID: c.rsa.GetSessionID(), RecordID: recordID, Version: version}
+	responce, err := c.cc.DeleteRecord(context.Background(), &request)
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	return nil
+}
+
+// ListRecords метод запрашивает на сервере список записей пользователя без данных.
+func (c *GophKeeperClient) ListRecords() ([]records.Record, error) {
+	var request = pb.ListRecordsRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.ListRecords(context.Background(), &request)
+	if err != nil {
+		return nil, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return nil, gkerrors.ErrSignIncorrect
+	}
+	recs := make([]records.Record, 0, len(responce.Records))
+	for _, rec := range responce.Records {
+		recs = append(recs, recordFromPB(rec))
+	}
+	return recs, nil
+}
+
+// GetRecord метод запрашивает на сервере запись пользователя и расшифровывает ее.
+func (c *GophKeeperClient) GetRecord(recordID string) (records.Record, error) {
+	var request = pb.GetRecordRequest{SessionID: c.rsa.GetSessionID(), RecordID: recordID}
+	responce, err := c.cc.GetRecord(context.Background(), &request)
+	if err != nil {
+		return records.Record{}, err
+	}
+	return c.recordFromResponce(responce)
+}
+
+// Sync метод отправляет на сервер записи, измененные после последней синхронизации, и применяет записи,
+// измененные на сервере после известной клиенту ревизии. Возвращает количество обнаруженных конфликтов.
+func (c *GophKeeperClient) Sync() (int, error) {
+	attachments := c.Strg.Attachments()
+	changes, err := c.Strg.ChangedRecords()
+	if err != nil {
+		return 0, err
+	}
+	err = c.encryptRecords(changes)
+	if err != nil {
+		log.Error().Err(err).Msg("Sync encryptRecords err")
+		return 0, err
+	}
+	var request = pb.SyncRequest{SessionID: c.rsa.GetSessionID(), Revision: c.Strg.Revision, Records: make([]*pb.Record, 0, len(changes))}
+	for _, rec := range changes {
+		request.Records = append(request.Records, recordToPB(rec))
+	}
+	responce, err := c.cc.Sync(context.Background(), &request)
+	if err != nil {
+		return 0, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return 0, gkerrors.ErrSignIncorrect
+	}
+	// Записи неизвестного типа проверяются до применения ответа, чтобы хранилище не осталось измененным частично
+	for _, in := range append(append([]*pb.Record{}, responce.Conflicts...), responce.Records...) {
+		err = storage.CheckTypes(recordFromPB(in))
+		if err != nil {
+			return 0, err
+		}
+	}
+	// Конфликты разрешаются до применения измененных записей, иначе серверная копия заменит локальные изменения
+	conflicted := make(map[string]bool, len(responce.Conflicts))
+	conflicts := 0
+	for _, in := range responce.Conflicts {
+		rec, legacy, err := c.decryptRecord(in)
+		if err != nil {
+			return 0, err
+		}
+		kept, err := c.Strg.ResolveConflict(rec)
+		if err != nil {
+			return 0, err
+		}
+		if kept {
+			conflicts++
+		}
+		if legacy {
+			c.Strg.MarkChanged(rec.ID)
+		}
+		conflicted[rec.ID] = true
+	}
+	for _, in := range responce.Records {
+		if conflicted[in.RecordID] {
+			continue
+		}
+		rec, legacy, err := c.decryptRecord(in)
+		if err != nil {
+			return 0, err
+		}
+		err = c.Strg.ApplyRecord(rec)
+		if err != nil {
+			return 0, err
+		}
+		if legacy {
+			c.Strg.MarkChanged(rec.ID)
+		}
+	}
+	if request.Revision == 0 {
+		c.pulled = true
+	}
+	c.Strg.Revision = responce.Revision
+	c.updateCache()
+	c.releaseAttachments(attachments)
+	c.retireLegacyKey(false)
+	return conflicts, nil
+}
+
+// decryptRecord метод преобразует запись из формата gRPC и расшифровывает ее данные.
+// Второе значение true, если данные записи зашифрованы в устаревшем формате.
+func (c *GophKeeperClient) decryptRecord(in *pb.Record) (records.Record, bool, error) {
+	rec := recordFromPB(in)
+	if rec.Deleted || len(rec.Data) == 0 {
+		return rec, false, nil
+	}
+	var err error
+	var legacy bool
+	rec.Data, legacy, err = c.rsa.DecryptUserDataVersion(rec.Data)
+	if err != nil {
+		log.Error().Err(err).Msg("decryptRecord DecryptUserData err")
+		return records.Record{}, false, err
+	}
+	return rec, legacy, nil
+}
+
+// recordFromResponce метод проверяет подпись сервера и расшифровывает полученную запись.
+func (c *GophKeeperClient) recordFromResponce(responce *pb.RecordResponce) (records.Record, error) {
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return records.Record{}, gkerrors.ErrSignIncorrect
+	}
+	if responce.Record == nil {
+		return records.Record{}, nil
+	}
+	rec := recordFromPB(responce.Record)
+	if len(rec.Data) == 0 {
+		return rec, nil
+	}
+	var err error
+	rec.Data, err = c.rsa.DecryptUserData(rec.Data)
+	if err != nil {
+		log.Error().Err(err).Msg("recordFromResponce DecryptUserData err")
+		return records.Record{}, err
+	}
+	return rec, nil
+}
+
+// encryptRecords метод зашифровывает данные каждой записи симметричным ключом пользователя.
+func (c *GophKeeperClient) encryptRecords(recs []records.Record) error {
+	var err error
+	for i := range recs {
+		recs[i].Data, err = c.rsa.EncryptUserData(recs[i].Data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptRecords метод расшифровывает данные каждой записи симметричным ключом пользователя.
+// Возвращает идентификаторы записей, данные которых зашифрованы в устаревшем формате.
+func (c *GophKeeperClient) decryptRecords(recs []records.Record) ([]string, error) {
+	var err error
+	var legacy bool
+	legacyIDs := make([]string, 0)
+	for i := range recs {
+		recs[i].Data, legacy, err = c.rsa.DecryptUserDataVersion(recs[i].Data)
+		if err != nil {
+			return nil, err
+		}
+		if legacy {
+			legacyIDs = append(legacyIDs, recs[i].ID)
+		}
+	}
+	return legacyIDs, nil
+}
+
+// recordFromPB функция преобразует запись из формата gRPC.
+func recordFromPB(in *pb.Record) records.Record {
+	return records.Record{ID: in.RecordID, Type: in.RecordType, Version: in.Version, TimeStamp: in.TimeStamp, Data: in.RecordData, Revision: in.Revision, Deleted: in.Deleted}
+}
+
+// recordToPB функция преобразует запись в формат gRPC.
+func recordToPB(rec records.Record) *pb.Record {
+	return &pb.Record{RecordID: rec.ID, RecordType: rec.Type, Version: rec.Version, TimeStamp: rec.TimeStamp, RecordData: rec.Data, Revision: rec.Revision, Deleted: rec.Deleted}
+}
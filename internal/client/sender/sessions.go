@@ -0,0 +1,57 @@
+package sender
+
+import (
+	"context"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// SessionInfo структура описывает действующую сессию пользователя на одном из устройств.
+type SessionInfo struct {
+	SessionID     string //Идентификатор сессии
+	Created       string //Время создания сессии
+	LastSeen      string //Время последнего запроса в сессии
+	DeviceName    string //Имя устройства клиента
+	ClientVersion string //Версия приложения клиента
+	RemoteAddr    string //Адрес, с которого подключен клиент
+	Current       bool   //Сессия текущего клиента
+}
+
+// ListSessions метод запрашивает на сервере список действующих сессий пользователя.
+func (c *GophKeeperClient) ListSessions() ([]SessionInfo, error) {
+	var request = pb.ListSessionsRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.ListSessions(context.Background(), &request)
+	if err != nil {
+		return nil, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return nil, gkerrors.ErrSignIncorrect
+	}
+	sessions := make([]SessionInfo, 0, len(responce.Sessions))
+	for _, session := range responce.Sessions {
+		sessions = append(sessions, SessionInfo{
+			SessionID:     session.SessionID,
+			Created:       session.Created,
+			LastSeen:      session.LastSeen,
+			DeviceName:    session.DeviceName,
+			ClientVersion: session.ClientVersion,
+			RemoteAddr:    session.RemoteAddr,
+			Current:       session.Current,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession метод отправляет на сервер запрос на завершение другой сессии пользователя.
+func (c *GophKeeperClient) RevokeSession(sessionID string) error {
+	var request = pb.RevokeSessionRequest{SessionID: c.rsa.GetSessionID(), RevokeSessionID: sessionID}
+	responce, err := c.cc.RevokeSession(context.Background(), &request)
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	return nil
+}
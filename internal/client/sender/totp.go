@@ -0,0 +1,93 @@
+package sender
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// TOTPSetup структура описывает выданный сервером секрет двухфакторной аутентификации.
+type TOTPSetup struct {
+	Secret        string   //Секрет TOTP в кодировке base32 для ручного ввода в приложение-аутентификатор
+	URI           string   //Ссылка otpauth:// для приложения-аутентификатора
+	RecoveryCodes []string //Одноразовые коды восстановления на случай утери устройства
+}
+
+// EnableTOTP метод запрашивает на сервере новый секрет двухфакторной аутентификации.
+// Двухфакторная аутентификация включается после подтверждения секрета методом ConfirmTOTP.
+func (c *GophKeeperClient) EnableTOTP() (TOTPSetup, error) {
+	var request = pb.EnableTOTPRequest{SessionID: c.rsa.GetSessionID()}
+	responce, err := c.cc.EnableTOTP(context.Background(), &request)
+	if status.Code(err) == codes.FailedPrecondition {
+		return TOTPSetup{}, gkerrors.ErrTOTPEnabled
+	}
+	if err != nil {
+		return TOTPSetup{}, err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return TOTPSetup{}, gkerrors.ErrSignIncorrect
+	}
+	var setup TOTPSetup
+	setup.Secret, err = c.rsa.DecryptData(responce.Secret, []byte("totpSecret"))
+	if err != nil {
+		return TOTPSetup{}, err
+	}
+	setup.URI, err = c.rsa.DecryptData(responce.Uri, []byte("totpURI"))
+	if err != nil {
+		return TOTPSetup{}, err
+	}
+	recoveryCodes, err := c.rsa.DecryptData(responce.RecoveryCodes, []byte("recoveryCodes"))
+	if err != nil {
+		return TOTPSetup{}, err
+	}
+	setup.RecoveryCodes = strings.Split(recoveryCodes, "\n")
+	return setup, nil
+}
+
+// ConfirmTOTP метод подтверждает выданный секрет кодом из приложения-аутентификатора и включает двухфакторную аутентификацию.
+func (c *GophKeeperClient) ConfirmTOTP(code string) error {
+	message, err := c.rsa.EncryptData(code, []byte("totpCode"))
+	if err != nil {
+		return err
+	}
+	var request = pb.EnableTOTPRequest{SessionID: c.rsa.GetSessionID(), Code: message}
+	responce, err := c.cc.EnableTOTP(context.Background(), &request)
+	if status.Code(err) == codes.InvalidArgument {
+		return gkerrors.ErrTOTPCodeIncorrect
+	}
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	if !responce.Enabled {
+		return gkerrors.ErrTOTPNotEnabled
+	}
+	return nil
+}
+
+// DisableTOTP метод отключает двухфакторную аутентификацию по коду из приложения-аутентификатора или коду восстановления.
+func (c *GophKeeperClient) DisableTOTP(code string) error {
+	message, err := c.rsa.EncryptData(code, []byte("totpCode"))
+	if err != nil {
+		return err
+	}
+	var request = pb.DisableTOTPRequest{SessionID: c.rsa.GetSessionID(), Code: message}
+	responce, err := c.cc.DisableTOTP(context.Background(), &request)
+	if status.Code(err) == codes.FailedPrecondition {
+		return gkerrors.ErrTOTPNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	if c.rsa.CheckSign(responce.Sign) != nil {
+		return gkerrors.ErrSignIncorrect
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// watchRetryInterval интервал повторного подключения к потоку уведомлений после обрыва соединения.
+const watchRetryInterval = 5 * time.Second
+
+// ChangeKind тип вида изменения данных пользователя другой сессией.
+type ChangeKind int
+
+// Виды изменений данных пользователя другой сессией.
+const (
+	ChangeSaved    ChangeKind = iota //Данные сохранены
+	ChangeLocked                     //Данные заблокированы на изменение
+	ChangeUnlocked                   //Блокировка данных снята
+)
+
+// ChangeEvent структура уведомления об изменении данных пользователя другой сессией.
+type ChangeEvent struct {
+	Kind          ChangeKind //Вид изменения
+	Version       int64      //Версия сохраненных данных, 0 - версия не изменилась
+	Revision      int64      //Ревизия данных после сохранения записей, 0 - ревизия неизвестна
+	TimeStamp     string     //Отметка времени сохранения данных
+	TimeLocked    string     //Время окончания блокировки данных
+	DeviceName    string     //Имя устройства сессии, выполнившей изменение
+	ClientVersion string     //Версия приложения сессии, выполнившей изменение
+}
+
+// String метод формирует для пользователя описание изменения.
+func (e ChangeEvent) String() string {
+	device := e.DeviceName
+	if device == "" {
+		device = "неизвестного устройства"
+	}
+	switch e.Kind {
+	case ChangeLocked:
+		return fmt.Sprintf("Данные заблокированы на изменение с устройства %s до %s", device, e.TimeLocked)
+	case ChangeUnlocked:
+		return fmt.Sprintf("Блокировка данных с устройства %s снята", device)
+	default:
+		return fmt.Sprintf("Данные изменены с устройства %s", device)
+	}
+}
+
+// StartWatch метод подписывается на уведомления об изменениях данных пользователя другими сессиями
+// и передает их функции notify из фоновой горутины. При обрыве соединения подписка возобновляется.
+// Возвращаемая функция отменяет подписку и дожидается завершения фоновой горутины.
+func (c *GophKeeperClient) StartWatch(notify func(ChangeEvent)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			err := c.watch(ctx, notify)
+			if ctx.Err() != nil {
+				return
+			}
+			if status.Code(err) == codes.Unauthenticated {
+				log.Info().Err(err).Msg("WatchChanges stopped")
+				return
+			}
+			log.Error().Err(err).Msg("WatchChanges error")
+			timer := time.NewTimer(watchRetryInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// watch метод получает уведомления из потока до его завершения или обрыва соединения.
+func (c *GophKeeperClient) watch(ctx context.Context, notify func(ChangeEvent)) error {
+	var request = pb.WatchChangesRequest{SessionID: c.rsa.GetSessionID()}
+	stream, err := c.cc.WatchChanges(ctx, &request)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if c.rsa.CheckSign(event.Sign) != nil {
+			return gkerrors.ErrSignIncorrect
+		}
+		notify(changeEvent(event))
+	}
+}
+
+// changeEvent функция преобразует уведомление сервера в уведомление клиента.
+func changeEvent(event *pb.ChangeEvent) ChangeEvent {
+	change := ChangeEvent{Version: event.Version, Revision: event.Revision, TimeStamp: event.TimeStamp, TimeLocked: event.TimeLocked}
+	switch event.Kind {
+	case pb.ChangeKind_LOCKED:
+		change.Kind = ChangeLocked
+	case pb.ChangeKind_UNLOCKED:
+		change.Kind = ChangeUnlocked
+	default:
+		change.Kind = ChangeSaved
+	}
+	if event.Owner != nil {
+		change.DeviceName, change.ClientVersion = event.Owner.DeviceName, event.Owner.ClientVersion
+	}
+	return change
+}
+
+// IsNewer метод сообщает, содержит ли уведомление о сохранении данные новее локальной копии.
+func (c *GophKeeperClient) IsNewer(event ChangeEvent) bool {
+	if event.Kind != ChangeSaved {
+		return false
+	}
+	if event.Revision != 0 && event.Revision <= c.Strg.Revision {
+		return false
+	}
+	if event.Version != 0 && event.Revision == 0 && event.Version <= c.Strg.Version {
+		return false
+	}
+	return true
+}
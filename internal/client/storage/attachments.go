@@ -0,0 +1,12 @@
+package storage
+
+// Attachments метод возвращает идентификаторы вложений, на которые ссылаются записи двоичных данных.
+func (s *UserStorage) Attachments() map[string]bool {
+	ids := make(map[string]bool)
+	for _, val := range s.Binaries {
+		if val.Attachment != "" {
+			ids[val.Attachment] = true
+		}
+	}
+	return ids
+}
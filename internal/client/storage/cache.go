@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"gophkeeper/internal/records"
+)
+
+// Snapshot структура хранит состояние хранилища для записи в локальный кэш и восстановления без соединения с сервером.
+type Snapshot struct {
+	TimeStamp time.Time
+	Version   int64
+	Revision  int64
+	Records   []records.Record //Записи с версиями на сервере, удаленные локально записи передаются с отметкой об удалении
+	Base      []records.Record //Последняя синхронизированная с сервером копия записей
+	Changed   []string         //Идентификаторы записей, измененных после последней синхронизации
+}
+
+// Snapshot метод возвращает состояние хранилища вместе с несинхронизированными изменениями.
+func (s *UserStorage) Snapshot() (Snapshot, error) {
+	recs, err := s.ExportRecords()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	found := make(map[string]bool, len(recs))
+	for i := range recs {
+		found[recs[i].ID] = true
+		recs[i].Version = s.versions[recs[i].ID]
+	}
+	for id, version := range s.versions {
+		if !found[id] {
+			recs = append(recs, records.Record{ID: id, Version: version, Deleted: true})
+		}
+	}
+	changed := make([]string, 0, len(s.changed))
+	for id := range s.changed {
+		changed = append(changed, id)
+	}
+	sort.Strings(changed)
+	base := make([]records.Record, len(s.base))
+	copy(base, s.base)
+	return Snapshot{TimeStamp: s.TimeStamp, Version: s.Version, Revision: s.Revision, Records: recs, Base: base, Changed: changed}, nil
+}
+
+// RestoreSnapshot метод заменяет данные хранилища сохраненным состоянием.
+// Несинхронизированные изменения отправляются на сервер при следующей синхронизации.
+func (s *UserStorage) RestoreSnapshot(snap Snapshot) error {
+	s.Passwords, s.Cards, s.Texts, s.Binaries = make([]Password, 0), make([]Card, 0), make([]Text, 0), make([]Binary, 0)
+	s.versions = make(map[string]int64)
+	s.changed = make(map[string]bool)
+	for _, rec := range snap.Records {
+		if rec.Version != 0 {
+			s.versions[rec.ID] = rec.Version
+		}
+		if rec.Deleted {
+			continue
+		}
+		err := s.insertRecord(rec)
+		if err != nil {
+			return err
+		}
+	}
+	s.MarkChanged(snap.Changed...)
+	s.base = snap.Base
+	s.TimeStamp, s.Version, s.Revision = snap.TimeStamp, snap.Version, snap.Revision
+	s.EmptyUsersTrash()
+	return nil
+}
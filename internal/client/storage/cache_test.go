@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gophkeeper/internal/records"
+)
+
+func TestSnapshot(t *testing.T) {
+	strg := NewUserStorage()
+	err := strg.ImportRecords([]records.Record{
+		{ID: "p1", Type: records.TypePassword, Version: 1, Revision: 3, Data: []byte(`{"ID":"p1","Name":"mail"}`)},
+		{ID: "t1", Type: records.TypeText, Version: 2, Revision: 4, Data: []byte(`{"ID":"t1","Name":"note"}`)},
+	}, "2023-05-30T10:00:00Z")
+	require.NoError(t, err)
+	strg.Version = 5
+
+	// Изменения без соединения с сервером: изменение, удаление и новая запись
+	strg.EditUsersPassword(0, &Password{ID: "p1", Name: "mail", Pass: "secret"})
+	require.True(t, strg.DeleteUsersText(0))
+	strg.AddUsersCard(&Card{Name: "bank"})
+	want, err := strg.ChangedRecords()
+	require.NoError(t, err)
+	require.Len(t, want, 3)
+
+	snap, err := strg.Snapshot()
+	require.NoError(t, err)
+	require.Len(t, snap.Changed, 3)
+
+	restored := NewUserStorage()
+	require.NoError(t, restored.RestoreSnapshot(snap))
+	require.Equal(t, strg.Passwords, restored.Passwords)
+	require.Equal(t, strg.Cards, restored.Cards)
+	require.Empty(t, restored.Texts)
+	require.Equal(t, int64(4), restored.Revision)
+	require.Equal(t, int64(5), restored.Version)
+	require.Equal(t, strg.TimeStamp, restored.TimeStamp)
+	got, err := restored.ChangedRecords()
+	require.NoError(t, err)
+	require.ElementsMatch(t, want, got)
+
+	// Последняя синхронизированная копия восстанавливается для слияния с данными сервера
+	server := NewUserStorage()
+	require.NoError(t, server.ImportRecords([]records.Record{
+		{ID: "p1", Type: records.TypePassword, Version: 1, Data: []byte(`{"ID":"p1","Name":"mail"}`)},
+		{ID: "t1", Type: records.TypeText, Version: 2, Data: []byte(`{"ID":"t1","Name":"note"}`)},
+	}, "2023-05-30T10:00:00Z"))
+	res, err := restored.Merge(server)
+	require.NoError(t, err)
+	require.Empty(t, res.Conflicts)
+}
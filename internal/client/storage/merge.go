@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gophkeeper/internal/records"
+)
+
+// MergeConflict структура описывает запись или поле записи, одновременно измененные на клиенте и на сервере.
+type MergeConflict struct {
+	ID        string //Идентификатор записи
+	Type      string //Тип записи
+	Name      string //Наименование записи
+	Field     string //Наименование поля, пустое при конфликте удаления записи
+	Local     string //Значение на клиенте для отображения пользователю
+	Server    string //Значение на сервере для отображения пользователю
+	UseServer bool   //Выбор пользователя: принять значение с сервера
+	local     json.RawMessage
+	server    json.RawMessage
+}
+
+// MergeResult структура хранит результат трехстороннего слияния до решения конфликтов пользователем.
+type MergeResult struct {
+	Conflicts []MergeConflict
+	merged    map[string]*mergeRecord
+	order     []string
+	server    *UserStorage
+}
+
+// mergeRecord структура хранит поля записи в процессе слияния.
+type mergeRecord struct {
+	recordType string
+	fields     map[string]json.RawMessage
+}
+
+// SaveBase метод запоминает текущее состояние хранилища как последнюю синхронизированную с сервером копию.
+func (s *UserStorage) SaveBase() error {
+	var err error
+	s.base, err = s.ExportRecords()
+	return err
+}
+
+// Merge метод выполняет трехстороннее слияние локальных данных с данными сервера относительно последней синхронизированной копии.
+// Непересекающиеся изменения принимаются автоматически, поля, измененные с обеих сторон, возвращаются в списке конфликтов.
+func (s *UserStorage) Merge(server *UserStorage) (*MergeResult, error) {
+	base, err := recordsByID(s.base)
+	if err != nil {
+		return nil, err
+	}
+	localRecs, err := s.ExportRecords()
+	if err != nil {
+		return nil, err
+	}
+	local, err := recordsByID(localRecs)
+	if err != nil {
+		return nil, err
+	}
+	serverRecs, err := server.ExportRecords()
+	if err != nil {
+		return nil, err
+	}
+	remote, err := recordsByID(serverRecs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := MergeResult{merged: make(map[string]*mergeRecord), server: server}
+	for _, rec := range localRecs {
+		res.order = append(res.order, rec.ID)
+	}
+	for _, rec := range serverRecs {
+		if local[rec.ID] == nil {
+			res.order = append(res.order, rec.ID)
+		}
+	}
+	for _, id := range res.order {
+		b, l, r := base[id], local[id], remote[id]
+		switch {
+		case l == nil || r == nil:
+			res.mergeDeleted(id, b, l, r)
+		default:
+			res.mergeFields(id, b, l, r)
+		}
+	}
+	return &res, nil
+}
+
+// ApplyMerge метод сохраняет в хранилище результат слияния с учетом выбора пользователя по каждому конфликту.
+// Данные сервера становятся последней синхронизированной копией.
+func (s *UserStorage) ApplyMerge(res *MergeResult) error {
+	for _, conflict := range res.Conflicts {
+		if !conflict.UseServer {
+			continue
+		}
+		if conflict.Field == "" {
+			res.merged[conflict.ID] = nil
+			if conflict.server != nil {
+				var fields map[string]json.RawMessage
+				err := json.Unmarshal(conflict.server, &fields)
+				if err != nil {
+					return err
+				}
+				res.merged[conflict.ID] = &mergeRecord{recordType: conflict.Type, fields: fields}
+			}
+			continue
+		}
+		if conflict.server == nil {
+			delete(res.merged[conflict.ID].fields, conflict.Field)
+			continue
+		}
+		res.merged[conflict.ID].fields[conflict.Field] = conflict.server
+	}
+
+	serverRecs, err := res.server.ExportRecords()
+	if err != nil {
+		return err
+	}
+	remote, err := recordsByID(serverRecs)
+	if err != nil {
+		return err
+	}
+	s.Passwords, s.Cards, s.Texts, s.Binaries = make([]Password, 0), make([]Card, 0), make([]Text, 0), make([]Binary, 0)
+	s.changed = make(map[string]bool)
+	for id, version := range res.server.versions {
+		s.versions[id] = version
+	}
+	for _, id := range res.order {
+		rec := res.merged[id]
+		if rec == nil {
+			if remote[id] != nil {
+				s.changed[id] = true
+			}
+			continue
+		}
+		jsonBZ, err := json.Marshal(rec.fields)
+		if err != nil {
+			return err
+		}
+		err = s.insertRecord(records.Record{ID: id, Type: rec.recordType, Data: jsonBZ})
+		if err != nil {
+			return err
+		}
+		if remote[id] == nil || !sameFields(remote[id].fields, rec.fields) {
+			s.changed[id] = true
+		}
+	}
+	s.TimeStamp = res.server.TimeStamp
+	s.Version = res.server.Version
+	s.Revision = res.server.Revision
+	s.base = serverRecs
+	return nil
+}
+
+// mergeDeleted метод выполняет слияние записи, отсутствующей на клиенте или на сервере.
+func (res *MergeResult) mergeDeleted(id string, b, l, r *mergeRecord) {
+	switch {
+	case b == nil && l != nil:
+		res.merged[id] = l
+	case b == nil && r != nil:
+		res.merged[id] = r
+	case l == nil && r == nil:
+		res.merged[id] = nil
+	case l == nil && sameFields(b.fields, r.fields):
+		res.merged[id] = nil
+	case r == nil && sameFields(b.fields, l.fields):
+		res.merged[id] = nil
+	case l == nil:
+		res.merged[id] = nil
+		res.addConflict(id, r, "", nil, r.raw())
+	default:
+		res.merged[id] = l
+		res.addConflict(id, l, "", l.raw(), nil)
+	}
+}
+
+// mergeFields метод выполняет слияние полей записи, присутствующей на клиенте и на сервере.
+// Сливаются все поля, известные последней синхронизированной копии, клиенту или серверу, поэтому поля,
+// добавленные или удаленные только с одной стороны, не теряются. Отсутствующее поле имеет значение nil.
+func (res *MergeResult) mergeFields(id string, b, l, r *mergeRecord) {
+	if b == nil {
+		b = &mergeRecord{recordType: l.recordType, fields: make(map[string]json.RawMessage)}
+	}
+	merged := &mergeRecord{recordType: l.recordType, fields: make(map[string]json.RawMessage)}
+	res.merged[id] = merged
+	for _, field := range fieldNames(b, l, r) {
+		bv, lv, rv := b.fields[field], l.fields[field], r.fields[field]
+		var mv json.RawMessage
+		switch {
+		case bytes.Equal(lv, bv):
+			mv = rv
+		case bytes.Equal(rv, bv), bytes.Equal(lv, rv):
+			mv = lv
+		default:
+			mv = lv
+			res.addConflict(id, l, field, lv, rv)
+		}
+		if mv != nil {
+			merged.fields[field] = mv
+		}
+	}
+}
+
+// fieldNames функция возвращает отсортированное объединение наименований полей записей.
+func fieldNames(recs ...*mergeRecord) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, rec := range recs {
+		for field := range rec.fields {
+			if !seen[field] {
+				seen[field] = true
+				names = append(names, field)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addConflict метод добавляет конфликт в результат слияния.
+func (res *MergeResult) addConflict(id string, rec *mergeRecord, field string, local, server json.RawMessage) {
+	res.Conflicts = append(res.Conflicts, MergeConflict{
+		ID:     id,
+		Type:   rec.recordType,
+		Name:   rec.name(),
+		Field:  field,
+		Local:  displayValue(rec.recordType, field, local),
+		Server: displayValue(rec.recordType, field, server),
+		local:  local,
+		server: server,
+	})
+}
+
+// raw метод кодирует поля записи в JSON.
+func (r *mergeRecord) raw() json.RawMessage {
+	jsonBZ, _ := json.Marshal(r.fields)
+	return jsonBZ
+}
+
+// name метод возвращает наименование записи.
+func (r *mergeRecord) name() string {
+	var name string
+	json.Unmarshal(r.fields["Name"], &name)
+	return name
+}
+
+// recordsByID функция раскладывает поля записей по идентификаторам.
+func recordsByID(recs []records.Record) (map[string]*mergeRecord, error) {
+	byID := make(map[string]*mergeRecord, len(recs))
+	for _, rec := range recs {
+		var fields map[string]json.RawMessage
+		err := json.Unmarshal(rec.Data, &fields)
+		if err != nil {
+			return nil, err
+		}
+		byID[rec.ID] = &mergeRecord{recordType: rec.Type, fields: fields}
+	}
+	return byID, nil
+}
+
+// sameFields функция сравнивает поля двух записей.
+func sameFields(a, b map[string]json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for field, val := range a {
+		if !bytes.Equal(val, b[field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// displayValue функция преобразует значение поля для отображения пользователю.
+func displayValue(recordType, field string, val json.RawMessage) string {
+	if val == nil && field != "" {
+		return "поле отсутствует"
+	}
+	if val == nil {
+		return "запись удалена"
+	}
+	if field == "" {
+		return "запись изменена"
+	}
+	var str string
+	if json.Unmarshal(val, &str) != nil {
+		return string(val)
+	}
+	if recordType == records.TypeBinary && field == "Data" {
+		data, err := base64.StdEncoding.DecodeString(str)
+		if err == nil {
+			return fmt.Sprintf("двоичные данные, %d байт", len(data))
+		}
+	}
+	return str
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	local := NewUserStorage()
+	local.AddUsersPassword(&Password{ID: "p1", Name: "mail", Login: "user", Pass: "123"})
+	local.AddUsersText(&Text{ID: "t1", Name: "note", Data: "text"})
+	local.AddUsersCard(&Card{ID: "c1", Name: "card", CardNumber: "1111"})
+	require.NoError(t, local.SaveBase())
+
+	server := NewUserStorage()
+	server.AddUsersPassword(&Password{ID: "p1", Name: "mail", Login: "user", Pass: "456"})
+	server.AddUsersText(&Text{ID: "t1", Name: "note", Data: "server text"})
+	server.AddUsersBinary(&Binary{ID: "b1", Name: "file", Data: []byte("data")})
+
+	// Непересекающиеся изменения одной записи, конфликт поля и удаление на клиенте
+	local.EditUsersPassword(0, &Password{Name: "mail", Login: "admin", Pass: "123"})
+	local.EditUsersText(0, &Text{Name: "note", Data: "local text"})
+	local.Cards = local.Cards[:0]
+
+	res, err := local.Merge(server)
+	require.NoError(t, err)
+	require.Len(t, res.Conflicts, 1)
+	require.Equal(t, "Data", res.Conflicts[0].Field)
+	require.Equal(t, "local text", res.Conflicts[0].Local)
+	require.Equal(t, "server text", res.Conflicts[0].Server)
+
+	res.Conflicts[0].UseServer = true
+	require.NoError(t, local.ApplyMerge(res))
+	require.Equal(t, []Password{{ID: "p1", Name: "mail", Login: "admin", Pass: "456"}}, local.Passwords)
+	require.Equal(t, []Text{{ID: "t1", Name: "note", Data: "server text"}}, local.Texts)
+	require.Empty(t, local.Cards)
+	require.Len(t, local.Binaries, 1)
+
+	// Удаление на сервере записи, измененной на клиенте
+	server.Binaries = server.Binaries[:0]
+	local.EditUsersBinary(0, &Binary{Name: "file", Data: []byte("new data")})
+	res, err = local.Merge(server)
+	require.NoError(t, err)
+	require.Len(t, res.Conflicts, 1)
+	require.Equal(t, "", res.Conflicts[0].Field)
+	require.NoError(t, local.ApplyMerge(res))
+	require.Len(t, local.Binaries, 1)
+}
+
+func TestMergeServerFields(t *testing.T) {
+	local := NewUserStorage()
+	local.AddUsersBinary(&Binary{ID: "b1", Name: "file", Data: []byte("data")})
+	require.NoError(t, local.SaveBase())
+
+	// На сервере файл перенесен во вложение, на клиенте изменено примечание:
+	// поля, которых нет в локальной записи, сохраняются
+	server := NewUserStorage()
+	server.AddUsersBinary(&Binary{ID: "b1", Name: "file", Attachment: "a1", Hash: "abc", Size: 4})
+	local.EditUsersBinary(0, &Binary{Name: "file", Data: []byte("data"), Comment: "note"})
+
+	res, err := local.Merge(server)
+	require.NoError(t, err)
+	require.Empty(t, res.Conflicts)
+	require.NoError(t, local.ApplyMerge(res))
+	require.Equal(t, []Binary{{ID: "b1", Name: "file", Comment: "note", Attachment: "a1", Hash: "abc", Size: 4}}, local.Binaries)
+
+	// Поле, удаленное на сервере, удаляется и из объединенной записи
+	require.NoError(t, local.SaveBase())
+	server.EditUsersBinary(0, &Binary{Name: "file", Data: []byte("data"), Comment: "note"})
+	local.EditUsersBinary(0, &Binary{Name: "file", Comment: "note 2", Attachment: "a1", Hash: "abc", Size: 4})
+	res, err = local.Merge(server)
+	require.NoError(t, err)
+	require.Empty(t, res.Conflicts)
+	require.NoError(t, local.ApplyMerge(res))
+	require.Equal(t, []Binary{{ID: "b1", Name: "file", Data: []byte("data"), Comment: "note 2"}}, local.Binaries)
+}
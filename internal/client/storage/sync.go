@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// conflictSuffix добавляется к имени локальной копии записи, измененной одновременно на другом устройстве.
+const conflictSuffix = " (конфликт)"
+
+// CheckTypes функция возвращает ошибку, если среди записей есть запись неизвестного типа, например сохраненная
+// более новой версией клиента. Такая запись не может храниться в хранилище, а пропустить ее нельзя: следующее сохранение
+// данных удалило бы ее на сервере. Записи с отметкой об удалении не проверяются.
+func CheckTypes(recs ...records.Record) error {
+	for _, rec := range recs {
+		if !rec.Deleted && !records.KnownType(rec.Type) {
+			return fmt.Errorf("%w: %s", gkerrors.ErrRecordTypeUnknown, rec.Type)
+		}
+	}
+	return nil
+}
+
+// ChangedRecords метод возвращает записи, измененные после последней синхронизации, с версией, на основе которой они изменены.
+// Удаленные записи возвращаются с отметкой об удалении.
+func (s *UserStorage) ChangedRecords() ([]records.Record, error) {
+	all, err := s.ExportRecords()
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(all))
+	recs := make([]records.Record, 0, len(s.changed))
+	for _, rec := range all {
+		found[rec.ID] = true
+		if !s.changed[rec.ID] {
+			continue
+		}
+		rec.Version = s.versions[rec.ID]
+		recs = append(recs, rec)
+	}
+	for id := range s.changed {
+		if found[id] || s.versions[id] == 0 {
+			continue
+		}
+		recs = append(recs, records.Record{ID: id, Version: s.versions[id], Deleted: true})
+	}
+	return recs, nil
+}
+
+// ApplyRecord метод сохраняет в хранилище расшифрованную запись, полученную с сервера.
+func (s *UserStorage) ApplyRecord(rec records.Record) error {
+	err := CheckTypes(rec)
+	if err != nil {
+		return err
+	}
+	s.removeRecord(rec.ID)
+	if !rec.Deleted {
+		err := s.insertRecord(rec)
+		if err != nil {
+			return err
+		}
+	}
+	s.versions[rec.ID] = rec.Version
+	delete(s.changed, rec.ID)
+	if rec.Deleted {
+		delete(s.versions, rec.ID)
+	}
+	return nil
+}
+
+// ResolveConflict метод принимает серверную копию записи, измененной одновременно на другом устройстве.
+// Локальная версия записи сохраняется как новая запись с отметкой о конфликте. Если серверная копия совпадает
+// с локальной, изменение уже сохранено на сервере, например повторно отправлено из локального кэша после сбоя,
+// и запись принимается без конфликта. Возвращает true, если локальная версия сохранена с отметкой о конфликте.
+func (s *UserStorage) ResolveConflict(server records.Record) (bool, error) {
+	err := CheckTypes(server)
+	if err != nil {
+		return false, err
+	}
+	local, err := s.recordByID(server.ID)
+	if err != nil {
+		return false, err
+	}
+	if local != nil && !server.Deleted && sameRecord(*local, server) {
+		return false, s.ApplyRecord(server)
+	}
+	if local != nil {
+		local.ID = newRecordID()
+		err = s.insertRecord(*local)
+		if err != nil {
+			return false, err
+		}
+		s.renameConflict(local.ID)
+		s.changed[local.ID] = true
+	}
+	return local != nil, s.ApplyRecord(server)
+}
+
+// sameRecord функция сравнивает тип и поля расшифрованных данных двух записей.
+func sameRecord(a, b records.Record) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	var af, bf map[string]json.RawMessage
+	if json.Unmarshal(a.Data, &af) != nil || json.Unmarshal(b.Data, &bf) != nil {
+		return false
+	}
+	return sameFields(af, bf)
+}
+
+// MarkChanged метод помечает записи измененными, чтобы отправить их на сервер при следующей синхронизации.
+func (s *UserStorage) MarkChanged(ids ...string) {
+	for _, id := range ids {
+		s.changed[id] = true
+	}
+}
+
+// ChangedCount метод возвращает количество записей, измененных после последней синхронизации.
+func (s *UserStorage) ChangedCount() int {
+	return len(s.changed)
+}
+
+// ResetSync метод сбрасывает состояние синхронизации после сохранения всех данных единым массивом.
+// Версии записей также сбрасываются: они будут получены заново при следующей синхронизации.
+func (s *UserStorage) ResetSync() {
+	s.Revision = 0
+	s.changed = make(map[string]bool)
+	s.versions = make(map[string]int64)
+}
+
+// allIDs метод возвращает идентификаторы всех записей хранилища.
+func (s *UserStorage) allIDs() []string {
+	ids := make([]string, 0, len(s.Passwords)+len(s.Cards)+len(s.Texts)+len(s.Binaries))
+	for _, val := range s.Passwords {
+		ids = append(ids, val.ID)
+	}
+	for _, val := range s.Cards {
+		ids = append(ids, val.ID)
+	}
+	for _, val := range s.Texts {
+		ids = append(ids, val.ID)
+	}
+	for _, val := range s.Binaries {
+		ids = append(ids, val.ID)
+	}
+	return ids
+}
+
+// recordByID метод возвращает запись хранилища с указанным идентификатором, или nil при ее отсутствии.
+func (s *UserStorage) recordByID(id string) (*records.Record, error) {
+	all, err := s.ExportRecords()
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range all {
+		if rec.ID == id {
+			return &rec, nil
+		}
+	}
+	return nil, nil
+}
+
+// insertRecord метод добавляет в хранилище расшифрованную запись в соответствующий раздел.
+func (s *UserStorage) insertRecord(rec records.Record) error {
+	var err error
+	switch rec.Type {
+	case records.TypePassword:
+		var pass Password
+		err = json.Unmarshal(rec.Data, &pass)
+		pass.ID = rec.ID
+		s.Passwords = append(s.Passwords, pass)
+	case records.TypeCard:
+		var card Card
+		err = json.Unmarshal(rec.Data, &card)
+		card.ID = rec.ID
+		s.Cards = append(s.Cards, card)
+	case records.TypeText:
+		var text Text
+		err = json.Unmarshal(rec.Data, &text)
+		text.ID = rec.ID
+		s.Texts = append(s.Texts, text)
+	case records.TypeBinary:
+		var binary Binary
+		err = json.Unmarshal(rec.Data, &binary)
+		binary.ID = rec.ID
+		s.Binaries = append(s.Binaries, binary)
+	default:
+		return CheckTypes(rec)
+	}
+	return err
+}
+
+// removeRecord метод удаляет из хранилища запись с указанным идентификатором.
+func (s *UserStorage) removeRecord(id string) {
+	for i := range s.Passwords {
+		if s.Passwords[i].ID == id {
+			s.Passwords = append(s.Passwords[:i], s.Passwords[i+1:]...)
+			return
+		}
+	}
+	for i := range s.Cards {
+		if s.Cards[i].ID == id {
+			s.Cards = append(s.Cards[:i], s.Cards[i+1:]...)
+			return
+		}
+	}
+	for i := range s.Texts {
+		if s.Texts[i].ID == id {
+			s.Texts = append(s.Texts[:i], s.Texts[i+1:]...)
+			return
+		}
+	}
+	for i := range s.Binaries {
+		if s.Binaries[i].ID == id {
+			s.Binaries = append(s.Binaries[:i], s.Binaries[i+1:]...)
+			return
+		}
+	}
+}
+
+// renameConflict метод добавляет к имени записи отметку о конфликте.
+func (s *UserStorage) renameConflict(id string) {
+	for i := range s.Passwords {
+		if s.Passwords[i].ID == id {
+			s.Passwords[i].Name += conflictSuffix
+		}
+	}
+	for i := range s.Cards {
+		if s.Cards[i].ID == id {
+			s.Cards[i].Name += conflictSuffix
+		}
+	}
+	for i := range s.Texts {
+		if s.Texts[i].ID == id {
+			s.Texts[i].Name += conflictSuffix
+		}
+	}
+	for i := range s.Binaries {
+		if s.Binaries[i].ID == id {
+			s.Binaries[i].Name += conflictSuffix
+		}
+	}
+}
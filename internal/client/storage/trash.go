@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"gophkeeper/internal/records"
+)
+
+// TrashItem структура для хранения удаленной записи в корзине до сохранения данных на сервер.
+type TrashItem struct {
+	Name   string         //Имя удаленной записи
+	Record records.Record //Удаленная запись
+}
+
+// DeleteUsersPassword метод перемещает запись с паролем в корзину.
+func (s *UserStorage) DeleteUsersPassword(i int) bool {
+	if i < 0 || i >= len(s.Passwords) {
+		return false
+	}
+	s.moveToTrash(s.Passwords[i].ID, s.Passwords[i].Name, records.TypePassword, s.Passwords[i])
+	s.Passwords = append(s.Passwords[:i], s.Passwords[i+1:]...)
+	return true
+}
+
+// DeleteUsersCard метод перемещает запись с данными карты в корзину.
+func (s *UserStorage) DeleteUsersCard(i int) bool {
+	if i < 0 || i >= len(s.Cards) {
+		return false
+	}
+	s.moveToTrash(s.Cards[i].ID, s.Cards[i].Name, records.TypeCard, s.Cards[i])
+	s.Cards = append(s.Cards[:i], s.Cards[i+1:]...)
+	return true
+}
+
+// DeleteUsersText метод перемещает запись с текстом в корзину.
+func (s *UserStorage) DeleteUsersText(i int) bool {
+	if i < 0 || i >= len(s.Texts) {
+		return false
+	}
+	s.moveToTrash(s.Texts[i].ID, s.Texts[i].Name, records.TypeText, s.Texts[i])
+	s.Texts = append(s.Texts[:i], s.Texts[i+1:]...)
+	return true
+}
+
+// DeleteUsersBinary метод перемещает запись с двоичными данными в корзину.
+func (s *UserStorage) DeleteUsersBinary(i int) bool {
+	if i < 0 || i >= len(s.Binaries) {
+		return false
+	}
+	s.moveToTrash(s.Binaries[i].ID, s.Binaries[i].Name, records.TypeBinary, s.Binaries[i])
+	s.Binaries = append(s.Binaries[:i], s.Binaries[i+1:]...)
+	return true
+}
+
+// SliceUsersTrash метод возвращает записи, удаленные после последнего сохранения данных на сервер.
+func (s *UserStorage) SliceUsersTrash() []TrashItem {
+	return s.trash
+}
+
+// RestoreUsersTrash метод возвращает запись из корзины в соответствующий раздел.
+func (s *UserStorage) RestoreUsersTrash(i int) (bool, error) {
+	if i < 0 || i >= len(s.trash) {
+		return false, nil
+	}
+	err := s.insertRecord(s.trash[i].Record)
+	if err != nil {
+		return false, err
+	}
+	s.changed[s.trash[i].Record.ID] = true
+	s.trash = append(s.trash[:i], s.trash[i+1:]...)
+	return true, nil
+}
+
+// EmptyUsersTrash метод очищает корзину. Удаление записей на сервере выполняется при следующем сохранении данных.
+func (s *UserStorage) EmptyUsersTrash() {
+	s.trash = make([]TrashItem, 0)
+}
+
+// moveToTrash метод сохраняет удаляемую запись в корзине и отмечает ее измененной.
+func (s *UserStorage) moveToTrash(id, name, recordType string, v any) {
+	jsonBZ, _ := json.Marshal(v)
+	s.trash = append(s.trash, TrashItem{Name: name, Record: records.Record{ID: id, Type: recordType, Data: jsonBZ}})
+	s.changed[id] = true
+}
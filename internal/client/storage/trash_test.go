@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gophkeeper/internal/records"
+)
+
+func TestTrash(t *testing.T) {
+	strg := NewUserStorage()
+	err := strg.ImportRecords([]records.Record{
+		{ID: "p1", Type: records.TypePassword, Version: 1, Data: []byte(`{"ID":"p1","Name":"mail"}`)},
+		{ID: "t1", Type: records.TypeText, Version: 2, Data: []byte(`{"ID":"t1","Name":"note"}`)},
+	}, "2023-05-30T10:00:00Z")
+	require.NoError(t, err)
+
+	// Удаление записи с несуществующим номером
+	require.False(t, strg.DeleteUsersText(1))
+	require.False(t, strg.DeleteUsersText(-1))
+	require.Nil(t, strg.StringUsersText(-1))
+
+	// Удаление и восстановление записи
+	require.True(t, strg.DeleteUsersPassword(0))
+	require.Empty(t, strg.Passwords)
+	require.Len(t, strg.SliceUsersTrash(), 1)
+	restored, err := strg.RestoreUsersTrash(0)
+	require.NoError(t, err)
+	require.True(t, restored)
+	require.Equal(t, []Password{{ID: "p1", Name: "mail"}}, strg.Passwords)
+	require.Empty(t, strg.SliceUsersTrash())
+
+	// Удаленная запись передается на сервер с отметкой об удалении после очистки корзины
+	require.True(t, strg.DeleteUsersText(0))
+	strg.EmptyUsersTrash()
+	changed, err := strg.ChangedRecords()
+	require.NoError(t, err)
+	require.Contains(t, changed, records.Record{ID: "t1", Version: 2, Deleted: true})
+	exported, err := strg.ExportRecords()
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+}
@@ -3,12 +3,19 @@
 package storage
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"time"
+
+	"gophkeeper/internal/records"
 )
 
 // Password структура для хранения логинов и паролей клиента.
 type Password struct {
+	ID      string
 	Name    string
 	Login   string
 	Pass    string
@@ -17,6 +24,7 @@ type Password struct {
 
 // Card структура для хранения данных карты клиента.
 type Card struct {
+	ID         string
 	Name       string
 	CardNumber string
 	Comment    string
@@ -24,27 +32,37 @@ type Card struct {
 
 // Text структура для хранения текстовых данных клиента.
 type Text struct {
+	ID      string
 	Name    string
 	Data    string
 	Comment string
 }
 
 // Binary структура для хранения произвольных данных клиента.
+// Файл размером больше 64кБ хранится на сервере вложением, запись содержит ссылку на него и хэш содержимого.
 type Binary struct {
-	Name    string
-	Data    []byte
-	Comment string
+	ID         string
+	Name       string
+	Data       []byte
+	Comment    string
+	Attachment string `json:",omitempty"` //Идентификатор вложения на сервере, пустой если файл хранится в Data
+	Hash       string `json:",omitempty"` //Хэш SHA-256 содержимого вложения, в hex
+	Size       int64  `json:",omitempty"` //Размер вложения, в байтах
 }
 
 // UserStorage структура для хранения данных на клиенте.
 type UserStorage struct {
-	TimeStamp  time.Time  `json:"-"`
-	Passwords  []Password `json:"passwords"`
-	Cards      []Card     `json:"cards"`
-	Texts      []Text     `json:"texts"`
-	Binaries   []Binary   `json:"binaries"`
-	Locked     bool       `json:"-"`
-	TimeLocked time.Time  `json:"-"`
+	TimeStamp time.Time        `json:"-"`
+	Version   int64            `json:"-"` //Версия данных на сервере, на основе которой выполнены изменения
+	Passwords []Password       `json:"passwords"`
+	Cards     []Card           `json:"cards"`
+	Texts     []Text           `json:"texts"`
+	Binaries  []Binary         `json:"binaries"`
+	Revision  int64            `json:"-"` //Последняя синхронизированная ревизия данных на сервере
+	versions  map[string]int64 //Версии записей на сервере
+	changed   map[string]bool  //Записи, измененные после последней синхронизации
+	base      []records.Record //Последняя синхронизированная с сервером копия записей
+	trash     []TrashItem      //Записи, удаленные после последнего сохранения данных на сервер
 }
 
 // NewUserStorage метод генерирует хранилище оперативных данных.
@@ -54,6 +72,9 @@ func NewUserStorage() *UserStorage {
 		Cards:     make([]Card, 0),
 		Texts:     make([]Text, 0),
 		Binaries:  make([]Binary, 0),
+		versions:  make(map[string]int64),
+		changed:   make(map[string]bool),
+		trash:     make([]TrashItem, 0),
 	}
 }
 
@@ -68,7 +89,131 @@ func (s *UserStorage) ImportUserData(jsonBZ []byte, timeStamp string) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	s.fillIDs()
+	s.Revision = 0
+	s.versions = make(map[string]int64)
+	s.changed = make(map[string]bool)
+	for _, rec := range s.allIDs() {
+		s.changed[rec] = true
+	}
+	s.EmptyUsersTrash()
+	return s.SaveBase()
+}
+
+// ImportRecords метод собирает хранилище из отдельных расшифрованных записей пользователя.
+// При записи неизвестного типа хранилище не изменяется и возвращается ошибка.
+func (s *UserStorage) ImportRecords(recs []records.Record, timeStamp string) error {
+	err := CheckTypes(recs...)
+	if err != nil {
+		return err
+	}
+	s.TimeStamp, err = time.Parse(time.RFC3339, timeStamp)
+	if err != nil {
+		return err
+	}
+	s.Passwords, s.Cards, s.Texts, s.Binaries = make([]Password, 0), make([]Card, 0), make([]Text, 0), make([]Binary, 0)
+	s.Revision = 0
+	s.versions = make(map[string]int64)
+	s.changed = make(map[string]bool)
+	for _, rec := range recs {
+		s.versions[rec.ID] = rec.Version
+		if rec.Revision > s.Revision {
+			s.Revision = rec.Revision
+		}
+		switch rec.Type {
+		case records.TypePassword:
+			var pass Password
+			err = json.Unmarshal(rec.Data, &pass)
+			s.Passwords = append(s.Passwords, pass)
+		case records.TypeCard:
+			var card Card
+			err = json.Unmarshal(rec.Data, &card)
+			s.Cards = append(s.Cards, card)
+		case records.TypeText:
+			var text Text
+			err = json.Unmarshal(rec.Data, &text)
+			s.Texts = append(s.Texts, text)
+		case records.TypeBinary:
+			var binary Binary
+			err = json.Unmarshal(rec.Data, &binary)
+			s.Binaries = append(s.Binaries, binary)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	s.fillIDs()
+	s.EmptyUsersTrash()
+	return s.SaveBase()
+}
+
+// ExportRecords метод разбивает данные пользователя на отдельные записи для шифрования и отправки.
+func (s *UserStorage) ExportRecords() ([]records.Record, error) {
+	recs := make([]records.Record, 0, len(s.Passwords)+len(s.Cards)+len(s.Texts)+len(s.Binaries))
+	add := func(id, recordType string, v any) error {
+		jsonBZ, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		recs = append(recs, records.Record{ID: id, Type: recordType, Data: jsonBZ})
+		return nil
+	}
+	for _, val := range s.Passwords {
+		if err := add(val.ID, records.TypePassword, val); err != nil {
+			return nil, err
+		}
+	}
+	for _, val := range s.Cards {
+		if err := add(val.ID, records.TypeCard, val); err != nil {
+			return nil, err
+		}
+	}
+	for _, val := range s.Texts {
+		if err := add(val.ID, records.TypeText, val); err != nil {
+			return nil, err
+		}
+	}
+	for _, val := range s.Binaries {
+		if err := add(val.ID, records.TypeBinary, val); err != nil {
+			return nil, err
+		}
+	}
+	return recs, nil
+}
+
+// fillIDs метод присваивает идентификаторы записям, сохраненным без них.
+func (s *UserStorage) fillIDs() {
+	for i := range s.Passwords {
+		if s.Passwords[i].ID == "" {
+			s.Passwords[i].ID = newRecordID()
+		}
+	}
+	for i := range s.Cards {
+		if s.Cards[i].ID == "" {
+			s.Cards[i].ID = newRecordID()
+		}
+	}
+	for i := range s.Texts {
+		if s.Texts[i].ID == "" {
+			s.Texts[i].ID = newRecordID()
+		}
+	}
+	for i := range s.Binaries {
+		if s.Binaries[i].ID == "" {
+			s.Binaries[i].ID = newRecordID()
+		}
+	}
+}
+
+// newRecordID функция генерирует идентификатор новой записи.
+// Без источника случайных чисел работа невозможна: одинаковые идентификаторы записей перезаписали бы друг друга на сервере.
+func newRecordID() string {
+	id := make([]byte, 16)
+	_, err := io.ReadFull(rand.Reader, id)
+	if err != nil {
+		panic(fmt.Sprintf("newRecordID crypto/rand error: %v", err))
+	}
+	return hex.EncodeToString(id)
 }
 
 // ExportUserData метод кодирует данные пользователя для отправки.
@@ -107,7 +252,7 @@ func (s *UserStorage) SliceUsersBinaries() []Binary {
 
 // StringUsersPassword метод возвращает полную информацию о сохраненной строке записи с паролем.
 func (s *UserStorage) StringUsersPassword(v int) *Password {
-	if v >= len(s.Passwords) {
+	if v < 0 || v >= len(s.Passwords) {
 		return nil
 	}
 	return &s.Passwords[v]
@@ -115,7 +260,7 @@ func (s *UserStorage) StringUsersPassword(v int) *Password {
 
 // StringUsersCard метод возвращает полную информацию о сохраненной строке записи с картами.
 func (s *UserStorage) StringUsersCard(v int) *Card {
-	if v >= len(s.Cards) {
+	if v < 0 || v >= len(s.Cards) {
 		return nil
 	}
 	return &s.Cards[v]
@@ -123,7 +268,7 @@ func (s *UserStorage) StringUsersCard(v int) *Card {
 
 // StringUsersText метод возвращает полную информацию о сохраненной строке записи с текстовыми данными.
 func (s *UserStorage) StringUsersText(v int) *Text {
-	if v >= len(s.Texts) {
+	if v < 0 || v >= len(s.Texts) {
 		return nil
 	}
 	return &s.Texts[v]
@@ -131,7 +276,7 @@ func (s *UserStorage) StringUsersText(v int) *Text {
 
 // StringUsersBinary метод возвращает полную информацию о сохраненной строке записи с двоичными данными.
 func (s *UserStorage) StringUsersBinary(v int) *Binary {
-	if v >= len(s.Binaries) {
+	if v < 0 || v >= len(s.Binaries) {
 		return nil
 	}
 	return &s.Binaries[v]
@@ -139,40 +284,64 @@ func (s *UserStorage) StringUsersBinary(v int) *Binary {
 
 // AddUserData метод добавляет новую запись с паролем.
 func (s *UserStorage) AddUsersPassword(pass *Password) {
+	if pass.ID == "" {
+		pass.ID = newRecordID()
+	}
 	s.Passwords = append(s.Passwords, *pass)
+	s.changed[pass.ID] = true
 }
 
 // AddUserData метод добавляет новую запись с картами.
 func (s *UserStorage) AddUsersCard(card *Card) {
+	if card.ID == "" {
+		card.ID = newRecordID()
+	}
 	s.Cards = append(s.Cards, *card)
+	s.changed[card.ID] = true
 }
 
 // AddUserData метод добавляет новую запись с текстом.
 func (s *UserStorage) AddUsersText(text *Text) {
+	if text.ID == "" {
+		text.ID = newRecordID()
+	}
 	s.Texts = append(s.Texts, *text)
+	s.changed[text.ID] = true
 }
 
 // AddUserData метод добавляет новую запись с двоичными данными.
 func (s *UserStorage) AddUsersBinary(binary *Binary) {
+	if binary.ID == "" {
+		binary.ID = newRecordID()
+	}
 	s.Binaries = append(s.Binaries, *binary)
+	s.changed[binary.ID] = true
 }
 
 // EditUsersPassword метод редактирует существующую запись с данными пароля.
 func (s *UserStorage) EditUsersPassword(i int, password *Password) {
+	password.ID = s.Passwords[i].ID
 	s.Passwords[i] = *password
+	s.changed[password.ID] = true
 }
 
 // EditUsersCard метод редактирует существующую запись с данными карты.
 func (s *UserStorage) EditUsersCard(i int, card *Card) {
+	card.ID = s.Cards[i].ID
 	s.Cards[i] = *card
+	s.changed[card.ID] = true
 }
 
 // EditUsersCard метод редактирует существующую запись с данными текста.
 func (s *UserStorage) EditUsersText(i int, text *Text) {
+	text.ID = s.Texts[i].ID
 	s.Texts[i] = *text
+	s.changed[text.ID] = true
 }
 
 // EditUsersBinary метод редактирует существующую запись с двоичными данными.
 func (s *UserStorage) EditUsersBinary(i int, binary *Binary) {
+	binary.ID = s.Binaries[i].ID
 	s.Binaries[i] = *binary
+	s.changed[binary.ID] = true
 }
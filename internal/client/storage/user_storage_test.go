@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+func TestImportRecordsUnknownType(t *testing.T) {
+	strg := NewUserStorage()
+	err := strg.ImportRecords([]records.Record{
+		{ID: "p1", Type: records.TypePassword, Version: 1, Revision: 3, Data: []byte(`{"ID":"p1","Name":"mail"}`)},
+	}, "2023-05-30T10:00:00Z")
+	require.NoError(t, err)
+
+	// Запись неизвестного типа не отбрасывается молча: импорт завершается ошибкой, данные хранилища не изменяются
+	err = strg.ImportRecords([]records.Record{
+		{ID: "t1", Type: records.TypeText, Version: 2, Revision: 5, Data: []byte(`{"ID":"t1","Name":"note"}`)},
+		{ID: "x1", Type: "passkey", Version: 1, Revision: 4, Data: []byte(`{"ID":"x1"}`)},
+	}, "2023-06-01T10:00:00Z")
+	require.ErrorIs(t, err, gkerrors.ErrRecordTypeUnknown)
+	require.ErrorContains(t, err, "passkey")
+	require.Equal(t, []Password{{ID: "p1", Name: "mail"}}, strg.Passwords)
+	require.Empty(t, strg.Texts)
+	require.Equal(t, int64(3), strg.Revision)
+	require.Equal(t, "2023-05-30T10:00:00Z", strg.TimeStamp.Format("2006-01-02T15:04:05Z07:00"))
+}
+
+func TestApplyRecordUnknownType(t *testing.T) {
+	strg := NewUserStorage()
+	err := strg.ImportRecords([]records.Record{
+		{ID: "p1", Type: records.TypePassword, Version: 1, Data: []byte(`{"ID":"p1","Name":"mail"}`)},
+	}, "2023-05-30T10:00:00Z")
+	require.NoError(t, err)
+
+	// Запись неизвестного типа не считается примененной: ее версия не сохраняется, и она не пропадает из следующей синхронизации
+	unknown := records.Record{ID: "x1", Type: "passkey", Version: 1, Revision: 2, Data: []byte(`{"ID":"x1"}`)}
+	require.ErrorIs(t, strg.ApplyRecord(unknown), gkerrors.ErrRecordTypeUnknown)
+	_, err = strg.ResolveConflict(unknown)
+	require.ErrorIs(t, err, gkerrors.ErrRecordTypeUnknown)
+	require.NotContains(t, strg.versions, "x1")
+	exported, err := strg.ExportRecords()
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+
+	// Удаление записи неизвестного типа применяется, так как данные записи не нужны
+	require.NoError(t, strg.ApplyRecord(records.Record{ID: "x1", Version: 2, Deleted: true}))
+	require.Equal(t, []Password{{ID: "p1", Name: "mail"}}, strg.Passwords)
+}
+
+// failingReader источник случайных чисел, который всегда возвращает ошибку.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
+
+func TestNewRecordID(t *testing.T) {
+	first, second := newRecordID(), newRecordID()
+	require.Len(t, first, 32)
+	require.NotEqual(t, first, second)
+
+	// Ошибка источника случайных чисел не приводит к нулевому идентификатору, общему для всех записей
+	reader := rand.Reader
+	rand.Reader = failingReader{}
+	defer func() { rand.Reader = reader }()
+	require.Panics(t, func() { newRecordID() })
+}
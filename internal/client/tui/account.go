@@ -0,0 +1,304 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gophkeeper/internal/client/sender"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// deleteAccountConfirmation слово, которое пользователь вводит для подтверждения удаления учетной записи
+const deleteAccountConfirmation = "УДАЛИТЬ"
+
+// accountMenu метод открывает меню учетной записи: смена пароля, история версий, сессии,
+// двухфакторная аутентификация и удаление учетной записи.
+func (ui *UI) accountMenu() {
+	if ui.offline {
+		ui.message.set("Меню учетной записи недоступно без соединения с сервером. Ctrl+Y - подключиться к серверу")
+		return
+	}
+	ui.push(&chooser{
+		title: "Учетная запись " + ui.login,
+		options: []option{
+			{key: 'U', label: "изменить пароль", action: ui.passwordForm},
+			{key: 'H', label: "история версий и восстановление данных", action: ui.historyMenu},
+			{key: 'A', label: "активные сессии", action: ui.sessionsMenu},
+			{key: 'T', label: "двухфакторная аутентификация", action: ui.totpMenu},
+			{key: 'X', label: "удалить учетную запись и все данные на сервере", action: ui.deleteAccount},
+		},
+	})
+}
+
+// passwordForm метод открывает форму смены пароля пользователя.
+func (ui *UI) passwordForm() {
+	ui.push(&form{
+		title: "Смена пароля",
+		inputs: []*input{newInput("Текущий пароль", "", true), newInput("Новый пароль", "", true),
+			newInput("Повторите новый пароль", "", true)},
+		submit: func(values []string) string {
+			if values[0] == "" || values[1] == "" {
+				return "Пароль не может быть пустым"
+			}
+			if values[1] != values[2] {
+				return "Новый пароль и его повторение не совпадают"
+			}
+			ui.busy("Смена пароля")
+			changed, err := ui.sndr.ChangePassword(values[0], values[1])
+			switch status.Code(err) {
+			case codes.PermissionDenied:
+				return "Неверный пароль"
+			case codes.ResourceExhausted:
+				return "Слишком много неудачных попыток ввода пароля. Смена пароля временно заблокирована, попробуйте позже"
+			}
+			if err != nil {
+				ui.requestFailed(err, "ChangePassword", "Произошла ошибка при попытке изменения пароля")
+				return ""
+			}
+			if !changed {
+				ui.message.set("Не удалось изменить пароль")
+				return ""
+			}
+			ui.message.set("Пароль успешно изменен")
+			return ""
+		},
+	})
+}
+
+// historyMenu метод открывает список сохраненных предыдущих версий данных для восстановления.
+func (ui *UI) historyMenu() {
+	ui.busy("Получение истории версий")
+	history, err := ui.sndr.ListHistory()
+	if err != nil {
+		ui.requestFailed(err, "ListHistory", "Произошла ошибка в процессе получения истории версий")
+		return
+	}
+	if len(history) == 0 {
+		ui.message.set("На сервере нет сохраненных предыдущих версий данных")
+		return
+	}
+	options := make([]option, len(history))
+	for i, version := range history {
+		version := version
+		options[i] = option{
+			label: fmt.Sprintf("версия %d, сохранена %s", version.Version, version.TimeStamp),
+			action: func() {
+				ui.confirmUnsaved(fmt.Sprintf("Восстановить версию %d?", version.Version), func() { ui.restoreVersion(version) })
+			},
+		}
+	}
+	ui.push(&chooser{
+		title:   "История версий",
+		lines:   []string{"Текущие данные будут сохранены в истории, восстановление можно отменить, восстановив их версию"},
+		options: options,
+	})
+}
+
+// restoreVersion метод восстанавливает выбранную версию данных и загружает ее с сервера.
+func (ui *UI) restoreVersion(version sender.HistoryVersion) {
+	ui.busy("Восстановление данных")
+	err := ui.sndr.RestoreVersion(version.Version)
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		ui.message.set("Данные на сервере заблокированы на изменение другим пользователем")
+		return
+	case codes.FailedPrecondition:
+		ui.message.set("Версии данных на сервере и клиенте не совпадают. Скачайте актуальные данные и повторите восстановление")
+		return
+	}
+	if err != nil {
+		ui.requestFailed(err, "RestoreVersion", "Произошла ошибка в процессе восстановления данных")
+		return
+	}
+	ui.saved()
+	ui.pending = false
+	ui.message.set(fmt.Sprintf("Версия %d восстановлена, текущие данные сохранены в истории", version.Version))
+}
+
+// sessionsMenu метод открывает список активных сессий пользователя для завершения сессий на других устройствах.
+func (ui *UI) sessionsMenu() {
+	ui.busy("Получение списка сессий")
+	sessions, err := ui.sndr.ListSessions()
+	if err != nil {
+		ui.requestFailed(err, "ListSessions", "Произошла ошибка в процессе получения списка сессий")
+		return
+	}
+	var lines []string
+	options := make([]option, 0, len(sessions))
+	for _, session := range sessions {
+		session := session
+		description := fmt.Sprintf("%s, создана %s, последний запрос %s, версия клиента %s, адрес %s",
+			session.DeviceName, session.Created, session.LastSeen, session.ClientVersion, session.RemoteAddr)
+		if session.Current {
+			lines = append(lines, "Текущая сессия: "+description, "Текущая сессия завершается командой L основного экрана")
+			continue
+		}
+		options = append(options, option{label: description, action: func() { ui.revokeSession(session) }})
+	}
+	if len(options) == 0 {
+		ui.message.set("Других активных сессий нет")
+		return
+	}
+	ui.push(&chooser{title: "Завершение сессии на другом устройстве", lines: lines, options: options})
+}
+
+// revokeSession метод завершает выбранную сессию и снова открывает список сессий.
+func (ui *UI) revokeSession(session sender.SessionInfo) {
+	ui.busy("Завершение сессии")
+	err := ui.sndr.RevokeSession(session.SessionID)
+	if status.Code(err) == codes.NotFound {
+		ui.message.set("Сессия уже завершена")
+		ui.sessionsMenu()
+		return
+	}
+	if err != nil {
+		ui.requestFailed(err, "RevokeSession", "Произошла ошибка в процессе завершения сессии")
+		return
+	}
+	ui.message.set(fmt.Sprintf("Сессия на устройстве %s завершена", session.DeviceName))
+	ui.sessionsMenu()
+}
+
+// totpMenu метод открывает меню включения и отключения двухфакторной аутентификации.
+func (ui *UI) totpMenu() {
+	ui.push(&chooser{
+		title: "Двухфакторная аутентификация",
+		options: []option{
+			{key: 'E', label: "включить двухфакторную аутентификацию", action: ui.enableTOTP},
+			{key: 'D', label: "отключить двухфакторную аутентификацию", action: ui.disableTOTPForm},
+		},
+	})
+}
+
+// enableTOTP метод запрашивает секрет двухфакторной аутентификации и выводит его вместе с кодами восстановления.
+func (ui *UI) enableTOTP() {
+	ui.busy("Получение секрета двухфакторной аутентификации")
+	setup, err := ui.sndr.EnableTOTP()
+	if errors.Is(err, gkerrors.ErrTOTPEnabled) {
+		ui.message.set("Двухфакторная аутентификация уже включена")
+		return
+	}
+	if err != nil {
+		ui.requestFailed(err, "EnableTOTP", "Произошла ошибка при получении секрета двухфакторной аутентификации")
+		return
+	}
+	lines := []string{"Добавьте секрет в приложение-аутентификатор по ссылке или введите его вручную",
+		"Ссылка: " + setup.URI, "Секрет: " + setup.Secret,
+		"Сохраните коды восстановления, каждый из них можно использовать для входа один раз вместо кода из приложения:"}
+	lines = append(lines, setup.RecoveryCodes...)
+	ui.push(&chooser{
+		title:   "Включение двухфакторной аутентификации",
+		lines:   lines,
+		options: []option{{label: "ввести код подтверждения", action: ui.confirmTOTPForm}},
+		cancel: func() {
+			ui.message.set("Двухфакторная аутентификация не включена")
+		},
+	})
+}
+
+// confirmTOTPForm метод открывает форму подтверждения секрета кодом из приложения-аутентификатора.
+func (ui *UI) confirmTOTPForm() {
+	ui.push(&form{
+		title:  "Включение двухфакторной аутентификации",
+		inputs: []*input{newInput("Код из приложения", "", false)},
+		submit: func(values []string) string {
+			if values[0] == "" {
+				return "Код не может быть пустым"
+			}
+			ui.busy("Включение двухфакторной аутентификации")
+			err := ui.sndr.ConfirmTOTP(values[0])
+			if errors.Is(err, gkerrors.ErrTOTPCodeIncorrect) {
+				return "Неверный код, проверьте время на устройстве и попробуйте еще раз"
+			}
+			if status.Code(err) == codes.ResourceExhausted {
+				return "Слишком много неудачных попыток ввода кода. Включение временно заблокировано, попробуйте позже"
+			}
+			if err != nil {
+				ui.requestFailed(err, "ConfirmTOTP", "Произошла ошибка при включении двухфакторной аутентификации")
+				return ""
+			}
+			ui.message.set("Двухфакторная аутентификация включена")
+			return ""
+		},
+		cancel: func() {
+			ui.message.set("Двухфакторная аутентификация не включена")
+		},
+	})
+}
+
+// disableTOTPForm метод открывает форму отключения двухфакторной аутентификации.
+func (ui *UI) disableTOTPForm() {
+	ui.push(&form{
+		title:  "Отключение двухфакторной аутентификации",
+		inputs: []*input{newInput("Код из приложения или код восстановления", "", false)},
+		submit: func(values []string) string {
+			if values[0] == "" {
+				return "Код не может быть пустым"
+			}
+			ui.busy("Отключение двухфакторной аутентификации")
+			err := ui.sndr.DisableTOTP(values[0])
+			switch status.Code(err) {
+			case codes.PermissionDenied:
+				return "Неверный код подтверждения"
+			case codes.ResourceExhausted:
+				return "Слишком много неудачных попыток ввода кода. Отключение временно заблокировано, попробуйте позже"
+			}
+			if errors.Is(err, gkerrors.ErrTOTPNotEnabled) {
+				ui.message.set("Двухфакторная аутентификация не включена")
+				return ""
+			}
+			if err != nil {
+				ui.requestFailed(err, "DisableTOTP", "Произошла ошибка при отключении двухфакторной аутентификации")
+				return ""
+			}
+			ui.message.set("Двухфакторная аутентификация отключена")
+			return ""
+		},
+	})
+}
+
+// deleteAccount метод открывает форму удаления учетной записи со всеми данными.
+// Удаление подтверждается вводом слова deleteAccountConfirmation и текущего пароля.
+func (ui *UI) deleteAccount() {
+	ui.push(&form{
+		title: "Удаление учетной записи",
+		inputs: []*input{newInput("Для подтверждения введите "+deleteAccountConfirmation, "", false),
+			newInput("Текущий пароль", "", true)},
+		submit: func(values []string) string {
+			if values[0] != deleteAccountConfirmation {
+				return "Для подтверждения введите " + deleteAccountConfirmation + ", для отмены нажмите Esc"
+			}
+			if values[1] == "" {
+				return "Пароль не может быть пустым"
+			}
+			const deleting = "Удаление учетной записи"
+			ui.busy(deleting)
+			err := ui.sndr.DeleteAccount(values[1])
+			switch status.Code(err) {
+			case codes.PermissionDenied:
+				return "Неверный пароль"
+			case codes.ResourceExhausted:
+				return "Слишком много неудачных попыток ввода пароля. Удаление временно заблокировано, попробуйте позже"
+			}
+			if err != nil {
+				ui.requestFailed(err, "DeleteAccount", "Произошла ошибка при удалении учетной записи")
+				return ""
+			}
+			// Клиент gRPC сообщает, если файлы вложений остались на сервере, это сообщение не должно потеряться
+			deleted := ui.message.get()
+			if deleted == deleting {
+				deleted = "Учетная запись и все данные удалены"
+			}
+			ui.stopLockRenewal()
+			ui.stopWatching()
+			ui.login = ""
+			ui.items = nil
+			ui.connect()
+			ui.message.set(deleted + ". " + ui.message.get())
+			return ""
+		},
+	})
+}
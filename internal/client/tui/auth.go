@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// connect метод запрашивает сессию на сервере и открывает окно выбора входа или регистрации.
+func (ui *UI) connect() {
+	ui.busy("Устанавливаю соединение с сервером")
+	err := ui.sndr.ReqSessionID()
+	if err != nil {
+		log.Error().Err(err).Msg("Get sessionID error")
+		ui.message.set("Соединение с сервером не установлено")
+		ui.push(&chooser{
+			title: "Нет соединения",
+			lines: []string{"Соединение с сервером не установлено. Без соединения можно работать с локальной копией данных, изменения будут отправлены на сервер при синхронизации после входа"},
+			options: []option{
+				{key: 'R', label: "попробовать еще раз", action: ui.connect},
+				{key: 'O', label: "открыть локальную копию данных", action: ui.offlineForm},
+				{key: 'Q', label: "завершить работу", action: ui.exit},
+			},
+			cancel: ui.exit,
+		})
+		return
+	}
+	ui.message.set("Соединение с сервером установлено")
+	ui.showAuth()
+}
+
+// showAuth метод открывает окно выбора входа или регистрации пользователя.
+func (ui *UI) showAuth() {
+	ui.push(&chooser{
+		title: "GophKeeper",
+		options: []option{
+			{key: 'L', label: "вход существующего пользователя", action: ui.loginForm},
+			{key: 'R', label: "регистрация нового пользователя", action: ui.registerForm},
+			{key: 'Q', label: "завершить работу", action: ui.exit},
+		},
+		cancel: ui.exit,
+	})
+}
+
+// loginForm метод открывает форму авторизации существующего пользователя.
+func (ui *UI) loginForm() {
+	ui.push(&form{
+		title:  "Вход",
+		inputs: []*input{newInput("Имя пользователя", "", false), newInput("Пароль", "", true)},
+		submit: func(values []string) string {
+			if problem := checkCredentials(values[0], values[1]); problem != "" {
+				return problem
+			}
+			ui.busy("Выполняется вход")
+			err := ui.sndr.UserLogin(values[0], values[1])
+			if errors.Is(err, gkerrors.ErrTOTPRequired) {
+				ui.totpForm(values[0], values[1])
+				return ""
+			}
+			return ui.loginResult(values[0], err)
+		},
+		cancel: ui.showAuth,
+	})
+}
+
+// totpForm метод открывает форму ввода кода двухфакторной аутентификации.
+func (ui *UI) totpForm(login, pass string) {
+	ui.push(&form{
+		title:  "Двухфакторная аутентификация",
+		inputs: []*input{newInput("Код из приложения или код восстановления", "", false)},
+		submit: func(values []string) string {
+			if values[0] == "" {
+				return "Код не может быть пустым"
+			}
+			ui.busy("Выполняется вход")
+			return ui.loginResult(login, ui.sndr.UserLoginTOTP(login, pass, values[0]))
+		},
+		cancel: ui.showAuth,
+	})
+}
+
+// loginResult метод обрабатывает результат авторизации. Возвращает сообщение пользователю, если вход не выполнен.
+func (ui *UI) loginResult(login string, err error) string {
+	switch status.Code(err) {
+	case codes.OK:
+		if err == nil {
+			ui.loggedIn(login)
+			return ""
+		}
+	case codes.PermissionDenied:
+		return "Неверный логин, пароль или код подтверждения"
+	case codes.ResourceExhausted:
+		return "Слишком много неудачных попыток входа. Вход временно заблокирован, попробуйте позже"
+	case codes.Unauthenticated:
+		log.Info().Err(err).Msg("UserLogin sign error")
+		return ui.restartSession()
+	}
+	log.Error().Err(err).Msg("UserLogin error")
+	return "Неизвестная ошибка при авторизации пользователя"
+}
+
+// registerForm метод открывает форму регистрации нового пользователя.
+func (ui *UI) registerForm() {
+	ui.push(&form{
+		title: "Регистрация",
+		inputs: []*input{newInput("Имя пользователя", "", false), newInput("Пароль", "", true),
+			newInput("Повторите пароль", "", true)},
+		submit: func(values []string) string {
+			if problem := checkCredentials(values[0], values[1]); problem != "" {
+				return problem
+			}
+			if values[1] != values[2] {
+				return "Пароль и его повторение не совпадают"
+			}
+			ui.busy("Выполняется регистрация")
+			err := ui.sndr.RegisterUser(values[0], values[1])
+			switch status.Code(err) {
+			case codes.OK:
+				if err == nil {
+					ui.loggedIn(values[0])
+					return ""
+				}
+			case codes.AlreadyExists:
+				log.Info().Err(err).Msg("RegisterUser  error")
+				return "Такой логин уже занят"
+			case codes.Unauthenticated:
+				log.Info().Err(err).Msg("RegisterUser sign error")
+				return ui.restartSession()
+			}
+			log.Error().Err(err).Msg("RegisterUser error")
+			return "Неизвестная ошибка при регистрации нового пользователя"
+		},
+		cancel: ui.showAuth,
+	})
+}
+
+// offlineForm метод открывает форму расшифровки локальной копии данных пользователя без соединения с сервером.
+func (ui *UI) offlineForm() {
+	ui.push(&form{
+		title:  "Локальная копия данных",
+		inputs: []*input{newInput("Имя пользователя", "", false), newInput("Пароль", "", true)},
+		submit: func(values []string) string {
+			if problem := checkCredentials(values[0], values[1]); problem != "" {
+				return problem
+			}
+			ui.busy("Расшифровка локальной копии данных")
+			err := ui.sndr.UnlockOffline(values[0], values[1])
+			switch {
+			case errors.Is(err, gkerrors.ErrNoCache):
+				return "Локальная копия данных пользователя не найдена"
+			case errors.Is(err, gkerrors.ErrWrongPassword):
+				return "Неверный пароль"
+			case err != nil:
+				log.Error().Err(err).Msg("UnlockOffline error")
+				return "Ошибка чтения локальной копии данных"
+			}
+			ui.showBrowser(values[0])
+			ui.offline = true
+			ui.message.set("Работа без соединения с сервером, изменения сохраняются в локальной копии данных. Ctrl+Y - подключиться к серверу")
+			return ""
+		},
+		cancel: ui.connect,
+	})
+}
+
+// reconnect метод восстанавливает соединение с сервером при работе с локальной копией данных.
+// После входа изменения из локальной копии отправляются на сервер синхронизацией.
+func (ui *UI) reconnect() {
+	ui.busy("Устанавливаю соединение с сервером")
+	err := ui.sndr.ReqSessionID()
+	if err != nil {
+		log.Error().Err(err).Msg("Get sessionID error")
+		ui.message.set("Соединение с сервером не установлено, изменения сохраняются в локальной копии данных")
+		return
+	}
+	ui.sndr.CloseOffline()
+	ui.offline = false
+	ui.login, ui.items = "", nil
+	ui.message.set("Соединение с сервером установлено. Войдите, чтобы отправить изменения на сервер")
+	ui.showAuth()
+}
+
+// restartSession метод перезапускает сессию после ошибки проверки подписи. Возвращает сообщение пользователю.
+func (ui *UI) restartSession() string {
+	err := ui.sndr.RefreshToken()
+	if err != nil {
+		log.Error().Err(err).Msg("RefreshToken error")
+		return "Ошибка обновления сессии"
+	}
+	err = ui.sndr.ReqSessionID()
+	if err != nil {
+		log.Error().Err(err).Msg("Get sessionID error")
+		return "Соединение с сервером не установлено, попробуйте еще раз"
+	}
+	return "Ошибка проверки подписи, сессия перезапущена. Попробуйте еще раз"
+}
+
+// checkCredentials функция проверяет имя пользователя и пароль перед отправкой на сервер.
+func checkCredentials(login, pass string) string {
+	if login == "" {
+		return "Имя пользователя не может быть пустым"
+	}
+	if strings.ContainsAny(login, ",;") {
+		return "Имя пользователя не должно содержать символов \",\", \";\""
+	}
+	if pass == "" {
+		return "Пароль не может быть пустым"
+	}
+	return ""
+}
+
+// loggedIn метод открывает основной экран после авторизации и загружает записи пользователя с сервера.
+// Изменения, выполненные без соединения с сервером, отправляются на сервер этой же синхронизацией.
+// После загрузки клиент подписывается на уведомления об изменениях данных другими сессиями.
+func (ui *UI) loggedIn(login string) {
+	ui.showBrowser(login)
+	ui.syncData()
+	ui.startWatch()
+}
+
+// showBrowser метод открывает основной экран со списком записей пользователя.
+func (ui *UI) showBrowser(login string) {
+	ui.login = login
+	ui.overlays = nil
+	ui.selected, ui.offset = 0, 0
+	ui.query, ui.searching, ui.reveal = nil, false, false
+	ui.lastSync = time.Time{}
+}
+
+// logout метод завершает сессию пользователя. При работе без соединения закрывается локальная копия данных.
+func (ui *UI) logout() {
+	if ui.offline {
+		ui.sndr.CloseOffline()
+		ui.offline = false
+	} else {
+		ui.stopLockRenewal()
+		ui.stopWatching()
+		ui.sndr.UserLogOut()
+	}
+	ui.login = ""
+	ui.items = nil
+}
@@ -0,0 +1,597 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// browserHints подсказка по клавишам основного экрана.
+const browserHints = "/ поиск  Enter изменить  N новая  D удалить  F файл  F3 пароль  ^Y синхр.  ^S сохранить  ^L блокировка  ^D скачать  F5 статус  K корзина  A аккаунт  L выход  Q завершить"
+
+// item структура записи в списке основного экрана.
+type item struct {
+	kind  *recordKind
+	index int //Номер записи в разделе хранилища
+	id    string
+	name  string
+}
+
+// refresh метод обновляет список записей с учетом строки поиска. Выбранная запись сохраняется, если она осталась в списке.
+func (ui *UI) refresh() {
+	var selectedID string
+	if ui.selected < len(ui.items) {
+		selectedID = ui.items[ui.selected].id
+	}
+	query := strings.ToLower(string(ui.query))
+	strg := ui.sndr.Strg
+	ui.items = ui.items[:0]
+	for _, kind := range kinds {
+		for i := 0; i < kind.count(strg); i++ {
+			id, name := kind.name(strg, i)
+			if query != "" && !strings.Contains(strings.ToLower(name), query) {
+				continue
+			}
+			ui.items = append(ui.items, item{kind: kind, index: i, id: id, name: name})
+		}
+	}
+	sort.SliceStable(ui.items, func(i, j int) bool {
+		return strings.ToLower(ui.items[i].name) < strings.ToLower(ui.items[j].name)
+	})
+	for i, it := range ui.items {
+		if it.id == selectedID {
+			ui.selected = i
+		}
+	}
+	if ui.selected >= len(ui.items) {
+		ui.selected = len(ui.items) - 1
+	}
+	if ui.selected < 0 {
+		ui.selected = 0
+	}
+}
+
+// current метод возвращает выбранную запись, или false, если список пуст.
+func (ui *UI) current() (item, bool) {
+	if ui.selected < len(ui.items) {
+		return ui.items[ui.selected], true
+	}
+	return item{}, false
+}
+
+// drawBrowser метод выводит основной экран: список записей, подробности выбранной записи и строку состояния.
+func (ui *UI) drawBrowser() {
+	s := ui.screen
+	w, h := s.Size()
+	ui.refresh()
+
+	fillLine(s, 0, 0, w, styleBar)
+	drawText(s, 1, 0, w-2, styleBar, "GophKeeper: "+ui.login)
+	search := "/ - поиск"
+	if ui.searching || len(ui.query) > 0 {
+		search = "Поиск: " + string(ui.query)
+	}
+	searchX := w / 3
+	drawText(s, searchX, 0, w-searchX-1, styleBar, search)
+	if ui.searching {
+		s.ShowCursor(searchX+len([]rune(search)), 0)
+	}
+
+	listW := w / 3
+	if listW < 20 {
+		listW = 20
+	}
+	top, listH := 1, h-4
+	if ui.selected < ui.offset {
+		ui.offset = ui.selected
+	}
+	if listH > 0 && ui.selected >= ui.offset+listH {
+		ui.offset = ui.selected - listH + 1
+	}
+	if len(ui.items) == 0 {
+		empty := "Записей нет, N - добавить"
+		if len(ui.query) > 0 {
+			empty = "Ничего не найдено"
+		}
+		drawText(s, 1, top, listW-1, styleHint, empty)
+	}
+	for row := 0; row < listH && ui.offset+row < len(ui.items); row++ {
+		it := ui.items[ui.offset+row]
+		style := styleDefault
+		if ui.offset+row == ui.selected {
+			style = styleSelected
+			fillLine(s, 0, top+row, listW, style)
+		}
+		drawText(s, 1, top+row, listW-2, style, fmt.Sprintf("%-7s %s", it.kind.title, it.name))
+	}
+	for row := top; row < top+listH; row++ {
+		s.SetContent(listW, row, tcell.RuneVLine, nil, styleDefault)
+	}
+	ui.drawDetails(listW+2, top, w-listW-3, listH)
+
+	fillLine(s, 0, h-3, w, styleBar)
+	drawText(s, 1, h-3, w-2, styleBar, ui.statusLine())
+	drawText(s, 1, h-2, w-2, styleDefault, ui.message.get())
+	drawText(s, 1, h-1, w-2, styleHint, browserHints)
+}
+
+// drawDetails метод выводит подробности выбранной записи.
+func (ui *UI) drawDetails(x, y, w, h int) {
+	it, ok := ui.current()
+	if !ok || w < 1 {
+		return
+	}
+	drawText(ui.screen, x, y, w, styleTitle, it.kind.title+": "+it.name)
+	row := y + 2
+	for _, f := range it.kind.details(ui.sndr.Strg, it.index, ui.reveal) {
+		drawText(ui.screen, x, row, w, styleHint, f.label)
+		row++
+		for _, line := range wrapText(f.value, w-2) {
+			if row >= y+h {
+				return
+			}
+			drawText(ui.screen, x+2, row, w-2, styleDefault, line)
+			row++
+		}
+	}
+	if row+1 < y+h {
+		drawText(ui.screen, x, row+1, w, styleHint, "ID: "+it.id)
+	}
+}
+
+// statusLine метод формирует строку состояния блокировки и синхронизации данных.
+func (ui *UI) statusLine() string {
+	strg := ui.sndr.Strg
+	lock := "нет"
+	if locked, timeLocked := ui.sndr.LockState(); locked {
+		lock = "до " + timeLocked.Local().Format("15:04:05")
+	}
+	synced := "не выполнялась"
+	if !ui.lastSync.IsZero() {
+		synced = ui.lastSync.Format("15:04:05")
+	}
+	if ui.pending {
+		synced = "на сервере есть новая версия"
+	}
+	if ui.offline {
+		return fmt.Sprintf("Нет соединения с сервером │ Несинхронизированных изменений: %d │ Ревизия: %d │ Версия: %d",
+			strg.ChangedCount(), strg.Revision, strg.Version)
+	}
+	return fmt.Sprintf("Блокировка: %s │ Несинхронизированных изменений: %d │ Синхронизация: %s │ Ревизия: %d │ Версия: %d",
+		lock, strg.ChangedCount(), synced, strg.Revision, strg.Version)
+}
+
+// handleBrowserKey метод обрабатывает нажатие клавиши на основном экране.
+// Без соединения с сервером клавиши запросов к серверу восстанавливают соединение.
+func (ui *UI) handleBrowserKey(ev *tcell.EventKey) {
+	if ui.offline {
+		switch ev.Key() {
+		case tcell.KeyCtrlY, tcell.KeyCtrlS, tcell.KeyCtrlL, tcell.KeyCtrlD, tcell.KeyF5:
+			ui.reconnect()
+			return
+		}
+	}
+	switch ev.Key() {
+	case tcell.KeyUp:
+		ui.move(-1)
+		return
+	case tcell.KeyDown:
+		ui.move(1)
+		return
+	case tcell.KeyPgUp:
+		ui.move(-10)
+		return
+	case tcell.KeyPgDn:
+		ui.move(10)
+		return
+	case tcell.KeyEscape:
+		ui.query, ui.searching = nil, false
+		return
+	case tcell.KeyCtrlY:
+		ui.syncData()
+		return
+	case tcell.KeyCtrlS:
+		ui.saveData()
+		return
+	case tcell.KeyCtrlL:
+		ui.toggleLock()
+		return
+	case tcell.KeyCtrlD:
+		ui.download()
+		return
+	case tcell.KeyF3:
+		ui.reveal = !ui.reveal
+		return
+	case tcell.KeyF5:
+		ui.checkStatus()
+		return
+	}
+	if ui.searching {
+		ui.handleSearchKey(ev)
+		return
+	}
+	switch ev.Key() {
+	case tcell.KeyHome:
+		ui.selected = 0
+	case tcell.KeyEnd:
+		ui.selected = len(ui.items) - 1
+	case tcell.KeyEnter:
+		ui.editRecord()
+	case tcell.KeyDelete:
+		ui.deleteRecord()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case '/':
+			ui.searching = true
+		case 'e', 'E':
+			ui.editRecord()
+		case 'n', 'N':
+			ui.newRecord()
+		case 'd', 'D':
+			ui.deleteRecord()
+		case 'f', 'F':
+			ui.saveFile()
+		case 'k', 'K':
+			ui.trash()
+		case 'a', 'A':
+			ui.accountMenu()
+		case 'l', 'L':
+			ui.confirmUnsaved("Завершить сессию?", func() {
+				ui.logout()
+				ui.connect()
+			})
+		case 'q', 'Q':
+			ui.confirmUnsaved("Завершить работу?", ui.exit)
+		}
+	}
+}
+
+// handleSearchKey метод изменяет строку поиска.
+func (ui *UI) handleSearchKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		ui.searching = false
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(ui.query) > 0 {
+			ui.query = ui.query[:len(ui.query)-1]
+		}
+	case tcell.KeyRune:
+		ui.query = append(ui.query, ev.Rune())
+		ui.selected = 0
+	}
+}
+
+// move метод перемещает выбор записи в списке.
+func (ui *UI) move(delta int) {
+	ui.selected += delta
+	if ui.selected >= len(ui.items) {
+		ui.selected = len(ui.items) - 1
+	}
+	if ui.selected < 0 {
+		ui.selected = 0
+	}
+}
+
+// confirmUnsaved метод запрашивает подтверждение действия, если есть несинхронизированные изменения.
+// Без соединения с сервером изменения остаются в локальной копии данных, подтверждение не требуется.
+func (ui *UI) confirmUnsaved(question string, action func()) {
+	changed := ui.sndr.Strg.ChangedCount()
+	if changed == 0 || ui.offline {
+		action()
+		return
+	}
+	ui.push(&confirm{question: fmt.Sprintf("Несинхронизированных изменений: %d, они будут потеряны. %s", changed, question), yes: action})
+}
+
+// recordForm метод открывает форму записи типа kind. При i < 0 форма создает новую запись, иначе изменяет запись i.
+func (ui *UI) recordForm(kind *recordKind, i int) {
+	values := make([]string, len(kind.inputs))
+	title := "Новая запись: " + kind.title
+	if i >= 0 {
+		values = kind.values(ui.sndr.Strg, i)
+		title = "Изменение записи: " + kind.title
+	}
+	inputs := make([]*input, len(kind.inputs))
+	for n, label := range kind.inputs {
+		inputs[n] = newInput(label, values[n], n == kind.masked)
+	}
+	ui.push(&form{title: title, inputs: inputs, submit: func(values []string) string {
+		problem := kind.save(ui.sndr, i, values)
+		if problem == "" {
+			ui.message.set("Данные сохранены локально. Ctrl+Y - синхронизировать с сервером")
+			ui.saveCache()
+		}
+		return problem
+	}})
+}
+
+// newRecord метод предлагает выбрать тип новой записи и открывает ее форму.
+func (ui *UI) newRecord() {
+	options := make([]option, len(kinds))
+	for n, kind := range kinds {
+		kind := kind
+		options[n] = option{key: kind.key, label: kind.title, action: func() { ui.recordForm(kind, -1) }}
+	}
+	ui.push(&chooser{title: "Тип новой записи", options: options})
+}
+
+// editRecord метод открывает форму изменения выбранной записи.
+func (ui *UI) editRecord() {
+	it, ok := ui.current()
+	if !ok {
+		return
+	}
+	ui.recordForm(it.kind, it.index)
+}
+
+// deleteRecord метод перемещает выбранную запись в корзину после подтверждения пользователя.
+func (ui *UI) deleteRecord() {
+	it, ok := ui.current()
+	if !ok {
+		return
+	}
+	ui.push(&confirm{question: fmt.Sprintf("Удалить запись \"%s\"?", it.name), yes: func() {
+		if !it.kind.remove(ui.sndr.Strg, it.index) {
+			ui.message.set("Запись не найдена")
+			return
+		}
+		ui.message.set("Запись перемещена в корзину. До сохранения данных на сервер ее можно восстановить")
+		ui.saveCache()
+	}})
+}
+
+// saveFile метод сохраняет содержимое выбранной записи двоичных данных в файл, указанный пользователем.
+// Содержимое вложения скачивается с сервера, поэтому без соединения сохраняются только данные, хранящиеся в записи.
+func (ui *UI) saveFile() {
+	it, ok := ui.current()
+	if !ok || it.kind.key != 'B' {
+		ui.message.set("Выберите запись двоичных данных")
+		return
+	}
+	binary := ui.sndr.Strg.Binaries[it.index]
+	if binary.Attachment != "" && ui.offline {
+		ui.message.set("Нет соединения с сервером: содержимое вложения хранится на сервере")
+		return
+	}
+	ui.push(&form{title: "Сохранение в файл", inputs: []*input{newInput("Путь к файлу", binary.Name, false)}, submit: func(values []string) string {
+		if values[0] == "" {
+			return "Укажите путь к файлу"
+		}
+		err := ui.sndr.SaveBinaryFile(binary, values[0])
+		switch {
+		case err == nil:
+			ui.message.set("Файл сохранен: " + values[0])
+			return ""
+		case errors.Is(err, gkerrors.ErrNoSuchAttachment):
+			return "Вложение не найдено на сервере"
+		case errors.Is(err, gkerrors.ErrAttachmentHash):
+			return "Содержимое вложения повреждено: хэш не совпадает"
+		case errors.Is(err, os.ErrNotExist), errors.Is(err, os.ErrPermission):
+			return "Не удалось создать файл"
+		}
+		ui.requestFailed(err, "SaveBinaryFile", "Произошла ошибка в процессе сохранения файла")
+		return ""
+	}})
+}
+
+// trash метод открывает корзину удаленных записей для восстановления записи или очистки корзины.
+func (ui *UI) trash() {
+	trash := ui.sndr.Strg.SliceUsersTrash()
+	if len(trash) == 0 {
+		ui.message.set("Корзина пуста")
+		return
+	}
+	options := make([]option, 0, len(trash)+1)
+	for n, val := range trash {
+		n := n
+		options = append(options, option{label: "восстановить " + val.Name, action: func() {
+			restored, err := ui.sndr.Strg.RestoreUsersTrash(n)
+			if err != nil {
+				log.Error().Err(err).Msg("RestoreUsersTrash error")
+				ui.message.set("Ошибка восстановления записи")
+				return
+			}
+			if restored {
+				ui.message.set("Запись восстановлена")
+				ui.saveCache()
+			}
+		}})
+	}
+	options = append(options, option{key: 'E', label: "очистить корзину", action: func() {
+		ui.push(&confirm{question: "Очистить корзину? Записи будут удалены на сервере при следующем сохранении", yes: func() {
+			ui.sndr.Strg.EmptyUsersTrash()
+			ui.message.set("Корзина очищена")
+		}})
+	}})
+	ui.push(&chooser{title: "Корзина", options: options})
+}
+
+// syncData метод синхронизирует измененные записи с сервером.
+func (ui *UI) syncData() {
+	ui.busy("Синхронизация данных с сервером")
+	conflicts, err := ui.sndr.Sync()
+	if status.Code(err) == codes.PermissionDenied {
+		ui.message.set("Данные на сервере заблокированы на изменение другим пользователем, изменения сохранены локально")
+		return
+	}
+	if errors.Is(err, gkerrors.ErrRecordTypeUnknown) {
+		ui.message.set("Данные на сервере содержат записи неизвестного типа, обновите клиент, изменения сохранены локально")
+		return
+	}
+	if err != nil {
+		ui.requestFailed(err, "Sync", "Произошла ошибка в процессе синхронизации данных")
+		return
+	}
+	ui.lastSync = time.Now()
+	ui.pending = false
+	if conflicts > 0 {
+		ui.message.set(fmt.Sprintf("Данные синхронизированы, обнаружено конфликтов: %d. Локальные версии сохранены с отметкой \"(конфликт)\"", conflicts))
+		return
+	}
+	ui.message.set("Данные успешно синхронизированы с сервером")
+}
+
+// saveData метод сохраняет все данные на сервере единым массивом. При расхождении версий выполняется слияние.
+func (ui *UI) saveData() {
+	ui.busy("Сохранение данных на сервер")
+	err := ui.sndr.SaveData()
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		ui.message.set("Данные на сервере заблокированы на изменение другим пользователем")
+		return
+	case codes.FailedPrecondition:
+		ui.message.set("Версии данных на сервере и клиенте не совпадают, выполняется слияние данных")
+		ui.mergeData()
+		return
+	}
+	if err != nil {
+		ui.requestFailed(err, "SaveData", "Произошла ошибка в процессе сохранения данных")
+		return
+	}
+	ui.saved()
+	ui.message.set("Данные успешно сохранены на сервере")
+}
+
+// saved метод отмечает сохранение данных. Сервер снимает блокировку данных после сохранения.
+func (ui *UI) saved() {
+	ui.lastSync = time.Now()
+	if locked, _ := ui.sndr.LockState(); !locked {
+		ui.stopLockRenewal()
+	}
+}
+
+// mergeData метод выполняет слияние локальных данных с данными сервера и предлагает пользователю решить конфликты.
+func (ui *UI) mergeData() {
+	res, err := ui.sndr.MergeServerData()
+	if err != nil {
+		ui.requestFailed(err, "MergeServerData", "Произошла ошибка в процессе получения данных для слияния")
+		return
+	}
+	ui.resolveConflict(res, 0)
+}
+
+// resolveConflict метод запрашивает решение конфликта i. После решения всех конфликтов объединенные данные сохраняются.
+func (ui *UI) resolveConflict(res *storage.MergeResult, i int) {
+	if i == len(res.Conflicts) {
+		ui.applyMerge(res)
+		return
+	}
+	conflict := &res.Conflicts[i]
+	title := fmt.Sprintf("Конфликт записи \"%s\"", conflict.Name)
+	if conflict.Field != "" {
+		title = fmt.Sprintf("Конфликт в поле %s записи \"%s\"", conflict.Field, conflict.Name)
+	}
+	ui.push(&chooser{
+		title: title,
+		lines: []string{"Локальное значение: " + conflict.Local, "Значение на сервере: " + conflict.Server},
+		options: []option{
+			{key: '1', label: "оставить локальное значение", action: func() {
+				conflict.UseServer = false
+				ui.resolveConflict(res, i+1)
+			}},
+			{key: '2', label: "принять значение с сервера", action: func() {
+				conflict.UseServer = true
+				ui.resolveConflict(res, i+1)
+			}},
+		},
+		cancel: func() { ui.message.set("Слияние данных отменено") },
+	})
+}
+
+// applyMerge метод применяет результат слияния и сохраняет объединенные данные на сервере.
+func (ui *UI) applyMerge(res *storage.MergeResult) {
+	err := ui.sndr.Strg.ApplyMerge(res)
+	if err != nil {
+		log.Error().Err(err).Msg("ApplyMerge error")
+		ui.message.set("Произошла ошибка в процессе слияния данных")
+		return
+	}
+	ui.busy("Сохранение объединенных данных на сервер")
+	err = ui.sndr.SaveData()
+	if err != nil {
+		ui.requestFailed(err, "SaveData after merge", "Произошла ошибка в процессе сохранения объединенных данных")
+		return
+	}
+	ui.saved()
+	ui.message.set("Данные объединены и успешно сохранены на сервере")
+}
+
+// download метод заменяет локальные данные данными с сервера после подтверждения, если есть несинхронизированные изменения.
+func (ui *UI) download() {
+	ui.confirmUnsaved("Скачать данные с сервера?", func() {
+		ui.busy("Получение данных с сервера")
+		err := ui.sndr.Download()
+		if errors.Is(err, gkerrors.ErrRecordTypeUnknown) {
+			ui.message.set("Данные на сервере содержат записи неизвестного типа, обновите клиент")
+			return
+		}
+		if err != nil {
+			ui.requestFailed(err, "Download", "Произошла ошибка в процессе получения данных")
+			return
+		}
+		ui.lastSync = time.Now()
+		ui.pending = false
+	})
+}
+
+// checkStatus метод запрашивает актуальный статус данных на сервере. Результат выводится сообщением клиента gRPC.
+func (ui *UI) checkStatus() {
+	ui.busy("Проверка статуса данных")
+	err := ui.sndr.CheckTimeStamp()
+	if err != nil {
+		ui.requestFailed(err, "CheckTimeStamp", "Произошла ошибка в процессе запроса")
+	}
+}
+
+// toggleLock метод блокирует данные на сервере с фоновым продлением блокировки или снимает блокировку текущей сессии.
+func (ui *UI) toggleLock() {
+	if locked, _ := ui.sndr.LockState(); locked {
+		ui.stopLockRenewal()
+		err := ui.sndr.ReleaseLock()
+		if err != nil {
+			ui.requestFailed(err, "ReleaseLock", "Ошибка снятия блокировки данных")
+			return
+		}
+		ui.message.set("Блокировка данных снята")
+		return
+	}
+	ui.busy("Блокировка данных на сервере")
+	err := ui.sndr.LockUserData()
+	if errors.Is(err, gkerrors.ErrLocked) {
+		ui.push(&confirm{
+			question: "Если блокировка осталась на другом вашем устройстве, ее можно перехватить. Несохраненные изменения на том устройстве не удастся сохранить без слияния. Перехватить блокировку?",
+			yes:      func() { ui.lockAcquired(ui.sndr.TakeOverLock()) },
+		})
+		return
+	}
+	ui.lockAcquired(err)
+}
+
+// lockAcquired метод запускает фоновое продление установленной блокировки данных.
+func (ui *UI) lockAcquired(err error) {
+	if err != nil {
+		ui.requestFailed(err, "DataLock", "Ошибка блокировки данных")
+		return
+	}
+	ui.stopLockRenewal()
+	ui.stopRenewal = ui.sndr.StartLockRenewal()
+}
+
+// stopLockRenewal метод останавливает фоновое продление блокировки данных.
+func (ui *UI) stopLockRenewal() {
+	if ui.stopRenewal != nil {
+		ui.stopRenewal()
+		ui.stopRenewal = nil
+	}
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gophkeeper/internal/client/crypto"
+	"gophkeeper/internal/client/sender"
+	"gophkeeper/internal/client/storage"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// field структура поля записи для вывода подробностей.
+type field struct {
+	label string
+	value string
+}
+
+// recordKind структура описывает тип записей пользователя: поля формы, подробности записи и работу с разделом хранилища.
+// Все типы записей обрабатываются интерфейсом одинаково через это описание.
+type recordKind struct {
+	title   string   //Название типа в списке и заголовках
+	key     rune     //Клавиша выбора типа при создании записи
+	inputs  []string //Подписи полей формы, первое поле - имя записи
+	masked  int      //Номер скрытого поля формы, -1 если скрытых полей нет
+	count   func(strg *storage.UserStorage) int
+	name    func(strg *storage.UserStorage, i int) (string, string)
+	values  func(strg *storage.UserStorage, i int) []string
+	details func(strg *storage.UserStorage, i int, reveal bool) []field
+	// save проверяет значения формы и добавляет запись при i < 0 или изменяет запись i. Непустой результат - сообщение пользователю.
+	save   func(sndr *sender.GophKeeperClient, i int, values []string) string
+	remove func(strg *storage.UserStorage, i int) bool
+}
+
+// kinds типы записей в порядке вывода.
+var kinds = []*recordKind{
+	{
+		title:  "Пароль",
+		key:    'P',
+		inputs: []string{"Имя записи", "Логин", "Пароль", "Примечание"},
+		masked: 2,
+		count:  func(strg *storage.UserStorage) int { return len(strg.Passwords) },
+		name: func(strg *storage.UserStorage, i int) (string, string) {
+			return strg.Passwords[i].ID, strg.Passwords[i].Name
+		},
+		values: func(strg *storage.UserStorage, i int) []string {
+			val := strg.Passwords[i]
+			return []string{val.Name, val.Login, val.Pass, val.Comment}
+		},
+		details: func(strg *storage.UserStorage, i int, reveal bool) []field {
+			val := strg.Passwords[i]
+			return []field{{"Логин", val.Login}, {"Пароль", hidden(val.Pass, reveal)}, {"Примечание", val.Comment}}
+		},
+		save: func(sndr *sender.GophKeeperClient, i int, values []string) string {
+			strg := sndr.Strg
+			if values[0] == "" {
+				return "Имя записи не может быть пустым"
+			}
+			pass := storage.Password{Name: values[0], Login: values[1], Pass: values[2], Comment: values[3]}
+			if i < 0 {
+				strg.AddUsersPassword(&pass)
+			} else {
+				strg.EditUsersPassword(i, &pass)
+			}
+			return ""
+		},
+		remove: (*storage.UserStorage).DeleteUsersPassword,
+	},
+	{
+		title:  "Карта",
+		key:    'C',
+		inputs: []string{"Имя записи", "Номер карты", "Примечание"},
+		masked: -1,
+		count:  func(strg *storage.UserStorage) int { return len(strg.Cards) },
+		name: func(strg *storage.UserStorage, i int) (string, string) {
+			return strg.Cards[i].ID, strg.Cards[i].Name
+		},
+		values: func(strg *storage.UserStorage, i int) []string {
+			val := strg.Cards[i]
+			return []string{val.Name, val.CardNumber, val.Comment}
+		},
+		details: func(strg *storage.UserStorage, i int, reveal bool) []field {
+			val := strg.Cards[i]
+			return []field{{"Номер карты", val.CardNumber}, {"Примечание", val.Comment}}
+		},
+		save: func(sndr *sender.GophKeeperClient, i int, values []string) string {
+			strg := sndr.Strg
+			if values[0] == "" {
+				return "Имя записи не может быть пустым"
+			}
+			if !crypto.LynnCheckOrder([]byte(values[1])) {
+				return "Номер карты содержит ошибку"
+			}
+			card := storage.Card{Name: values[0], CardNumber: values[1], Comment: values[2]}
+			if i < 0 {
+				strg.AddUsersCard(&card)
+			} else {
+				strg.EditUsersCard(i, &card)
+			}
+			return ""
+		},
+		remove: (*storage.UserStorage).DeleteUsersCard,
+	},
+	{
+		title:  "Текст",
+		key:    'T',
+		inputs: []string{"Имя записи", "Текст", "Примечание"},
+		masked: -1,
+		count:  func(strg *storage.UserStorage) int { return len(strg.Texts) },
+		name: func(strg *storage.UserStorage, i int) (string, string) {
+			return strg.Texts[i].ID, strg.Texts[i].Name
+		},
+		values: func(strg *storage.UserStorage, i int) []string {
+			val := strg.Texts[i]
+			return []string{val.Name, val.Data, val.Comment}
+		},
+		details: func(strg *storage.UserStorage, i int, reveal bool) []field {
+			val := strg.Texts[i]
+			return []field{{"Текст", val.Data}, {"Примечание", val.Comment}}
+		},
+		save: func(sndr *sender.GophKeeperClient, i int, values []string) string {
+			strg := sndr.Strg
+			if values[0] == "" {
+				return "Имя записи не может быть пустым"
+			}
+			text := storage.Text{Name: values[0], Data: values[1], Comment: values[2]}
+			if i < 0 {
+				strg.AddUsersText(&text)
+			} else {
+				strg.EditUsersText(i, &text)
+			}
+			return ""
+		},
+		remove: (*storage.UserStorage).DeleteUsersText,
+	},
+	{
+		title:  "Файл",
+		key:    'B',
+		inputs: []string{"Имя записи", "Путь к файлу", "Примечание"},
+		masked: -1,
+		count:  func(strg *storage.UserStorage) int { return len(strg.Binaries) },
+		name: func(strg *storage.UserStorage, i int) (string, string) {
+			return strg.Binaries[i].ID, strg.Binaries[i].Name
+		},
+		values: func(strg *storage.UserStorage, i int) []string {
+			val := strg.Binaries[i]
+			return []string{val.Name, "", val.Comment}
+		},
+		details: func(strg *storage.UserStorage, i int, reveal bool) []field {
+			val := strg.Binaries[i]
+			if val.Attachment != "" {
+				return []field{{"Размер", fmt.Sprintf("%d байт, хранится на сервере вложением", val.Size)},
+					{"SHA-256", val.Hash}, {"Примечание", val.Comment}}
+			}
+			return []field{{"Размер", fmt.Sprintf("%d байт", len(val.Data))}, {"Примечание", val.Comment}}
+		},
+		save: func(sndr *sender.GophKeeperClient, i int, values []string) string {
+			strg := sndr.Strg
+			binary := storage.Binary{Name: values[0], Comment: values[2]}
+			if i >= 0 {
+				old := strg.Binaries[i]
+				binary.Data, binary.Attachment, binary.Hash, binary.Size = old.Data, old.Attachment, old.Hash, old.Size
+			}
+			if values[1] == "" && i < 0 {
+				return "Укажите путь к файлу"
+			}
+			if values[1] != "" {
+				if problem := readFile(sndr, values[1], &binary); problem != "" {
+					return problem
+				}
+			}
+			if binary.Name == "" {
+				return "Имя записи не может быть пустым"
+			}
+			if i < 0 {
+				strg.AddUsersBinary(&binary)
+			} else {
+				strg.EditUsersBinary(i, &binary)
+			}
+			return ""
+		},
+		remove: (*storage.UserStorage).DeleteUsersBinary,
+	},
+}
+
+// hidden функция скрывает значение поля, если пользователь не запросил его показ.
+func hidden(value string, reveal bool) string {
+	if reveal || value == "" {
+		return value
+	}
+	return "******** (F3 - показать)"
+}
+
+// readFile функция считывает файл в запись двоичных данных, файл больше 64кБ загружается на сервер вложением.
+// Возвращает сообщение об ошибке.
+func readFile(sndr *sender.GophKeeperClient, path string, binary *storage.Binary) string {
+	err := sndr.ReadBinary(path, binary)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrInvalid):
+		return "Файл не найден"
+	case errors.Is(err, gkerrors.ErrQuotaExceeded):
+		return "Превышен допустимый объем вложений на сервере"
+	case status.Code(err) == codes.Unauthenticated:
+		return "Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться"
+	case status.Code(err) == codes.Unavailable:
+		return "Нет соединения с сервером: файлы больше 64кБ хранятся на сервере вложением"
+	}
+	log.Error().Err(err).Msg("ReadBinary error")
+	return "Ошибка чтения или загрузки файла"
+}
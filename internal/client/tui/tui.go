@@ -0,0 +1,226 @@
+// Модуль предназначен для полноэкранного терминального интерфейса клиента.
+// Интерфейс показывает список всех записей пользователя с поиском, подробности выбранной записи
+// и строку состояния блокировки и синхронизации данных. Записи добавляются и изменяются в формах.
+package tui
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gophkeeper/internal/client/sender"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// redrawInterval интервал обновления экрана для вывода состояния блокировки и сообщений фонового продления блокировки.
+// С этим же интервалом проверяется, можно ли загрузить более новые данные с сервера.
+const redrawInterval = time.Second
+
+// overlay интерфейс окна, открытого поверх основного экрана. Нажатия клавиш получает верхнее окно.
+type overlay interface {
+	draw(ui *UI)
+	handleKey(ui *UI, ev *tcell.EventKey)
+}
+
+// messages структура хранит последнее сообщение пользователю.
+// Реализует io.Writer для сообщений клиента gRPC, в том числе из горутины продления блокировки.
+type messages struct {
+	mu   sync.Mutex
+	text string
+}
+
+// Write метод сохраняет сообщение клиента gRPC.
+func (m *messages) Write(p []byte) (int, error) {
+	if text := strings.TrimSpace(string(p)); text != "" {
+		m.set(text)
+	}
+	return len(p), nil
+}
+
+// set метод заменяет последнее сообщение.
+func (m *messages) set(text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.text = text
+}
+
+// get метод возвращает последнее сообщение.
+func (m *messages) get() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.text
+}
+
+// UI структура полноэкранного интерфейса клиента.
+type UI struct {
+	screen      tcell.Screen
+	sndr        *sender.GophKeeperClient
+	overlays    []overlay
+	message     *messages
+	login       string    //Логин авторизованного пользователя, пустой до авторизации
+	items       []item    //Записи, отображаемые в списке с учетом строки поиска
+	selected    int       //Номер выбранной записи в списке
+	offset      int       //Номер первой видимой записи списка
+	query       []rune    //Строка поиска
+	searching   bool      //Пользователь вводит строку поиска
+	reveal      bool      //Показывать пароль выбранной записи
+	lastSync    time.Time //Время последней синхронизации или сохранения данных
+	stopRenewal func()    //Остановка фонового продления блокировки данных
+	offline     bool      //Открыта локальная копия данных без соединения с сервером
+	stopWatch   func()    //Отмена подписки на уведомления об изменениях данных другими сессиями
+	pending     bool      //На сервере есть более новая версия данных, еще не загруженная клиентом
+	quit        bool
+}
+
+// New функция открывает терминал в полноэкранном режиме и создает интерфейс клиента.
+// Если стандартный ввод или вывод не является терминалом, возвращается ошибка gkerrors.ErrNoTerminal.
+func New(sndr *sender.GophKeeperClient) (*UI, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, gkerrors.ErrNoTerminal
+	}
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	err = screen.Init()
+	if err != nil {
+		return nil, err
+	}
+	return newUI(screen, sndr), nil
+}
+
+// newUI функция создает интерфейс клиента на инициализированном экране.
+func newUI(screen tcell.Screen, sndr *sender.GophKeeperClient) *UI {
+	return &UI{screen: screen, sndr: sndr, message: &messages{}}
+}
+
+// Run метод выполняет интерфейс до завершения работы пользователем и восстанавливает терминал.
+func (ui *UI) Run() {
+	out := ui.sndr.Out
+	ui.sndr.Out = ui.message
+	stop := make(chan struct{})
+	defer func() {
+		close(stop)
+		ui.sndr.Out = out
+		ui.screen.Fini()
+	}()
+	go ui.tick(stop)
+
+	ui.connect()
+	for !ui.quit {
+		ui.draw()
+		switch ev := ui.screen.PollEvent().(type) {
+		case nil:
+			return
+		case *tcell.EventResize:
+			ui.screen.Sync()
+		case *tcell.EventKey:
+			ui.handleKey(ev)
+		case *tcell.EventInterrupt:
+			if event, ok := ev.Data().(sender.ChangeEvent); ok {
+				ui.handleChange(event)
+			} else {
+				ui.autoSync()
+			}
+		}
+	}
+	if ui.login != "" {
+		ui.logout()
+	}
+}
+
+// tick метод периодически запрашивает перерисовку экрана.
+func (ui *UI) tick(stop chan struct{}) {
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ui.screen.PostEvent(tcell.NewEventInterrupt(nil))
+		}
+	}
+}
+
+// handleKey метод передает нажатие клавиши верхнему окну или основному экрану.
+func (ui *UI) handleKey(ev *tcell.EventKey) {
+	if ev.Key() == tcell.KeyCtrlC {
+		ui.exit()
+		return
+	}
+	if n := len(ui.overlays); n > 0 {
+		ui.overlays[n-1].handleKey(ui, ev)
+		return
+	}
+	if ui.login != "" {
+		ui.handleBrowserKey(ev)
+	}
+}
+
+// draw метод перерисовывает экран.
+func (ui *UI) draw() {
+	ui.screen.Clear()
+	ui.screen.HideCursor()
+	if ui.login != "" {
+		ui.drawBrowser()
+	} else {
+		w, h := ui.screen.Size()
+		drawText(ui.screen, 1, 0, w-2, styleTitle, "Менеджер паролей GophKeeper")
+		fillLine(ui.screen, 0, h-1, w, styleBar)
+		drawText(ui.screen, 1, h-1, w-2, styleBar, ui.message.get())
+	}
+	for _, o := range ui.overlays {
+		o.draw(ui)
+	}
+	ui.screen.Show()
+}
+
+// push метод открывает окно поверх остальных.
+func (ui *UI) push(o overlay) {
+	ui.overlays = append(ui.overlays, o)
+}
+
+// pop метод закрывает верхнее окно.
+func (ui *UI) pop() {
+	if n := len(ui.overlays); n > 0 {
+		ui.overlays = ui.overlays[:n-1]
+	}
+}
+
+// busy метод выводит сообщение о выполняемом запросе к серверу до его завершения.
+func (ui *UI) busy(text string) {
+	ui.message.set(text)
+	ui.draw()
+}
+
+// exit метод завершает работу интерфейса.
+func (ui *UI) exit() {
+	ui.quit = true
+}
+
+// saveCache метод сохраняет локальные изменения записей в локальной копии данных.
+func (ui *UI) saveCache() {
+	err := ui.sndr.SaveCache()
+	if err != nil {
+		log.Error().Err(err).Msg("SaveCache error")
+		ui.message.set("Ошибка сохранения локальной копии данных")
+	}
+}
+
+// requestFailed метод сообщает пользователю об ошибке запроса к серверу.
+func (ui *UI) requestFailed(err error, method, text string) {
+	if status.Code(err) == codes.Unauthenticated {
+		ui.message.set("Ошибка проверки подписи или время сессии истекло. Попробуйте перелогиниться")
+		return
+	}
+	log.Error().Err(err).Msgf("%s error", method)
+	ui.message.set(text)
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/require"
+
+	"gophkeeper/internal/client/sender"
+	"gophkeeper/internal/client/storage"
+)
+
+// newTestUI функция создает интерфейс авторизованного пользователя на экране-имитаторе без подключения к серверу.
+func newTestUI(t *testing.T) (*UI, tcell.SimulationScreen) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	require.NoError(t, screen.Init())
+	screen.SetSize(140, 30)
+	sndr := sender.NewGophKeeperClient(nil, nil, storage.NewUserStorage())
+	ui := newUI(screen, &sndr)
+	ui.login = "user"
+	return ui, screen
+}
+
+// typeText функция вводит строку посимвольно.
+func typeText(ui *UI, text string) {
+	for _, r := range text {
+		ui.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+// press функция нажимает специальные клавиши.
+func press(ui *UI, keys ...tcell.Key) {
+	for _, key := range keys {
+		ui.handleKey(tcell.NewEventKey(key, 0, tcell.ModNone))
+	}
+}
+
+// screenText функция перерисовывает экран и возвращает его содержимое.
+func screenText(ui *UI, screen tcell.SimulationScreen) string {
+	ui.draw()
+	cells, w, _ := screen.GetContents()
+	var text strings.Builder
+	for i, cell := range cells {
+		if len(cell.Runes) == 0 {
+			text.WriteRune(' ')
+		} else {
+			text.WriteRune(cell.Runes[0])
+		}
+		if (i+1)%w == 0 {
+			text.WriteRune('\n')
+		}
+	}
+	return text.String()
+}
+
+func TestInput(t *testing.T) {
+	in := newInput("Пароль", "abc", true)
+	require.True(t, in.handleKey(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone)))
+	require.True(t, in.handleKey(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone)))
+	require.True(t, in.handleKey(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)))
+	require.Equal(t, "a c", in.text())
+	require.False(t, in.handleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	require.NoError(t, screen.Init())
+	screen.SetSize(20, 1)
+	cursor := in.draw(screen, 0, 0, 10, false)
+	require.Equal(t, 2, cursor)
+	screen.Show()
+	cells, _, _ := screen.GetContents()
+	require.Equal(t, []rune{'*'}, cells[1].Runes)
+
+	require.Equal(t, []string{"абв", "г", "", "де"}, wrapText("абвг\n\nде", 3))
+}
+
+func TestRecordForms(t *testing.T) {
+	ui, screen := newTestUI(t)
+	strg := ui.sndr.Strg
+
+	// Поля формы могут содержать пробелы, пароль скрыт до нажатия F3
+	typeText(ui, "np")
+	typeText(ui, "почта на работе")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "user")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "секрет 1")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "рабочий ящик")
+	press(ui, tcell.KeyEnter)
+	require.Empty(t, ui.overlays)
+	require.Equal(t, []storage.Password{{ID: strg.Passwords[0].ID, Name: "почта на работе", Login: "user", Pass: "секрет 1", Comment: "рабочий ящик"}}, strg.Passwords)
+	text := screenText(ui, screen)
+	require.Contains(t, text, "почта на работе")
+	require.NotContains(t, text, "секрет 1")
+	require.Contains(t, text, "Несинхронизированных изменений: 1")
+	press(ui, tcell.KeyF3)
+	require.Contains(t, screenText(ui, screen), "секрет 1")
+
+	// Неверный номер карты не сохраняется, форма остается открытой с сообщением
+	typeText(ui, "nc")
+	typeText(ui, "карта")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "1234")
+	press(ui, tcell.KeyEnter, tcell.KeyEnter)
+	require.Empty(t, strg.Cards)
+	require.Len(t, ui.overlays, 1)
+	require.Contains(t, screenText(ui, screen), "Номер карты содержит ошибку")
+	press(ui, tcell.KeyEscape)
+	require.Empty(t, ui.overlays)
+
+	// Изменение выбранной записи
+	press(ui, tcell.KeyEnter, tcell.KeyEnd)
+	typeText(ui, " 2")
+	press(ui, tcell.KeyCtrlS)
+	require.Len(t, strg.Passwords, 1)
+	require.Equal(t, "почта на работе 2", strg.Passwords[0].Name)
+	require.Equal(t, "секрет 1", strg.Passwords[0].Pass)
+
+	// Удаление в корзину и восстановление
+	typeText(ui, "dy")
+	require.Empty(t, strg.Passwords)
+	require.Len(t, strg.SliceUsersTrash(), 1)
+	typeText(ui, "k")
+	press(ui, tcell.KeyEnter)
+	require.Len(t, strg.Passwords, 1)
+	require.Empty(t, strg.SliceUsersTrash())
+}
+
+func TestSearch(t *testing.T) {
+	ui, screen := newTestUI(t)
+	strg := ui.sndr.Strg
+	strg.AddUsersPassword(&storage.Password{Name: "Почта"})
+	strg.AddUsersText(&storage.Text{Name: "заметка о почте"})
+	strg.AddUsersCard(&storage.Card{Name: "Банк"})
+
+	text := screenText(ui, screen)
+	require.Len(t, ui.items, 3)
+	require.Contains(t, text, "Банк")
+
+	typeText(ui, "/поч")
+	text = screenText(ui, screen)
+	require.Len(t, ui.items, 2)
+	require.Contains(t, text, "Поиск: поч")
+	require.NotContains(t, text, "Банк")
+
+	// Во время поиска буквы не выполняют команды
+	typeText(ui, "dn")
+	screenText(ui, screen)
+	require.Empty(t, ui.items)
+	require.Empty(t, ui.overlays)
+	require.Contains(t, screenText(ui, screen), "Ничего не найдено")
+
+	press(ui, tcell.KeyEscape)
+	screenText(ui, screen)
+	require.Len(t, ui.items, 3)
+	press(ui, tcell.KeyDown)
+	it, ok := ui.current()
+	require.True(t, ok)
+	require.Equal(t, "заметка о почте", it.name)
+}
+
+func TestChangeEvents(t *testing.T) {
+	ui, screen := newTestUI(t)
+	strg := ui.sndr.Strg
+	strg.Revision = 5
+
+	// Уведомление о блокировке выводится сообщением
+	ui.handleChange(sender.ChangeEvent{Kind: sender.ChangeLocked, DeviceName: "laptop", TimeLocked: "10:00:00"})
+	require.False(t, ui.pending)
+	require.Contains(t, screenText(ui, screen), "Данные заблокированы на изменение с устройства laptop")
+
+	// Уведомление о данных, уже полученных клиентом, не требует синхронизации
+	ui.handleChange(sender.ChangeEvent{Kind: sender.ChangeSaved, Revision: 5, DeviceName: "laptop"})
+	require.False(t, ui.pending)
+
+	// При несинхронизированных изменениях новые данные не загружаются, пользователь получает предупреждение
+	strg.AddUsersText(&storage.Text{Name: "заметка"})
+	ui.handleChange(sender.ChangeEvent{Kind: sender.ChangeSaved, Revision: 6, DeviceName: "laptop"})
+	require.True(t, ui.pending)
+	text := screenText(ui, screen)
+	require.Contains(t, text, "На сервере есть более новая версия данных")
+	require.Contains(t, text, "на сервере есть новая версия")
+	ui.autoSync()
+	require.True(t, ui.pending)
+
+	// Без соединения с сервером уведомления не обрабатываются
+	ui.pending, ui.offline = false, true
+	ui.handleChange(sender.ChangeEvent{Kind: sender.ChangeSaved, Revision: 7})
+	require.False(t, ui.pending)
+}
+
+func TestAccountMenu(t *testing.T) {
+	ui, screen := newTestUI(t)
+
+	// Меню учетной записи открывается окном поверх списка записей без выхода из полноэкранного режима
+	typeText(ui, "a")
+	require.Len(t, ui.overlays, 1)
+	text := screenText(ui, screen)
+	require.Contains(t, text, "Учетная запись user")
+	require.Contains(t, text, "U - изменить пароль")
+
+	// Неверно заполненная форма смены пароля остается открытой с сообщением, запрос на сервер не отправляется
+	typeText(ui, "u")
+	typeText(ui, "old")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "new")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "other")
+	press(ui, tcell.KeyEnter)
+	require.Len(t, ui.overlays, 1)
+	text = screenText(ui, screen)
+	require.Contains(t, text, "Новый пароль и его повторение не совпадают")
+	require.NotContains(t, text, "old")
+	press(ui, tcell.KeyEscape)
+	require.Empty(t, ui.overlays)
+
+	// Удаление учетной записи требует ввода слова подтверждения
+	typeText(ui, "ax")
+	typeText(ui, "удалить")
+	press(ui, tcell.KeyEnter)
+	typeText(ui, "pass")
+	press(ui, tcell.KeyEnter)
+	require.Len(t, ui.overlays, 1)
+	require.Contains(t, screenText(ui, screen), "Для подтверждения введите УДАЛИТЬ, для отмены нажмите Esc")
+	require.Equal(t, "user", ui.login)
+	press(ui, tcell.KeyEscape)
+
+	// Без соединения с сервером меню недоступно
+	ui.offline = true
+	typeText(ui, "a")
+	require.Empty(t, ui.overlays)
+	require.Contains(t, screenText(ui, screen), "Меню учетной записи недоступно без соединения с сервером")
+}
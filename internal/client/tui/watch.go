@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+
+	"gophkeeper/internal/client/sender"
+)
+
+// startWatch метод подписывается на уведомления об изменениях данных другими сессиями пользователя.
+// Уведомления передаются в цикл обработки событий экрана.
+func (ui *UI) startWatch() {
+	ui.stopWatch = ui.sndr.StartWatch(func(event sender.ChangeEvent) {
+		ui.screen.PostEvent(tcell.NewEventInterrupt(event))
+	})
+}
+
+// stopWatching метод отменяет подписку на уведомления об изменениях данных.
+func (ui *UI) stopWatching() {
+	if ui.stopWatch != nil {
+		ui.stopWatch()
+		ui.stopWatch = nil
+	}
+	ui.pending = false
+}
+
+// handleChange метод обрабатывает уведомление об изменении данных другой сессией.
+// Более новые данные загружаются сразу, если пользователь ничего не изменяет, иначе пользователь получает предупреждение.
+func (ui *UI) handleChange(event sender.ChangeEvent) {
+	if ui.login == "" || ui.offline {
+		return
+	}
+	ui.message.set(event.String())
+	if !ui.sndr.IsNewer(event) {
+		return
+	}
+	if ui.idle() {
+		ui.syncData()
+		return
+	}
+	ui.pending = true
+	ui.message.set(event.String() + ". На сервере есть более новая версия данных, она будет загружена после завершения изменений")
+}
+
+// autoSync метод загружает более новые данные с сервера, когда пользователь завершил изменения.
+// Ошибка загрузки не повторяется автоматически, чтобы не отправлять запросы каждую секунду.
+func (ui *UI) autoSync() {
+	if ui.pending && ui.idle() {
+		ui.pending = false
+		ui.syncData()
+	}
+}
+
+// idle метод сообщает, что пользователь не изменяет данные: окна форм закрыты,
+// несинхронизированных изменений нет и данные не заблокированы текущей сессией.
+func (ui *UI) idle() bool {
+	locked, _ := ui.sndr.LockState()
+	return ui.login != "" && !ui.offline && len(ui.overlays) == 0 && ui.sndr.Strg.ChangedCount() == 0 && !locked
+}
@@ -0,0 +1,366 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Стили элементов интерфейса.
+var (
+	styleDefault  = tcell.StyleDefault
+	styleTitle    = tcell.StyleDefault.Bold(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleBar      = tcell.StyleDefault.Background(tcell.ColorNavy).Foreground(tcell.ColorWhite)
+	styleInput    = tcell.StyleDefault.Underline(true)
+	styleError    = tcell.StyleDefault.Foreground(tcell.ColorRed)
+	styleHint     = tcell.StyleDefault.Foreground(tcell.ColorGray)
+)
+
+// drawText функция выводит строку в позиции x, y, обрезая ее по ширине w. Возвращает ширину выведенного текста.
+func drawText(s tcell.Screen, x, y, w int, style tcell.Style, text string) int {
+	col := 0
+	for _, r := range text {
+		rw := runewidth.RuneWidth(r)
+		if rw == 0 {
+			continue
+		}
+		if col+rw > w {
+			break
+		}
+		s.SetContent(x+col, y, r, nil, style)
+		col += rw
+	}
+	return col
+}
+
+// fillLine функция заполняет строку экрана пробелами заданного стиля.
+func fillLine(s tcell.Screen, x, y, w int, style tcell.Style) {
+	for i := 0; i < w; i++ {
+		s.SetContent(x+i, y, ' ', nil, style)
+	}
+}
+
+// drawBox функция очищает прямоугольник и рисует вокруг него рамку с заголовком.
+func drawBox(s tcell.Screen, x, y, w, h int, title string) {
+	for row := y; row < y+h; row++ {
+		fillLine(s, x, row, w, styleDefault)
+	}
+	for col := x + 1; col < x+w-1; col++ {
+		s.SetContent(col, y, tcell.RuneHLine, nil, styleDefault)
+		s.SetContent(col, y+h-1, tcell.RuneHLine, nil, styleDefault)
+	}
+	for row := y + 1; row < y+h-1; row++ {
+		s.SetContent(x, row, tcell.RuneVLine, nil, styleDefault)
+		s.SetContent(x+w-1, row, tcell.RuneVLine, nil, styleDefault)
+	}
+	s.SetContent(x, y, tcell.RuneULCorner, nil, styleDefault)
+	s.SetContent(x+w-1, y, tcell.RuneURCorner, nil, styleDefault)
+	s.SetContent(x, y+h-1, tcell.RuneLLCorner, nil, styleDefault)
+	s.SetContent(x+w-1, y+h-1, tcell.RuneLRCorner, nil, styleDefault)
+	if title != "" {
+		drawText(s, x+2, y, w-4, styleTitle, " "+title+" ")
+	}
+}
+
+// centerBox функция возвращает положение и размер окна, размещенного в центре экрана.
+func centerBox(s tcell.Screen, w, h int) (int, int, int, int) {
+	sw, sh := s.Size()
+	if w > sw {
+		w = sw
+	}
+	if h > sh {
+		h = sh
+	}
+	return (sw - w) / 2, (sh - h) / 2, w, h
+}
+
+// wrapText функция разбивает текст на строки шириной не больше w с учетом переводов строк.
+func wrapText(text string, w int) []string {
+	if w < 1 {
+		return nil
+	}
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line strings.Builder
+		col := 0
+		for _, r := range paragraph {
+			rw := runewidth.RuneWidth(r)
+			if col+rw > w {
+				lines = append(lines, line.String())
+				line.Reset()
+				col = 0
+			}
+			line.WriteRune(r)
+			col += rw
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// input структура поля ввода строки. В отличие от fmt.Scanln значение может содержать пробелы.
+type input struct {
+	label  string
+	value  []rune
+	cursor int  //Позиция курсора в значении
+	masked bool //Вводимые символы скрываются, например при вводе пароля
+}
+
+// newInput функция создает поле ввода с начальным значением, курсор устанавливается в конец значения.
+func newInput(label, value string, masked bool) *input {
+	runes := []rune(value)
+	return &input{label: label, value: runes, cursor: len(runes), masked: masked}
+}
+
+// text метод возвращает введенное значение.
+func (in *input) text() string {
+	return string(in.value)
+}
+
+// handleKey метод изменяет значение по нажатой клавише. Возвращает false, если клавиша не относится к редактированию.
+func (in *input) handleKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		in.value = append(in.value[:in.cursor], append([]rune{ev.Rune()}, in.value[in.cursor:]...)...)
+		in.cursor++
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if in.cursor > 0 {
+			in.value = append(in.value[:in.cursor-1], in.value[in.cursor:]...)
+			in.cursor--
+		}
+	case tcell.KeyDelete:
+		if in.cursor < len(in.value) {
+			in.value = append(in.value[:in.cursor], in.value[in.cursor+1:]...)
+		}
+	case tcell.KeyLeft:
+		if in.cursor > 0 {
+			in.cursor--
+		}
+	case tcell.KeyRight:
+		if in.cursor < len(in.value) {
+			in.cursor++
+		}
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		in.cursor = 0
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		in.cursor = len(in.value)
+	case tcell.KeyCtrlU:
+		in.value = in.value[:0]
+		in.cursor = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// draw метод выводит значение поля шириной w со сдвигом, при котором курсор остается видимым.
+// Возвращает экранную позицию курсора.
+func (in *input) draw(s tcell.Screen, x, y, w int, reveal bool) int {
+	runes := in.value
+	if in.masked && !reveal {
+		runes = []rune(strings.Repeat("*", len(in.value)))
+	}
+	start := 0
+	for runewidth.StringWidth(string(runes[start:in.cursor])) >= w && start < in.cursor {
+		start++
+	}
+	fillLine(s, x, y, w, styleInput)
+	drawText(s, x, y, w, styleInput, string(runes[start:]))
+	return x + runewidth.StringWidth(string(runes[start:in.cursor]))
+}
+
+// form структура окна с полями ввода. Enter переходит к следующему полю, на последнем поле отправляет форму.
+type form struct {
+	title  string
+	inputs []*input
+	focus  int
+	reveal bool   //Показывать значения скрытых полей
+	err    string //Сообщение о неверно заполненной форме
+	// submit проверяет и применяет введенные значения. Непустой результат - сообщение пользователю, форма остается открытой.
+	submit func(values []string) string
+	cancel func()
+}
+
+// values метод возвращает значения полей формы.
+func (f *form) values() []string {
+	values := make([]string, len(f.inputs))
+	for i, in := range f.inputs {
+		values[i] = in.text()
+	}
+	return values
+}
+
+// draw метод выводит форму в центре экрана.
+func (f *form) draw(ui *UI) {
+	labelW := 0
+	for _, in := range f.inputs {
+		if w := runewidth.StringWidth(in.label); w > labelW {
+			labelW = w
+		}
+	}
+	x, y, w, h := centerBox(ui.screen, 76, len(f.inputs)*2+6)
+	drawBox(ui.screen, x, y, w, h, f.title)
+	fieldX := x + labelW + 4
+	for i, in := range f.inputs {
+		row := y + 2 + i*2
+		drawText(ui.screen, x+2, row, labelW, styleDefault, in.label)
+		cursor := in.draw(ui.screen, fieldX, row, x+w-2-fieldX, f.reveal)
+		if i == f.focus {
+			ui.screen.ShowCursor(cursor, row)
+		}
+	}
+	drawText(ui.screen, x+2, y+h-3, w-4, styleError, f.err)
+	hint := "Enter - далее, Tab - другое поле, Esc - отмена"
+	for _, in := range f.inputs {
+		if in.masked {
+			hint = "Enter - далее, Tab - другое поле, F2 - показать пароль, Esc - отмена"
+		}
+	}
+	drawText(ui.screen, x+2, y+h-2, w-4, styleHint, hint)
+}
+
+// handleKey метод обрабатывает нажатие клавиши в форме.
+func (f *form) handleKey(ui *UI, ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ui.pop()
+		if f.cancel != nil {
+			f.cancel()
+		}
+	case tcell.KeyTab, tcell.KeyDown:
+		f.focus = (f.focus + 1) % len(f.inputs)
+	case tcell.KeyBacktab, tcell.KeyUp:
+		f.focus = (f.focus + len(f.inputs) - 1) % len(f.inputs)
+	case tcell.KeyF2:
+		f.reveal = !f.reveal
+	case tcell.KeyEnter:
+		if f.focus < len(f.inputs)-1 {
+			f.focus++
+			return
+		}
+		f.commit(ui)
+	case tcell.KeyCtrlS:
+		f.commit(ui)
+	default:
+		f.inputs[f.focus].handleKey(ev)
+	}
+}
+
+// commit метод закрывает форму и передает введенные значения. При ошибке форма открывается снова с сообщением.
+func (f *form) commit(ui *UI) {
+	ui.pop()
+	f.err = f.submit(f.values())
+	if f.err != "" {
+		ui.push(f)
+	}
+}
+
+// confirm структура окна подтверждения действия.
+type confirm struct {
+	question string
+	yes      func()
+	no       func()
+}
+
+// draw метод выводит вопрос в центре экрана.
+func (c *confirm) draw(ui *UI) {
+	lines := wrapText(c.question, 68)
+	x, y, w, h := centerBox(ui.screen, 72, len(lines)+4)
+	drawBox(ui.screen, x, y, w, h, "Подтверждение")
+	for i, line := range lines {
+		drawText(ui.screen, x+2, y+1+i, w-4, styleDefault, line)
+	}
+	drawText(ui.screen, x+2, y+h-2, w-4, styleHint, "Y - да, N - нет")
+}
+
+// handleKey метод обрабатывает ответ пользователя.
+func (c *confirm) handleKey(ui *UI, ev *tcell.EventKey) {
+	switch {
+	case ev.Key() == tcell.KeyEnter || ev.Rune() == 'y' || ev.Rune() == 'Y':
+		ui.pop()
+		c.yes()
+	case ev.Key() == tcell.KeyEscape || ev.Rune() == 'n' || ev.Rune() == 'N':
+		ui.pop()
+		if c.no != nil {
+			c.no()
+		}
+	}
+}
+
+// option структура варианта выбора. Вариант выбирается стрелками и Enter или клавишей key, если она задана.
+type option struct {
+	key    rune
+	label  string
+	action func()
+}
+
+// chooser структура окна выбора одного из вариантов.
+type chooser struct {
+	title    string
+	lines    []string //Пояснение над вариантами
+	options  []option
+	selected int
+	cancel   func()
+}
+
+// draw метод выводит варианты в центре экрана.
+func (c *chooser) draw(ui *UI) {
+	var lines []string
+	for _, line := range c.lines {
+		lines = append(lines, wrapText(line, 68)...)
+	}
+	x, y, w, h := centerBox(ui.screen, 72, len(lines)+len(c.options)+5)
+	drawBox(ui.screen, x, y, w, h, c.title)
+	for i, line := range lines {
+		drawText(ui.screen, x+2, y+1+i, w-4, styleDefault, line)
+	}
+	top := y + 2 + len(lines)
+	if len(lines) == 0 {
+		top = y + 1
+	}
+	for i, opt := range c.options {
+		style := styleDefault
+		if i == c.selected {
+			style = styleSelected
+		}
+		label := opt.label
+		if opt.key != 0 {
+			label = string(opt.key) + " - " + label
+		}
+		fillLine(ui.screen, x+2, top+i, w-4, style)
+		drawText(ui.screen, x+2, top+i, w-4, style, label)
+	}
+	drawText(ui.screen, x+2, y+h-2, w-4, styleHint, "↑↓ и Enter - выбрать, Esc - отмена")
+}
+
+// handleKey метод обрабатывает выбор варианта.
+func (c *chooser) handleKey(ui *UI, ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		if c.selected > 0 {
+			c.selected--
+		}
+	case tcell.KeyDown:
+		if c.selected < len(c.options)-1 {
+			c.selected++
+		}
+	case tcell.KeyEnter:
+		ui.pop()
+		c.options[c.selected].action()
+	case tcell.KeyEscape:
+		ui.pop()
+		if c.cancel != nil {
+			c.cancel()
+		}
+	case tcell.KeyRune:
+		for _, opt := range c.options {
+			if opt.key != 0 && strings.EqualFold(string(opt.key), string(ev.Rune())) {
+				ui.pop()
+				opt.action()
+				return
+			}
+		}
+	}
+}
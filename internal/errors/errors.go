@@ -5,15 +5,43 @@ import "errors"
 
 // Переменные для передачи хэндлену идентификатора ошибки.
 var (
-	ErrExpired        error = errors.New("sessionID expired")
-	ErrLoginExist     error = errors.New("user with such login exists")
-	ErrNoSuchUser     error = errors.New("user with such login not registered")
-	ErrLoginIncorrect error = errors.New("users login contains error")
-	ErrWrongPassword  error = errors.New("password incorrect")
-	ErrNotAuth        error = errors.New("user isn't authenticated")
-	ErrNoUserData     error = errors.New("user hasn't saved data")
-	ErrTimeNotEqual   error = errors.New("users data timeStamp not equal to servers")
-	ErrLocked         error = errors.New("users data changes locked by another user")
-	ErrSignIncorrect  error = errors.New("incorrect sign encryption")
-	ErrTooBig         error = errors.New("file is too big")
+	ErrExpired             error = errors.New("sessionID expired")
+	ErrLoginExist          error = errors.New("user with such login exists")
+	ErrNoSuchUser          error = errors.New("user with such login not registered")
+	ErrLoginIncorrect      error = errors.New("users login contains error")
+	ErrWrongPassword       error = errors.New("password incorrect")
+	ErrNotAuth             error = errors.New("user isn't authenticated")
+	ErrNoUserData          error = errors.New("user hasn't saved data")
+	ErrDataVersionNotEqual error = errors.New("users data version not equal to servers")
+	ErrLocked              error = errors.New("users data changes locked by another user")
+	ErrSignIncorrect       error = errors.New("incorrect sign encryption")
+	ErrTooBig              error = errors.New("file is too big")
+	ErrRecordExists        error = errors.New("record with such ID exists")
+	ErrNoSuchRecord        error = errors.New("record with such ID not found")
+	ErrVersionNotEqual     error = errors.New("record version not equal to servers")
+	ErrNoSuchVersion       error = errors.New("users data version not found in history")
+	ErrKeyParamsIncorrect  error = errors.New("master key params incorrect")
+	ErrKeyIncorrect        error = errors.New("users data key incorrect")
+	ErrSignExpired         error = errors.New("request sign time out of window")
+	ErrReplay              error = errors.New("request already received")
+	ErrPublicKeyIncorrect  error = errors.New("session public key incorrect")
+	ErrSessionKeyIncorrect error = errors.New("session store master key incorrect")
+	ErrNoSuchSession       error = errors.New("users session not found")
+	ErrNotLocked           error = errors.New("users data isn't locked by session")
+	ErrInvalidCredentials  error = errors.New("invalid credentials")
+	ErrTooManyAttempts     error = errors.New("too many failed login attempts")
+	ErrTOTPRequired        error = errors.New("totp code required")
+	ErrTOTPEnabled         error = errors.New("totp is already enabled")
+	ErrTOTPNotEnabled      error = errors.New("totp isn't enabled")
+	ErrTOTPCodeIncorrect   error = errors.New("totp code incorrect")
+	ErrTOTPKeyIncorrect    error = errors.New("totp secrets master key incorrect")
+	ErrNoCredentials       error = errors.New("credentials are not provided")
+	ErrRecordAmbiguous     error = errors.New("several records match the name")
+	ErrRecordTypeUnknown   error = errors.New("record type unknown")
+	ErrNoTerminal          error = errors.New("standard input or output isn't a terminal")
+	ErrNoCache             error = errors.New("local cache of user data not found")
+	ErrQuotaExceeded       error = errors.New("attachments quota exceeded")
+	ErrNoSuchAttachment    error = errors.New("attachment with such ID not found")
+	ErrAttachmentSize      error = errors.New("attachment size not equal to declared")
+	ErrAttachmentHash      error = errors.New("attachment hash incorrect")
 )
@@ -0,0 +1,157 @@
+// Модуль предназначен для установления защищенного канала сессии: обмена эфемерными ключами X25519,
+// вычисления ключей сессии HKDF и шифрования полей сообщений AES-GCM.
+package handshake
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// KeyLen длина открытого ключа X25519 и каждого из ключей сессии.
+const KeyLen = curve25519.PointSize
+
+// keysInfo контекст вычисления ключей сессии HKDF.
+var keysInfo = []byte("gophkeeper session keys v1")
+
+// KeyPair структура для хранения эфемерной пары ключей X25519 одной стороны сессии.
+type KeyPair struct {
+	private []byte
+	Public  []byte
+}
+
+// Keys структура для хранения ключей сессии. Для каждого направления используются отдельные ключи шифрования и подписи.
+type Keys struct {
+	ClientKey  []byte //Ключ шифрования сообщений клиента
+	ServerKey  []byte //Ключ шифрования сообщений сервера
+	ClientSign []byte //Ключ подписи запросов клиента
+	ServerSign []byte //Ключ подписи ответов сервера
+}
+
+// NewKeyPair функция генерирует эфемерную пару ключей X25519.
+func NewKeyPair() (KeyPair, error) {
+	private := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(private)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{private: private, Public: public}, nil
+}
+
+// Clear метод перезаписывает нулями закрытый ключ пары.
+func (k KeyPair) Clear() {
+	Zero(k.private)
+}
+
+// Clear метод перезаписывает нулями ключи шифрования и подписи сессии.
+func (k Keys) Clear() {
+	for _, key := range [][]byte{k.ClientKey, k.ServerKey, k.ClientSign, k.ServerSign} {
+		Zero(key)
+	}
+}
+
+// Zero функция перезаписывает нулями содержимое среза с ключом.
+func Zero(key []byte) {
+	for i := range key {
+		key[i] = 0
+	}
+}
+
+// ClientKeys метод вычисляет ключи сессии на стороне клиента по открытому ключу сервера.
+func (k KeyPair) ClientKeys(serverPublic []byte) (Keys, error) {
+	return k.deriveKeys(serverPublic, k.Public, serverPublic)
+}
+
+// ServerKeys метод вычисляет ключи сессии на стороне сервера по открытому ключу клиента.
+func (k KeyPair) ServerKeys(clientPublic []byte) (Keys, error) {
+	return k.deriveKeys(clientPublic, clientPublic, k.Public)
+}
+
+// deriveKeys метод вычисляет общий секрет X25519 и получает из него ключи сессии HKDF-SHA256.
+// Солью служат открытые ключи клиента и сервера, поэтому ключи сессии привязаны к обоим участникам обмена.
+func (k KeyPair) deriveKeys(peerPublic, clientPublic, serverPublic []byte) (Keys, error) {
+	if len(peerPublic) != KeyLen {
+		return Keys{}, gkerrors.ErrPublicKeyIncorrect
+	}
+	secret, err := curve25519.X25519(k.private, peerPublic)
+	if err != nil {
+		return Keys{}, gkerrors.ErrPublicKeyIncorrect
+	}
+	salt := make([]byte, 0, len(clientPublic)+len(serverPublic))
+	salt = append(salt, clientPublic...)
+	salt = append(salt, serverPublic...)
+	keysBZ := make([]byte, 4*KeyLen)
+	_, err = io.ReadFull(hkdf.New(sha256.New, secret, salt, keysInfo), keysBZ)
+	if err != nil {
+		return Keys{}, err
+	}
+	return Keys{
+		ClientKey:  keysBZ[:KeyLen],
+		ServerKey:  keysBZ[KeyLen : 2*KeyLen],
+		ClientSign: keysBZ[2*KeyLen : 3*KeyLen],
+		ServerSign: keysBZ[3*KeyLen:],
+	}, nil
+}
+
+// Seal функция зашифровывает сообщение ключом сессии. Метка поля сообщения используется как дополнительные данные AES-GCM,
+// результат содержит случайный nonce и шифротекст с тегом.
+func Seal(key, message, label []byte) ([]byte, error) {
+	aesgcm, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize(), aesgcm.NonceSize()+len(message)+aesgcm.Overhead())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aesgcm.Seal(nonce, nonce, message, label), nil
+}
+
+// Open функция расшифровывает сообщение, зашифрованное функцией Seal с той же меткой.
+func Open(key, messageBZ, label []byte) ([]byte, error) {
+	aesgcm, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(messageBZ) < aesgcm.NonceSize()+aesgcm.Overhead() {
+		return nil, gkerrors.ErrSignIncorrect
+	}
+	return aesgcm.Open(nil, messageBZ[:aesgcm.NonceSize()], messageBZ[aesgcm.NonceSize():], label)
+}
+
+// Sign функция создает подпись HMAC-SHA256 данных ключом подписи сессии.
+func Sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Verify функция проверяет подпись HMAC-SHA256 данных.
+func Verify(key, data, sign []byte) bool {
+	return len(key) == KeyLen && hmac.Equal(Sign(key, data), sign)
+}
+
+// newCipher функция создает шифр AES-GCM на ключе сессии.
+func newCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeyLen {
+		return nil, gkerrors.ErrExpired
+	}
+	aesblock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(aesblock)
+}
@@ -0,0 +1,67 @@
+package handshake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+func TestKeys(t *testing.T) {
+	client, err := NewKeyPair()
+	require.NoError(t, err)
+	server, err := NewKeyPair()
+	require.NoError(t, err)
+	require.Len(t, client.Public, KeyLen)
+
+	clientKeys, err := client.ClientKeys(server.Public)
+	require.NoError(t, err)
+	serverKeys, err := server.ServerKeys(client.Public)
+	require.NoError(t, err)
+	require.Equal(t, clientKeys, serverKeys)
+	require.NotEqual(t, clientKeys.ClientKey, clientKeys.ServerKey)
+	require.NotEqual(t, clientKeys.ClientSign, clientKeys.ServerSign)
+
+	other, err := NewKeyPair()
+	require.NoError(t, err)
+	otherKeys, err := other.ServerKeys(client.Public)
+	require.NoError(t, err)
+	require.NotEqual(t, clientKeys, otherKeys)
+
+	_, err = server.ServerKeys([]byte("short"))
+	require.ErrorIs(t, err, gkerrors.ErrPublicKeyIncorrect)
+	_, err = server.ServerKeys(make([]byte, KeyLen))
+	require.ErrorIs(t, err, gkerrors.ErrPublicKeyIncorrect)
+}
+
+func TestSeal(t *testing.T) {
+	client, err := NewKeyPair()
+	require.NoError(t, err)
+	server, err := NewKeyPair()
+	require.NoError(t, err)
+	keys, err := client.ClientKeys(server.Public)
+	require.NoError(t, err)
+
+	sealed, err := Seal(keys.ClientKey, []byte("login,pass"), []byte("login"))
+	require.NoError(t, err)
+	again, err := Seal(keys.ClientKey, []byte("login,pass"), []byte("login"))
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	message, err := Open(keys.ClientKey, sealed, []byte("login"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("login,pass"), message)
+
+	_, err = Open(keys.ClientKey, sealed, []byte("userID"))
+	require.Error(t, err)
+	_, err = Open(keys.ServerKey, sealed, []byte("login"))
+	require.Error(t, err)
+	_, err = Open(keys.ClientKey, sealed[:10], []byte("login"))
+	require.Error(t, err)
+
+	sign := Sign(keys.ClientSign, []byte("data"))
+	require.True(t, Verify(keys.ClientSign, []byte("data"), sign))
+	require.False(t, Verify(keys.ServerSign, []byte("data"), sign))
+	require.False(t, Verify(nil, []byte("data"), Sign(nil, []byte("data"))))
+}
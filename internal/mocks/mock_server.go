@@ -1,10 +1,7 @@
 package mocks
 
 import (
-	"bytes"
 	"context"
-	"crypto/rsa"
-	"encoding/gob"
 	"net"
 	"time"
 
@@ -15,6 +12,7 @@ import (
 	"google.golang.org/grpc/test/bufconn"
 
 	pb "gophkeeper/api/grpc/proto"
+	clientRsa "gophkeeper/internal/client/crypto"
 	servConf "gophkeeper/internal/server/config"
 	servRsa "gophkeeper/internal/server/crypto"
 )
@@ -27,13 +25,16 @@ type mockServer struct {
 
 var timeStamp, realSessionID string
 
+// masterKey параметры мастер-ключа и зашифрованный ключ данных зарегистрированного пользователя.
+var masterKey *pb.MasterKey
+
 func Dialer() func(context.Context, string) (net.Conn, error) {
 	listener := bufconn.Listen(1024 * 1024)
 	servCnfg, err := servConf.NewConfig()
 	if err != nil {
 		log.Fatal().Err(err).Msg("NewConfig read environment error")
 	}
-	servRsa := servRsa.NewSessions(servCnfg)
+	servRsa := servRsa.NewSessions(servCnfg, servRsa.NewMemoryStore())
 	server := grpc.NewServer()
 
 	pb.RegisterGophKeeperServer(server, &mockServer{cfg: servCnfg, rsa: servRsa})
@@ -50,19 +51,20 @@ func Dialer() func(context.Context, string) (net.Conn, error) {
 }
 
 func (s *mockServer) NewSessionID(ctx context.Context, in *pb.NewSessionIDRequest) (*pb.NewSessionIDResponce, error) {
-	var buf bytes.Buffer
-	buf.Write(in.UserPublicKeyBZ)
-
-	var userPublicKey = rsa.PublicKey{}
-	dec := gob.NewDecoder(&buf)
-	dec.Decode(&userPublicKey)
-	var publicKey *rsa.PublicKey
-	realSessionID, publicKey, _ = s.rsa.NewSessionID(&userPublicKey)
-
-	var publicKeyBZ bytes.Buffer
-	enc := gob.NewEncoder(&publicKeyBZ)
-	enc.Encode(&publicKey)
-	return &pb.NewSessionIDResponce{SessionID: realSessionID, PublicKeyBZ: publicKeyBZ.Bytes()}, nil
+	var publicKey []byte
+	realSessionID, publicKey, _ = s.rsa.NewSessionID(in.UserPublicKeyBZ, in.DeviceName, in.ClientVersion, "")
+	return &pb.NewSessionIDResponce{SessionID: realSessionID, PublicKeyBZ: publicKey}, nil
+}
+
+func (s *mockServer) KeyParams(ctx context.Context, in *pb.KeyParamsRequest) (*pb.KeyParamsResponce, error) {
+	var responce pb.KeyParamsResponce
+	if masterKey == nil {
+		responce.Legacy = true
+	} else {
+		responce.MasterKey = &pb.MasterKey{Salt: masterKey.Salt, Time: masterKey.Time, Memory: masterKey.Memory, Threads: masterKey.Threads}
+	}
+	responce.Sign, _ = s.rsa.SignData(in.SessionID)
+	return &responce, nil
 }
 
 func (s *mockServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (*pb.NewUserResponce, error) {
@@ -77,17 +79,16 @@ func (s *mockServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (*pb.Ne
 	if userLogin == "234" {
 		return nil, status.Error(codes.AlreadyExists, "user with such login exists")
 	}
-	userID := servRsa.RandomID(s.cfg.LenghtUserID)
-	symKey := servRsa.NewSymmetricalKey(userID)
+	userID, _ := servRsa.RandomID(s.cfg.LenghtUserID)
 	timeStamp := time.Now().Format(time.RFC3339)
 	var responce = pb.NewUserResponce{TimeStamp: timeStamp}
 	responce.UserID, _ = s.rsa.EncryptData(in.SessionID, userID, []byte(`userID`))
-	responce.SymKey, _ = s.rsa.EncryptData(in.SessionID, symKey, []byte(`key`))
 
 	if userLogin == "345" {
 		return &responce, nil
 	}
 
+	masterKey = in.MasterKey
 	responce.Sign, _ = s.rsa.SignData(in.SessionID)
 
 	return &responce, nil
@@ -104,7 +105,7 @@ func (s *mockServer) LoginUser(ctx context.Context, in *pb.LoginUserRequest) (*p
 		return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
 	}
 	userID := "1234567890"
-	var responce pb.LoginUserResponce
+	var responce = pb.LoginUserResponce{MasterKey: masterKey}
 	responce.UserID, _ = s.rsa.EncryptData(in.SessionID, userID, []byte(`userID`))
 
 	if userLogin == "345" {
@@ -122,11 +123,9 @@ func (s *mockServer) UserData(ctx context.Context, in *pb.UserDataRequest) (*pb.
 	}
 
 	var responce pb.UserDataResponce
-	symKey := servRsa.NewSymmetricalKey("1234567890")
 	timeStamp = time.Now().Format(time.RFC3339)
 	responce.UserData = nil
 	responce.TimeStamp = timeStamp
-	responce.SymKey, _ = s.rsa.EncryptData(realSessionID, symKey, []byte(`key`))
 
 	if in.SessionID == "147852369" {
 		return &responce, nil
@@ -184,6 +183,33 @@ func (s *mockServer) DataLock(ctx context.Context, in *pb.DataLockRequest) (*pb.
 	return &responce, nil
 }
 
+func (s *mockServer) ReleaseLock(ctx context.Context, in *pb.ReleaseLockRequest) (*pb.ReleaseLockResponce, error) {
+	if in.SessionID == "9876543210" {
+		return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
+	}
+	var responce = pb.ReleaseLockResponce{Status: true}
+	if in.SessionID == "147852369" {
+		return &responce, nil
+	}
+	responce.Sign, _ = s.rsa.SignData(realSessionID)
+	return &responce, nil
+}
+
+func (s *mockServer) RenewLock(ctx context.Context, in *pb.RenewLockRequest) (*pb.RenewLockResponce, error) {
+	if in.SessionID == "9876543210" {
+		return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
+	}
+	if in.SessionID == "123654789" {
+		return nil, status.Error(codes.FailedPrecondition, "users data isn't locked by session")
+	}
+	var responce = pb.RenewLockResponce{Locked: true, TimeLocked: time.Now().Add(2 * time.Minute).Format(time.RFC3339)}
+	if in.SessionID == "147852369" {
+		return &responce, nil
+	}
+	responce.Sign, _ = s.rsa.SignData(realSessionID)
+	return &responce, nil
+}
+
 func (s *mockServer) UpdateData(ctx context.Context, in *pb.UpdateDataRequest) (*pb.UpdateDataResponce, error) {
 	if in.SessionID == "9876543210" {
 		return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
@@ -208,13 +234,13 @@ func (s *mockServer) UpdateData(ctx context.Context, in *pb.UpdateDataRequest) (
 
 func (s *mockServer) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.ChangePasswordResponce, error) {
 	old, _ := s.rsa.DecryptPassword(realSessionID, in.OldPassword, []byte("oldPass"))
-	if old == servRsa.HashPasswd("234") {
+	if old == authKey("234") {
 		return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
 	}
 
 	var responce = pb.ChangePasswordResponce{Status: true}
 
-	if old == servRsa.HashPasswd("345") {
+	if old == authKey("345") {
 		return &responce, nil
 	}
 
@@ -234,3 +260,12 @@ func (s *mockServer) LogOut(ctx context.Context, in *pb.LogOutRequest) (*pb.LogO
 	responce.Status = true
 	return &responce, nil
 }
+
+// authKey функция вычисляет ключ аутентификации, который клиент передает вместо пароля зарегистрированного пользователя.
+func authKey(password string) string {
+	if masterKey == nil {
+		return ""
+	}
+	params := clientRsa.KeyParams{Salt: masterKey.Salt, Time: masterKey.Time, Memory: masterKey.Memory, Threads: uint8(masterKey.Threads)}
+	return clientRsa.DeriveMasterKey(password, params).AuthKey
+}
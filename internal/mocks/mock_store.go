@@ -5,7 +5,10 @@
 package mocks
 
 import (
+	records "gophkeeper/internal/records"
+	storage "gophkeeper/internal/server/storage"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -33,13 +36,73 @@ func (m *MockStorager) EXPECT() *MockStoragerMockRecorder {
 	return m.recorder
 }
 
+// AttachmentsUsage mocks base method.
+func (m *MockStorager) AttachmentsUsage(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachmentsUsage", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachmentsUsage indicates an expected call of AttachmentsUsage.
+func (mr *MockStoragerMockRecorder) AttachmentsUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachmentsUsage", reflect.TypeOf((*MockStorager)(nil).AttachmentsUsage), arg0)
+}
+
+// AuthAttempt mocks base method.
+func (m *MockStorager) AuthAttempt(arg0 string, arg1 int) (time.Time, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthAttempt", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthAttempt indicates an expected call of AuthAttempt.
+func (mr *MockStoragerMockRecorder) AuthAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthAttempt", reflect.TypeOf((*MockStorager)(nil).AuthAttempt), arg0, arg1)
+}
+
+// AuthReleased mocks base method.
+func (m *MockStorager) AuthReleased(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthReleased", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthReleased indicates an expected call of AuthReleased.
+func (mr *MockStoragerMockRecorder) AuthReleased(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthReleased", reflect.TypeOf((*MockStorager)(nil).AuthReleased), arg0, arg1)
+}
+
+// AuthSucceeded mocks base method.
+func (m *MockStorager) AuthSucceeded(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthSucceeded", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthSucceeded indicates an expected call of AuthSucceeded.
+func (mr *MockStoragerMockRecorder) AuthSucceeded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthSucceeded", reflect.TypeOf((*MockStorager)(nil).AuthSucceeded), arg0)
+}
+
 // AuthUser mocks base method.
-func (m *MockStorager) AuthUser(arg0, arg1 string) (string, error) {
+func (m *MockStorager) AuthUser(arg0, arg1 string) (string, storage.UserKey, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AuthUser", arg0, arg1)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(storage.UserKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // AuthUser indicates an expected call of AuthUser.
@@ -49,18 +112,18 @@ func (mr *MockStoragerMockRecorder) AuthUser(arg0, arg1 interface{}) *gomock.Cal
 }
 
 // ChangeUserPassword mocks base method.
-func (m *MockStorager) ChangeUserPassword(arg0, arg1, arg2 string) (bool, error) {
+func (m *MockStorager) ChangeUserPassword(arg0, arg1, arg2 string, arg3 storage.UserKey) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeUserPassword", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ChangeUserPassword", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ChangeUserPassword indicates an expected call of ChangeUserPassword.
-func (mr *MockStoragerMockRecorder) ChangeUserPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoragerMockRecorder) ChangeUserPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserPassword", reflect.TypeOf((*MockStorager)(nil).ChangeUserPassword), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserPassword", reflect.TypeOf((*MockStorager)(nil).ChangeUserPassword), arg0, arg1, arg2, arg3)
 }
 
 // CheckUser mocks base method.
@@ -90,31 +153,325 @@ func (mr *MockStoragerMockRecorder) CloseDB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseDB", reflect.TypeOf((*MockStorager)(nil).CloseDB))
 }
 
+// CommitAttachment mocks base method.
+func (m *MockStorager) CommitAttachment(arg0, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitAttachment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitAttachment indicates an expected call of CommitAttachment.
+func (mr *MockStoragerMockRecorder) CommitAttachment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitAttachment", reflect.TypeOf((*MockStorager)(nil).CommitAttachment), arg0, arg1, arg2)
+}
+
+// CreateRecord mocks base method.
+func (m *MockStorager) CreateRecord(arg0, arg1 string, arg2 records.Record) (records.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(records.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecord indicates an expected call of CreateRecord.
+func (mr *MockStoragerMockRecorder) CreateRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*MockStorager)(nil).CreateRecord), arg0, arg1, arg2)
+}
+
+// DeleteRecord mocks base method.
+func (m *MockStorager) DeleteRecord(arg0, arg1, arg2 string, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecord indicates an expected call of DeleteRecord.
+func (mr *MockStoragerMockRecorder) DeleteRecord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecord", reflect.TypeOf((*MockStorager)(nil).DeleteRecord), arg0, arg1, arg2, arg3)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStorager) DeleteUser(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoragerMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStorager)(nil).DeleteUser), arg0, arg1)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockStorager) DisableTOTP(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockStoragerMockRecorder) DisableTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockStorager)(nil).DisableTOTP), arg0)
+}
+
+// DropAttachment mocks base method.
+func (m *MockStorager) DropAttachment(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropAttachment indicates an expected call of DropAttachment.
+func (mr *MockStoragerMockRecorder) DropAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropAttachment", reflect.TypeOf((*MockStorager)(nil).DropAttachment), arg0, arg1)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockStorager) EnableTOTP(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockStoragerMockRecorder) EnableTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockStorager)(nil).EnableTOTP), arg0, arg1)
+}
+
+// ExpiredAttachments mocks base method.
+func (m *MockStorager) ExpiredAttachments(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiredAttachments", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpiredAttachments indicates an expected call of ExpiredAttachments.
+func (mr *MockStoragerMockRecorder) ExpiredAttachments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiredAttachments", reflect.TypeOf((*MockStorager)(nil).ExpiredAttachments), arg0)
+}
+
+// GetRecord mocks base method.
+func (m *MockStorager) GetRecord(arg0, arg1 string) (records.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecord", arg0, arg1)
+	ret0, _ := ret[0].(records.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecord indicates an expected call of GetRecord.
+func (mr *MockStoragerMockRecorder) GetRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecord", reflect.TypeOf((*MockStorager)(nil).GetRecord), arg0, arg1)
+}
+
+// ListHistory mocks base method.
+func (m *MockStorager) ListHistory(arg0 string) ([]storage.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", arg0)
+	ret0, _ := ret[0].([]storage.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockStoragerMockRecorder) ListHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockStorager)(nil).ListHistory), arg0)
+}
+
+// ListRecords mocks base method.
+func (m *MockStorager) ListRecords(arg0 string) ([]records.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecords", arg0)
+	ret0, _ := ret[0].([]records.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecords indicates an expected call of ListRecords.
+func (mr *MockStoragerMockRecorder) ListRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecords", reflect.TypeOf((*MockStorager)(nil).ListRecords), arg0)
+}
+
 // RegisterUser mocks base method.
-func (m *MockStorager) RegisterUser(arg0, arg1 string) (string, string, string, error) {
+func (m *MockStorager) RegisterUser(arg0, arg1 string, arg2 storage.UserKey) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "RegisterUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockStoragerMockRecorder) RegisterUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockStorager)(nil).RegisterUser), arg0, arg1, arg2)
+}
+
+// ReleaseAttachment mocks base method.
+func (m *MockStorager) ReleaseAttachment(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseAttachment indicates an expected call of ReleaseAttachment.
+func (mr *MockStoragerMockRecorder) ReleaseAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAttachment", reflect.TypeOf((*MockStorager)(nil).ReleaseAttachment), arg0, arg1)
+}
+
+// ReleaseLock mocks base method.
+func (m *MockStorager) ReleaseLock(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLock indicates an expected call of ReleaseLock.
+func (mr *MockStoragerMockRecorder) ReleaseLock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockStorager)(nil).ReleaseLock), arg0, arg1)
+}
+
+// RenewLock mocks base method.
+func (m *MockStorager) RenewLock(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewLock", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewLock indicates an expected call of RenewLock.
+func (mr *MockStoragerMockRecorder) RenewLock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewLock", reflect.TypeOf((*MockStorager)(nil).RenewLock), arg0, arg1)
+}
+
+// ReserveAttachment mocks base method.
+func (m *MockStorager) ReserveAttachment(arg0, arg1 string, arg2, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveAttachment indicates an expected call of ReserveAttachment.
+func (mr *MockStoragerMockRecorder) ReserveAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveAttachment", reflect.TypeOf((*MockStorager)(nil).ReserveAttachment), arg0, arg1, arg2, arg3)
+}
+
+// RestoreVersion mocks base method.
+func (m *MockStorager) RestoreVersion(arg0, arg1 string, arg2, arg3 int64) (string, int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVersion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].([]string)
 	ret3, _ := ret[3].(error)
 	return ret0, ret1, ret2, ret3
 }
 
-// RegisterUser indicates an expected call of RegisterUser.
-func (mr *MockStoragerMockRecorder) RegisterUser(arg0, arg1 interface{}) *gomock.Call {
+// RestoreVersion indicates an expected call of RestoreVersion.
+func (mr *MockStoragerMockRecorder) RestoreVersion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockStorager)(nil).RegisterUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVersion", reflect.TypeOf((*MockStorager)(nil).RestoreVersion), arg0, arg1, arg2, arg3)
+}
+
+// SetTOTP mocks base method.
+func (m *MockStorager) SetTOTP(arg0 string, arg1 []byte, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTOTP", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTOTP indicates an expected call of SetTOTP.
+func (mr *MockStoragerMockRecorder) SetTOTP(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTOTP", reflect.TypeOf((*MockStorager)(nil).SetTOTP), arg0, arg1, arg2)
+}
+
+// StaleAttachments mocks base method.
+func (m *MockStorager) StaleAttachments(arg0 string, arg1 time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaleAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StaleAttachments indicates an expected call of StaleAttachments.
+func (mr *MockStoragerMockRecorder) StaleAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaleAttachments", reflect.TypeOf((*MockStorager)(nil).StaleAttachments), arg0, arg1)
+}
+
+// SyncRecords mocks base method.
+func (m *MockStorager) SyncRecords(arg0, arg1 string, arg2 int64, arg3 []records.Record) (int64, []records.Record, []records.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncRecords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]records.Record)
+	ret2, _ := ret[2].([]records.Record)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// SyncRecords indicates an expected call of SyncRecords.
+func (mr *MockStoragerMockRecorder) SyncRecords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRecords", reflect.TypeOf((*MockStorager)(nil).SyncRecords), arg0, arg1, arg2, arg3)
+}
+
+// UpdateRecord mocks base method.
+func (m *MockStorager) UpdateRecord(arg0, arg1 string, arg2 records.Record) (records.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(records.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecord indicates an expected call of UpdateRecord.
+func (mr *MockStoragerMockRecorder) UpdateRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecord", reflect.TypeOf((*MockStorager)(nil).UpdateRecord), arg0, arg1, arg2)
 }
 
 // UpdateUserData mocks base method.
-func (m *MockStorager) UpdateUserData(arg0, arg1, arg2 string, arg3 []byte) (bool, string, error) {
+func (m *MockStorager) UpdateUserData(arg0, arg1 string, arg2 int64, arg3 []byte) (bool, string, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateUserData", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // UpdateUserData indicates an expected call of UpdateUserData.
@@ -123,13 +480,73 @@ func (mr *MockStoragerMockRecorder) UpdateUserData(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserData", reflect.TypeOf((*MockStorager)(nil).UpdateUserData), arg0, arg1, arg2, arg3)
 }
 
+// UseRecoveryCode mocks base method.
+func (m *MockStorager) UseRecoveryCode(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoragerMockRecorder) UseRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStorager)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockStorager) UseTOTPStep(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockStoragerMockRecorder) UseTOTPStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockStorager)(nil).UseTOTPStep), arg0, arg1)
+}
+
+// UserKeyParams mocks base method.
+func (m *MockStorager) UserKeyParams(arg0 string) (storage.UserKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserKeyParams", arg0)
+	ret0, _ := ret[0].(storage.UserKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserKeyParams indicates an expected call of UserKeyParams.
+func (mr *MockStoragerMockRecorder) UserKeyParams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserKeyParams", reflect.TypeOf((*MockStorager)(nil).UserKeyParams), arg0)
+}
+
+// UserTOTP mocks base method.
+func (m *MockStorager) UserTOTP(arg0 string) (storage.TOTPInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserTOTP", arg0)
+	ret0, _ := ret[0].(storage.TOTPInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserTOTP indicates an expected call of UserTOTP.
+func (mr *MockStoragerMockRecorder) UserTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserTOTP", reflect.TypeOf((*MockStorager)(nil).UserTOTP), arg0)
+}
+
 // UsersData mocks base method.
-func (m *MockStorager) UsersData(arg0 string) ([]byte, string, string, error) {
+func (m *MockStorager) UsersData(arg0 string) ([]byte, string, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UsersData", arg0)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(string)
+	ret2, _ := ret[2].(int64)
 	ret3, _ := ret[3].(error)
 	return ret0, ret1, ret2, ret3
 }
@@ -141,29 +558,30 @@ func (mr *MockStoragerMockRecorder) UsersData(arg0 interface{}) *gomock.Call {
 }
 
 // UsersDataLock mocks base method.
-func (m *MockStorager) UsersDataLock(arg0, arg1 string) (bool, string) {
+func (m *MockStorager) UsersDataLock(arg0 string, arg1 storage.LockInfo, arg2 bool) (bool, storage.LockInfo) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UsersDataLock", arg0, arg1)
+	ret := m.ctrl.Call(m, "UsersDataLock", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(string)
+	ret1, _ := ret[1].(storage.LockInfo)
 	return ret0, ret1
 }
 
 // UsersDataLock indicates an expected call of UsersDataLock.
-func (mr *MockStoragerMockRecorder) UsersDataLock(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockStoragerMockRecorder) UsersDataLock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersDataLock", reflect.TypeOf((*MockStorager)(nil).UsersDataLock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersDataLock", reflect.TypeOf((*MockStorager)(nil).UsersDataLock), arg0, arg1, arg2)
 }
 
 // UsersTimeStamp mocks base method.
-func (m *MockStorager) UsersTimeStamp(arg0 string) (string, bool, string, error) {
+func (m *MockStorager) UsersTimeStamp(arg0 string) (string, int64, bool, storage.LockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UsersTimeStamp", arg0)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(bool)
-	ret2, _ := ret[2].(string)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(storage.LockInfo)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
 }
 
 // UsersTimeStamp indicates an expected call of UsersTimeStamp.
@@ -0,0 +1,61 @@
+// Модуль описывает отдельные записи хранилища пользователя, общие для клиента и сервера.
+// Модуль упаковывает список записей в единый массив данных для совместимости с методами UserData и UpdateData.
+package records
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Типы записей пользователя.
+const (
+	TypePassword = "password"
+	TypeCard     = "card"
+	TypeText     = "text"
+	TypeBinary   = "binary"
+)
+
+// KnownType функция сообщает, известен ли тип записи этой версии программы.
+func KnownType(recordType string) bool {
+	switch recordType {
+	case TypePassword, TypeCard, TypeText, TypeBinary:
+		return true
+	}
+	return false
+}
+
+// packMagic префикс, отличающий упакованный список записей от единого зашифрованного массива данных.
+var packMagic = []byte("GKRECORDS1\n")
+
+// Record структура для хранения одной зашифрованной записи пользователя.
+type Record struct {
+	ID        string `json:"id"`         //Идентификатор записи
+	Type      string `json:"type"`       //Тип записи
+	Version   int64  `json:"version"`    //Версия записи на сервере
+	TimeStamp string `json:"time_stamp"` //Отметка времени последнего изменения записи
+	Data      []byte `json:"data"`       //Зашифрованные данные записи
+	Revision  int64  `json:"revision"`   //Ревизия данных пользователя, в которой запись изменена последний раз
+	Deleted   bool   `json:"deleted"`    //Отметка об удалении записи
+}
+
+// Pack функция упаковывает список записей в единый массив данных.
+func Pack(recs []Record) ([]byte, error) {
+	jsonBZ, err := json.Marshal(recs)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, packMagic...), jsonBZ...), nil
+}
+
+// Unpack функция распаковывает список записей. Возвращает false, если данные не являются упакованным списком записей.
+func Unpack(blob []byte) ([]Record, bool, error) {
+	if !bytes.HasPrefix(blob, packMagic) {
+		return nil, false, nil
+	}
+	var recs []Record
+	err := json.Unmarshal(blob[len(packMagic):], &recs)
+	if err != nil {
+		return nil, true, err
+	}
+	return recs, true, nil
+}
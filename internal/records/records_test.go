@@ -0,0 +1,41 @@
+package records
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		name string
+		recs []Record
+	}{
+		{
+			name: "test1 empty list",
+			recs: []Record{},
+		},
+		{
+			name: "test2 records",
+			recs: []Record{
+				{ID: "1", Type: TypePassword, Version: 1, TimeStamp: "2023-05-20T10:00:00+03:00", Data: []byte("pass")},
+				{ID: "2", Type: TypeBinary, Version: 3, TimeStamp: "2023-05-20T11:00:00+03:00", Data: []byte{0, 1, 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob, err := Pack(tt.recs)
+			require.NoError(t, err)
+			got, ok, err := Unpack(blob)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, tt.recs, got)
+		})
+	}
+
+	// Единый зашифрованный массив данных не является списком записей
+	_, ok, err := Unpack([]byte("legacy encrypted data"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,361 @@
+// Модуль предназначен для хранения вложений пользователей - больших файлов записей двоичных данных - вне основного хранилища.
+// Вложения хранятся в файлах каталога пользователя последовательностью зашифрованных клиентом частей,
+// сервер не может их расшифровать и только учитывает их объем в квоте пользователя.
+// Объем вложений учитывается в базе данных, поэтому квота соблюдается при работе нескольких экземпляров сервера.
+package attachments
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/crypto"
+)
+
+// MaxChunkSize максимальный размер одной зашифрованной части вложения.
+const MaxChunkSize = 1 << 20
+
+// lenghtAttachmentID длина идентификатора вложения.
+const lenghtAttachmentID = 32
+
+// chunkHeader размер заголовка части вложения в файле: длина части, uint32 big endian.
+const chunkHeader = 4
+
+// reserveChunks количество заголовков частей, резервируемых в квоте одним запросом к базе данных.
+const reserveChunks = 16
+
+// staleUpload время, после которого незавершенная загрузка считается прерванной и удаляется.
+const staleUpload = 24 * time.Hour
+
+// Ledger интерфейс учета объема вложений пользователей в базе данных.
+type Ledger interface {
+	ReserveAttachment(userID, attachmentID string, size, quota int64) error
+	CommitAttachment(userID, attachmentID string, size int64) error
+	ReleaseAttachment(userID, attachmentID string) error
+	ExpiredAttachments(userID string) ([]string, error)
+	DropAttachment(userID, attachmentID string) error
+	AttachmentsUsage(userID string) (int64, error)
+	StaleAttachments(userID string, before time.Time) ([]string, error)
+}
+
+// Store структура хранит вложения пользователей в каталоге dir и ограничивает их объем квотой.
+type Store struct {
+	dir    string
+	quota  int64
+	ledger Ledger
+}
+
+// NewStore функция создает хранилище вложений в каталоге dir с квотой quota байт на пользователя.
+// Объем вложений учитывается в ledger.
+func NewStore(dir string, quota int64, ledger Ledger) *Store {
+	return &Store{dir: dir, quota: quota, ledger: ledger}
+}
+
+// Quota метод возвращает допустимый объем вложений одного пользователя в байтах.
+func (s *Store) Quota() int64 {
+	return s.quota
+}
+
+// Usage метод возвращает объем, учтенный в квоте пользователя: сохраненные вложения с заголовками частей
+// и объем, зарезервированный незавершенными загрузками.
+func (s *Store) Usage(userID string) (int64, error) {
+	if !validID(userID) {
+		return 0, gkerrors.ErrNotAuth
+	}
+	return s.ledger.AttachmentsUsage(userID)
+}
+
+// Create метод начинает загрузку вложения из зашифрованных частей общим размером size байт.
+// Объем частей вместе с заголовками резервируется в квоте пользователя до завершения загрузки.
+// При превышении квоты возвращается ошибка ErrQuotaExceeded.
+func (s *Store) Create(userID string, size int64) (*Upload, error) {
+	if !validID(userID) {
+		return nil, gkerrors.ErrNotAuth
+	}
+	if size <= 0 {
+		return nil, gkerrors.ErrQuotaExceeded
+	}
+	id, err := crypto.RandomID(lenghtAttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	reserved := size + chunkHeader*reserveChunks
+	err = s.ledger.ReserveAttachment(userID, id, reserved, s.quota)
+	if err != nil {
+		return nil, err
+	}
+	userDir := filepath.Join(s.dir, userID)
+	err = os.MkdirAll(userDir, 0700)
+	var file *os.File
+	if err == nil {
+		file, err = os.OpenFile(filepath.Join(userDir, id)+".tmp", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	}
+	if err != nil {
+		s.drop(userID, id)
+		return nil, err
+	}
+	return &Upload{store: s, userID: userID, id: id, path: filepath.Join(userDir, id), file: file, out: bufio.NewWriter(file),
+		size: size, reserved: reserved}, nil
+}
+
+// Sweep метод удаляет освобожденные вложения пользователя, на которые больше не ссылается ни одна версия данных в истории,
+// и прерванные загрузки: файлы и учтенный в квоте объем. Запись в базе данных удаляется после файла,
+// поэтому при ошибке удаления файла вложение остается в квоте и удаляется при следующем вызове.
+func (s *Store) Sweep(userID string) error {
+	if !validID(userID) {
+		return gkerrors.ErrNotAuth
+	}
+	expired, err := s.ledger.ExpiredAttachments(userID)
+	if err != nil {
+		return err
+	}
+	for _, id := range expired {
+		err = os.Remove(filepath.Join(s.dir, userID, id))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		err = s.ledger.DropAttachment(userID, id)
+		if err != nil && !errors.Is(err, gkerrors.ErrNoSuchAttachment) {
+			return err
+		}
+	}
+	stale, err := s.ledger.StaleAttachments(userID, time.Now().Add(-staleUpload))
+	if err != nil {
+		return err
+	}
+	for _, id := range stale {
+		err = os.Remove(filepath.Join(s.dir, userID, id) + ".tmp")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		err = s.ledger.DropAttachment(userID, id)
+		if err != nil && !errors.Is(err, gkerrors.ErrNoSuchAttachment) {
+			return err
+		}
+	}
+	// Файлы частей без записи в базе данных остаются, если сервер остановился между удалением записи и файла
+	entries, err := os.ReadDir(filepath.Join(s.dir, userID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".tmp") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if time.Since(info.ModTime()) < staleUpload {
+			continue
+		}
+		err = os.Remove(filepath.Join(s.dir, userID, entry.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open метод открывает сохраненное вложение пользователя для чтения.
+// Если вложение не найдено, возвращается ошибка ErrNoSuchAttachment.
+func (s *Store) Open(userID, id string) (*Reader, error) {
+	if !validID(userID) || !validID(id) {
+		return nil, gkerrors.ErrNoSuchAttachment
+	}
+	file, err := os.Open(filepath.Join(s.dir, userID, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, gkerrors.ErrNoSuchAttachment
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Reader{file: file, in: bufio.NewReader(file), size: info.Size()}, nil
+}
+
+// Release метод освобождает вложение пользователя, на которое больше не ссылаются его текущие данные.
+// Файл вложения удаляется методом Sweep, когда на него не может ссылаться ни одна версия данных в истории.
+// Если вложение не найдено или уже освобождено, возвращается ошибка ErrNoSuchAttachment.
+func (s *Store) Release(userID, id string) error {
+	if !validID(userID) || !validID(id) {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	return s.ledger.ReleaseAttachment(userID, id)
+}
+
+// DeleteUser метод удаляет все вложения пользователя вместе с его каталогом.
+func (s *Store) DeleteUser(userID string) error {
+	if !validID(userID) {
+		return gkerrors.ErrNotAuth
+	}
+	return os.RemoveAll(filepath.Join(s.dir, userID))
+}
+
+// drop метод освобождает объем, зарезервированный в квоте пользователя для загрузки.
+// Ошибка не прерывает работу: прерванная загрузка будет удалена методом Sweep.
+func (s *Store) drop(userID, id string) {
+	err := s.ledger.DropAttachment(userID, id)
+	if err != nil {
+		log.Error().Err(err).Msgf("attachments DropAttachment error. userID = %s", userID)
+	}
+}
+
+// Upload структура загружаемого вложения. Вложение становится доступным для чтения после вызова Commit.
+type Upload struct {
+	store    *Store
+	userID   string
+	id       string
+	path     string
+	file     *os.File
+	out      *bufio.Writer
+	size     int64 //Объявленный общий размер частей вложения
+	received int64 //Общий размер полученных частей
+	written  int64 //Объем, записанный в файл, вместе с заголовками частей
+	reserved int64 //Объем, зарезервированный в квоте пользователя
+	done     bool
+}
+
+// ID метод возвращает идентификатор загружаемого вложения.
+func (u *Upload) ID() string {
+	return u.id
+}
+
+// Write метод записывает очередную часть вложения. Если записанный объем превышает объявленный,
+// возвращается ошибка ErrAttachmentSize. Если заголовки частей не помещаются в зарезервированный объем,
+// резерв увеличивается, при превышении квоты возвращается ошибка ErrQuotaExceeded.
+func (u *Upload) Write(chunk []byte) error {
+	if len(chunk) == 0 || len(chunk) > MaxChunkSize || u.received+int64(len(chunk)) > u.size {
+		return gkerrors.ErrAttachmentSize
+	}
+	need := u.written + chunkHeader + int64(len(chunk))
+	if need > u.reserved {
+		extra := need - u.reserved + chunkHeader*reserveChunks
+		err := u.store.ledger.ReserveAttachment(u.userID, u.id, extra, u.store.quota)
+		if err != nil {
+			return err
+		}
+		u.reserved += extra
+	}
+	var header [chunkHeader]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(chunk)))
+	_, err := u.out.Write(header[:])
+	if err != nil {
+		return err
+	}
+	_, err = u.out.Write(chunk)
+	if err != nil {
+		return err
+	}
+	u.received += int64(len(chunk))
+	u.written = need
+	return nil
+}
+
+// Commit метод завершает загрузку и сохраняет вложение. Если полученный объем не совпадает с объявленным,
+// загрузка отменяется и возвращается ошибка ErrAttachmentSize. В квоте пользователя остается объем, фактически записанный в файл.
+func (u *Upload) Commit() error {
+	if u.received != u.size {
+		u.Abort()
+		return gkerrors.ErrAttachmentSize
+	}
+	err := u.out.Flush()
+	if err == nil {
+		err = u.file.Sync()
+	}
+	if err == nil {
+		err = u.file.Close()
+	}
+	if err == nil {
+		err = os.Rename(u.path+".tmp", u.path)
+	}
+	if err != nil {
+		u.Abort()
+		return err
+	}
+	u.done = true
+	err = u.store.ledger.CommitAttachment(u.userID, u.id, u.written)
+	if err != nil {
+		os.Remove(u.path)
+		u.store.drop(u.userID, u.id)
+		return err
+	}
+	return nil
+}
+
+// Abort метод отменяет незавершенную загрузку и удаляет записанные части. После Commit метод ничего не делает.
+func (u *Upload) Abort() {
+	if u.done {
+		return
+	}
+	u.done = true
+	u.file.Close()
+	os.Remove(u.path + ".tmp")
+	u.store.drop(u.userID, u.id)
+}
+
+// Reader структура последовательного чтения частей сохраненного вложения.
+type Reader struct {
+	file *os.File
+	in   *bufio.Reader
+	size int64
+}
+
+// Size метод возвращает объем, занимаемый вложением в хранилище, с учетом заголовков частей.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// Next метод возвращает очередную часть вложения. После последней части возвращается ошибка io.EOF.
+func (r *Reader) Next() ([]byte, error) {
+	var header [chunkHeader]byte
+	_, err := io.ReadFull(r.in, header[:])
+	if err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(header[:])
+	if n == 0 || n > MaxChunkSize {
+		return nil, gkerrors.ErrAttachmentSize
+	}
+	chunk := make([]byte, n)
+	_, err = io.ReadFull(r.in, chunk)
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return chunk, err
+}
+
+// Close метод закрывает файл вложения.
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+// validID функция проверяет, что идентификатор можно использовать как имя файла:
+// он не пустой и состоит только из латинских букв и цифр.
+func validID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
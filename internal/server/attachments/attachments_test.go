@@ -0,0 +1,254 @@
+package attachments
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// memLedger структура учета объема вложений в памяти для тестов.
+// Вложения из history считаются используемыми версиями данных в истории.
+type memLedger struct {
+	mu       sync.Mutex
+	size     map[string]int64
+	stored   map[string]bool
+	released map[string]bool
+	history  map[string]bool
+	created  map[string]time.Time
+}
+
+func newMemLedger() *memLedger {
+	return &memLedger{size: make(map[string]int64), stored: make(map[string]bool), released: make(map[string]bool),
+		history: make(map[string]bool), created: make(map[string]time.Time)}
+}
+
+func (l *memLedger) ReserveAttachment(userID, attachmentID string, size, quota int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	used, _ := l.usage(userID)
+	if used+size > quota {
+		return gkerrors.ErrQuotaExceeded
+	}
+	key := userID + "/" + attachmentID
+	if l.stored[key] {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	if _, ok := l.size[key]; !ok {
+		l.created[key] = time.Now()
+	}
+	l.size[key] += size
+	return nil
+}
+
+func (l *memLedger) CommitAttachment(userID, attachmentID string, size int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := userID + "/" + attachmentID
+	if _, ok := l.size[key]; !ok || l.stored[key] {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	l.size[key], l.stored[key] = size, true
+	return nil
+}
+
+func (l *memLedger) ReleaseAttachment(userID, attachmentID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := userID + "/" + attachmentID
+	if !l.stored[key] || l.released[key] {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	l.released[key] = true
+	return nil
+}
+
+func (l *memLedger) ExpiredAttachments(userID string) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]string, 0)
+	for key := range l.released {
+		if filepath.Dir(key) == userID && !l.history[key] {
+			ids = append(ids, filepath.Base(key))
+		}
+	}
+	return ids, nil
+}
+
+func (l *memLedger) DropAttachment(userID, attachmentID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := userID + "/" + attachmentID
+	if _, ok := l.size[key]; !ok {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	delete(l.size, key)
+	delete(l.stored, key)
+	delete(l.released, key)
+	delete(l.created, key)
+	return nil
+}
+
+func (l *memLedger) AttachmentsUsage(userID string) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.usage(userID)
+}
+
+func (l *memLedger) usage(userID string) (int64, error) {
+	var used int64
+	for key, size := range l.size {
+		if filepath.Dir(key) == userID {
+			used += size
+		}
+	}
+	return used, nil
+}
+
+func (l *memLedger) StaleAttachments(userID string, before time.Time) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]string, 0)
+	for key, created := range l.created {
+		if filepath.Dir(key) == userID && !l.stored[key] && created.Before(before) {
+			ids = append(ids, filepath.Base(key))
+		}
+	}
+	return ids, nil
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	ledger := newMemLedger()
+	store := NewStore(dir, 200, ledger)
+
+	// Загрузка вложения из двух частей
+	upload, err := store.Create("user1", 60)
+	require.NoError(t, err)
+	require.NoError(t, upload.Write(make([]byte, 40)))
+	require.NoError(t, upload.Write([]byte("01234567890123456789")))
+
+	// Незавершенная загрузка резервирует объем вместе с заголовками частей и недоступна для чтения
+	_, err = store.Create("user1", 80)
+	require.ErrorIs(t, err, gkerrors.ErrQuotaExceeded)
+	_, err = store.Open("user1", upload.ID())
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+	require.NoError(t, upload.Commit())
+
+	// В квоте учитывается объем, фактически записанный в файл
+	used, err := store.Usage("user1")
+	require.NoError(t, err)
+	require.Equal(t, int64(60+2*chunkHeader), used)
+
+	reader, err := store.Open("user1", upload.ID())
+	require.NoError(t, err)
+	require.Equal(t, used, reader.Size())
+	chunk, err := reader.Next()
+	require.NoError(t, err)
+	require.Len(t, chunk, 40)
+	chunk, err = reader.Next()
+	require.NoError(t, err)
+	require.Equal(t, "01234567890123456789", string(chunk))
+	_, err = reader.Next()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, reader.Close())
+
+	// Вложение недоступно другому пользователю, идентификатор не может быть путем
+	_, err = store.Open("user2", upload.ID())
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+	_, err = store.Open("user1", "../user1/"+upload.ID())
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+
+	// Превышение объявленного размера и неполная загрузка отменяют загрузку
+	upload, err = store.Create("user1", 10)
+	require.NoError(t, err)
+	require.ErrorIs(t, upload.Write(make([]byte, 11)), gkerrors.ErrAttachmentSize)
+	require.NoError(t, upload.Write(make([]byte, 5)))
+	require.ErrorIs(t, upload.Commit(), gkerrors.ErrAttachmentSize)
+	files, err := os.ReadDir(filepath.Join(dir, "user1"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	used, err = store.Usage("user1")
+	require.NoError(t, err)
+	require.Equal(t, int64(60+2*chunkHeader), used)
+
+	// Заголовки многих маленьких частей не помещаются в резерв и упираются в квоту
+	upload, err = store.Create("user1", 60)
+	require.NoError(t, err)
+	for i := 0; i < 60; i++ {
+		err = upload.Write([]byte{1})
+		if err != nil {
+			break
+		}
+	}
+	require.ErrorIs(t, err, gkerrors.ErrQuotaExceeded)
+	upload.Abort()
+
+	// Освобожденное вложение хранится, пока на него ссылается версия данных в истории
+	id := files[0].Name()
+	ledger.history["user1/"+id] = true
+	require.NoError(t, store.Release("user1", id))
+	require.ErrorIs(t, store.Release("user1", id), gkerrors.ErrNoSuchAttachment)
+	require.NoError(t, store.Sweep("user1"))
+	reader, err = store.Open("user1", id)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	used, err = store.Usage("user1")
+	require.NoError(t, err)
+	require.Equal(t, int64(60+2*chunkHeader), used)
+
+	// После удаления версии из истории вложение удаляется и освобождает квоту
+	delete(ledger.history, "user1/"+id)
+	require.NoError(t, store.Sweep("user1"))
+	_, err = store.Open("user1", id)
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+	used, err = store.Usage("user1")
+	require.NoError(t, err)
+	require.Zero(t, used)
+
+	// Удаление пользователя удаляет его каталог
+	upload, err = store.Create("user1", 100)
+	require.NoError(t, err)
+	upload.Abort()
+	require.NoError(t, store.DeleteUser("user1"))
+	_, err = os.Stat(filepath.Join(dir, "user1"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSweep(t *testing.T) {
+	dir := t.TempDir()
+	ledger := newMemLedger()
+	store := NewStore(dir, 1000, ledger)
+
+	// Загрузка, прерванная остановкой сервера, оставляет файл частей и резерв квоты
+	upload, err := store.Create("user1", 100)
+	require.NoError(t, err)
+	require.NoError(t, upload.Write(make([]byte, 50)))
+	require.NoError(t, upload.out.Flush())
+	orphan := filepath.Join(dir, "user1", "orphan.tmp")
+	require.NoError(t, os.WriteFile(orphan, []byte("data"), 0600))
+
+	// Недавние загрузки не удаляются
+	require.NoError(t, store.Sweep("user1"))
+	used, err := store.Usage("user1")
+	require.NoError(t, err)
+	require.Positive(t, used)
+
+	old := time.Now().Add(-2 * staleUpload)
+	ledger.created["user1/"+upload.ID()] = old
+	require.NoError(t, os.Chtimes(orphan, old, old))
+	require.NoError(t, store.Sweep("user1"))
+	used, err = store.Usage("user1")
+	require.NoError(t, err)
+	require.Zero(t, used)
+	files, err := os.ReadDir(filepath.Join(dir, "user1"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+	upload.file.Close()
+}
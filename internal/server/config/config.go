@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 
@@ -16,6 +18,20 @@ type Config struct {
 	LenghtSesionID    int    `json:"lenghtsessionid"` //Длина токена SessionID
 	LenghtUserID      int    `json:"lenghtuserid"`    //Длина идентификатора userID
 	LockingTime       int    `json:"lockingtime"`     //Время блокировки на запись данных пользователем, в минутах
+	HistoryDepth      int    `json:"historydepth"`    //Количество хранимых предыдущих версий данных пользователя
+	PasswordTime      int    `json:"passwordtime"`    //Количество проходов argon2id при хэшировании паролей
+	PasswordMemory    int    `json:"passwordmemory"`  //Объем памяти argon2id при хэшировании паролей, в килобайтах
+	PasswordThreads   int    `json:"passwordthreads"` //Количество потоков argon2id при хэшировании паролей
+	SignWindow        int    `json:"signwindow"`      //Допустимое расхождение времени подписи запроса, в секундах
+	SessionStore      string `json:"sessionstore"`    //Хранилище сессий: memory или postgres
+	SessionKey        string `json:"sessionkey"`      //Мастер-ключ шифрования ключей сессий в базе данных, в hex
+	LoginAttempts     int    `json:"loginattempts"`   //Количество неудачных попыток входа с одним логином до временной блокировки
+	PeerAttempts      int    `json:"peerattempts"`    //Количество неудачных попыток входа с одного адреса до временной блокировки
+	LoginBackoff      int    `json:"loginbackoff"`    //Начальная задержка после неудачной попытки входа, удваивается с каждой попыткой, в секундах
+	LoginLockout      int    `json:"loginlockout"`    //Время временной блокировки входа после исчерпания попыток, в минутах
+	LoginSecret       string `json:"loginsecret"`     //Секрет вычисления параметров мастер-ключа для незарегистрированных логинов, в hex
+	AttachmentQuota   int    `json:"attachmentquota"` //Допустимый объем вложений одного пользователя в каталоге DatabaseDirectory, в мегабайтах
+	TOTPKey           string `json:"totpkey"`         //Мастер-ключ шифрования секретов TOTP в базе данных, в hex
 }
 
 // NewConfig считывает основные параметры и генерирует структуру Config.
@@ -72,6 +88,71 @@ func NewConfig() (*Config, error) {
 		config.LockingTime = 15
 		newConf = true
 	}
+	if config.HistoryDepth == 0 {
+		config.HistoryDepth = 10
+		newConf = true
+	}
+	if config.PasswordTime == 0 {
+		config.PasswordTime = 1
+		newConf = true
+	}
+	if config.PasswordMemory == 0 {
+		config.PasswordMemory = 64 * 1024
+		newConf = true
+	}
+	if config.PasswordThreads == 0 {
+		config.PasswordThreads = 4
+		newConf = true
+	}
+	if config.SignWindow == 0 {
+		config.SignWindow = 300
+		newConf = true
+	}
+	if config.SessionStore == "" {
+		config.SessionStore = "memory"
+		newConf = true
+	}
+	if config.SessionStore == "postgres" && config.SessionKey == "" {
+		config.SessionKey, err = randomKey()
+		if err != nil {
+			return nil, err
+		}
+		newConf = true
+	}
+	if config.LoginAttempts == 0 {
+		config.LoginAttempts = 5
+		newConf = true
+	}
+	if config.PeerAttempts == 0 {
+		config.PeerAttempts = 20
+		newConf = true
+	}
+	if config.LoginBackoff == 0 {
+		config.LoginBackoff = 1
+		newConf = true
+	}
+	if config.LoginLockout == 0 {
+		config.LoginLockout = 15
+		newConf = true
+	}
+	if config.LoginSecret == "" {
+		config.LoginSecret, err = randomKey()
+		if err != nil {
+			return nil, err
+		}
+		newConf = true
+	}
+	if config.AttachmentQuota == 0 {
+		config.AttachmentQuota = 100
+		newConf = true
+	}
+	if config.TOTPKey == "" {
+		config.TOTPKey, err = randomKey()
+		if err != nil {
+			return nil, err
+		}
+		newConf = true
+	}
 
 	if newConf {
 		bytes, err := json.Marshal(config)
@@ -79,7 +160,12 @@ func NewConfig() (*Config, error) {
 			log.Error().Err(err).Msg("NewConfig encoding to file err")
 			return nil, err
 		}
-		_, err = file.Write(bytes)
+		_, err = file.WriteAt(bytes, 0)
+		if err != nil {
+			log.Error().Err(err).Msg("NewConfig writing to file err")
+			return nil, err
+		}
+		err = file.Truncate(int64(len(bytes)))
 		if err != nil {
 			log.Error().Err(err).Msg("NewConfig writing to file err")
 			return nil, err
@@ -88,3 +174,13 @@ func NewConfig() (*Config, error) {
 
 	return &config, nil
 }
+
+// randomKey функция генерирует случайный 256-битный ключ в hex.
+func randomKey() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
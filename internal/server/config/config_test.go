@@ -24,6 +24,17 @@ func TestConfig(t *testing.T) {
 				LenghtSesionID:    16,
 				LenghtUserID:      12,
 				LockingTime:       15,
+				HistoryDepth:      10,
+				PasswordTime:      1,
+				PasswordMemory:    65536,
+				PasswordThreads:   4,
+				SignWindow:        300,
+				SessionStore:      "memory",
+				LoginAttempts:     5,
+				PeerAttempts:      20,
+				LoginBackoff:      1,
+				LoginLockout:      15,
+				AttachmentQuota:   100,
 			},
 		},
 		{
@@ -36,13 +47,39 @@ func TestConfig(t *testing.T) {
 				LenghtSesionID:    16,
 				LenghtUserID:      12,
 				LockingTime:       15,
+				HistoryDepth:      10,
+				PasswordTime:      1,
+				PasswordMemory:    65536,
+				PasswordThreads:   4,
+				SignWindow:        300,
+				SessionStore:      "memory",
+				LoginAttempts:     5,
+				PeerAttempts:      20,
+				LoginBackoff:      1,
+				LoginLockout:      15,
+				AttachmentQuota:   100,
 			},
 		},
 	}
+	var loginSecret, totpKey string
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NewConfig()
 			require.NoError(t, err)
+			// Секрет генерируется случайно при создании файла и затем читается из него
+			require.Len(t, got.LoginSecret, 64)
+			if loginSecret == "" {
+				loginSecret = got.LoginSecret
+			}
+			require.Equal(t, loginSecret, got.LoginSecret)
+			tt.want.LoginSecret = got.LoginSecret
+			require.Len(t, got.TOTPKey, 64)
+			if totpKey == "" {
+				totpKey = got.TOTPKey
+			}
+			require.Equal(t, totpKey, got.TOTPKey)
+			require.NotEqual(t, got.LoginSecret, got.TOTPKey)
+			tt.want.TOTPKey = got.TOTPKey
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetEnv() = %v, want %v", got, tt.want)
 			}
@@ -50,3 +87,18 @@ func TestConfig(t *testing.T) {
 	}
 
 }
+
+func TestConfigSessionKey(t *testing.T) {
+	err := os.WriteFile("config.json", []byte(`{"sessionstore":"postgres"}`), 0777)
+	require.NoError(t, err)
+	got, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, "postgres", got.SessionStore)
+	require.Len(t, got.SessionKey, 64)
+
+	// Сгенерированный ключ сохраняется в файл конфигурации
+	again, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, got, again)
+	os.Remove("config.json")
+}
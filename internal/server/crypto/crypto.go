@@ -1,37 +1,30 @@
 package crypto
 
 import (
-	"crypto"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/sha256"
+	"errors"
 	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
 	"gophkeeper/internal/server/config"
+	"gophkeeper/internal/sign"
 	"strings"
-	"sync"
 	"time"
-)
 
-// Session структура для хранения данных одной сессии.
-type Session struct {
-	userID        string
-	userPublicKey *rsa.PublicKey
-	privateKey    *rsa.PrivateKey
-	expires       time.Time
-}
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
 
 // Sessions структура для хранения оперативных данных.
 type Sessions struct {
-	cfg      *config.Config
-	sessions map[string]Session
-	sync.RWMutex
+	cfg   *config.Config
+	store SessionStore
 }
 
 // NewSessions функция генерирует структуру хранения оперативных данных клиентов.
-func NewSessions(cfg *config.Config) *Sessions {
+// Сессии хранятся в переданном хранилище: в памяти процесса или в базе данных, общей для нескольких экземпляров сервера.
+func NewSessions(cfg *config.Config, store SessionStore) *Sessions {
 	s := Sessions{
-		cfg:      cfg,
-		sessions: make(map[string]Session),
+		cfg:   cfg,
+		store: store,
 	}
 	s.sessionsCleaner(cfg.Expires)
 	return &s
@@ -43,141 +36,204 @@ func (s *Sessions) sessionsCleaner(period int) {
 		defer ticker.Stop()
 		for {
 			<-ticker.C
-			s.Lock()
-			for i, v := range s.sessions {
-				if !v.expires.After(time.Now()) {
-					delete(s.sessions, i)
-				}
+			err := s.store.DeleteExpiredSessions()
+			if err != nil {
+				log.Error().Err(err).Msg("sessionsCleaner DeleteExpiredSessions error")
 			}
-			s.Unlock()
 		}
 	}()
 }
 
-// NewSessionID метод генерирует асимметричный ключ и сохраняет новую сессию
-func (s *Sessions) NewSessionID(userKey *rsa.PublicKey) (string, *rsa.PublicKey, error) {
-	sessionID := RandomID(s.cfg.LenghtSesionID)
-	privateKey, err := GenerateKeys()
+// NewSessionID метод генерирует эфемерный ключ X25519, вычисляет по открытому ключу клиента ключи сессии и сохраняет новую сессию
+// с именем устройства, версией приложения и адресом клиента. Возвращает идентификатор сессии и открытый ключ сервера.
+func (s *Sessions) NewSessionID(userKey []byte, deviceName, clientVersion, remoteAddr string) (string, []byte, error) {
+	keyPair, err := handshake.NewKeyPair()
 	if err != nil {
 		return "", nil, err
 	}
-	publicKey := &privateKey.PublicKey
-	s.Lock()
-	s.sessions[sessionID] = Session{userPublicKey: userKey, privateKey: privateKey, expires: time.Now().Add(time.Hour * time.Duration(s.cfg.Expires))}
-	s.Unlock()
-	return sessionID, publicKey, nil
+	keys, err := keyPair.ServerKeys(userKey)
+	if err != nil {
+		return "", nil, err
+	}
+	sessionID, err := RandomID(s.cfg.LenghtSesionID)
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	err = s.store.SaveSession(sessionID, Session{
+		Keys:          keys,
+		Expires:       now.Add(time.Hour * time.Duration(s.cfg.Expires)),
+		Created:       now,
+		LastSeen:      now,
+		DeviceName:    deviceName,
+		ClientVersion: clientVersion,
+		RemoteAddr:    remoteAddr,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return sessionID, keyPair.Public, nil
 }
 
-// CheckSign метод проверяет подпись и валидность сессии клиента
-func (s *Sessions) CheckSign(sessionID string, userSignBZ []byte) (string, error) {
-	s.RLock()
-	session, ok := s.sessions[sessionID]
-	s.RUnlock()
-	if !ok {
-		return "", gkerrors.ErrExpired
+// CheckSign метод проверяет подпись запроса и валидность сессии клиента.
+// Подпись вычисляется от имени метода, сообщения запроса, времени подписи и номера запроса.
+// Время подписи должно отличаться от времени сервера не более чем на signwindow секунд, номер запроса не должен повторяться.
+func (s *Sessions) CheckSign(sessionID, method string, timestamp int64, nonce uint64, req proto.Message, userSignBZ []byte) (string, error) {
+	session, err := s.store.LoadSession(sessionID)
+	if err != nil {
+		return "", err
 	}
-	if !session.expires.After(time.Now()) {
-		s.Lock()
-		delete(s.sessions, sessionID)
-		s.Unlock()
+	now := time.Now()
+	if !session.Expires.After(now) {
+		err = s.store.DeleteSession(sessionID)
+		if err != nil {
+			log.Error().Err(err).Msg("CheckSign DeleteSession error")
+		}
 		return "", gkerrors.ErrExpired
 	}
-	var opts rsa.PSSOptions
-	opts.SaltLength = rsa.PSSSaltLengthAuto
-	newhash := crypto.SHA256
-	pssh := newhash.New()
-	pssh.Write([]byte(``))
-	hashed := pssh.Sum(nil)
-	err := rsa.VerifyPSS(session.userPublicKey, newhash, hashed, userSignBZ, &opts)
+	window := time.Second * time.Duration(s.cfg.SignWindow)
+	signed := time.UnixMilli(timestamp)
+	if signed.Before(now.Add(-window)) || signed.After(now.Add(window)) {
+		return "", gkerrors.ErrSignExpired
+	}
+	hashed, err := sign.RequestDigest(method, sessionID, timestamp, nonce, req)
 	if err != nil {
+		return "", err
+	}
+	if !handshake.Verify(session.Keys.ClientSign, hashed, userSignBZ) {
 		return "", gkerrors.ErrSignIncorrect
 	}
-	s.RLock()
-	userID := s.sessions[sessionID].userID
-	s.RUnlock()
-	return userID, nil
+	err = s.store.AddNonce(sessionID, nonce, signed, window)
+	if err != nil {
+		return "", err
+	}
+	return session.UserID, nil
 }
 
+// GetUserID метод возвращает userID аутентифицированного клиента или пустую строку.
 func (s *Sessions) GetUserID(sessionID string) string {
-	s.RLock()
-	userID := s.sessions[sessionID].userID
-	s.RUnlock()
-	return userID
+	session, err := s.store.LoadSession(sessionID)
+	if err != nil {
+		return ""
+	}
+	return session.UserID
+}
+
+// SessionInfo метод возвращает данные сессии клиента.
+func (s *Sessions) SessionInfo(sessionID string) (Session, error) {
+	return s.store.LoadSession(sessionID)
+}
+
+// sessionKeys метод возвращает ключи сессии клиента.
+func (s *Sessions) sessionKeys(sessionID string) (handshake.Keys, error) {
+	session, err := s.store.LoadSession(sessionID)
+	if err != nil {
+		return handshake.Keys{}, err
+	}
+	return session.Keys, nil
 }
 
 // DecryptLogin метод расшифровывает логин и пароль пользователя
 func (s *Sessions) DecryptLogin(sessionID string, userLoginBZ []byte) (string, string, error) {
-	s.RLock()
-	privateKey := s.sessions[sessionID].privateKey
-	s.RUnlock()
-	hash := sha256.New()
-	text, err := rsa.DecryptOAEP(hash, rand.Reader, privateKey, userLoginBZ, []byte(`login`))
+	text, err := s.DecryptPassword(sessionID, userLoginBZ, []byte(`login`))
 	if err != nil {
 		return "", "", err
 	}
-	login, pass, found := strings.Cut(string(text), ",")
+	login, pass, found := strings.Cut(text, ",")
 	if !found {
 		return "", "", gkerrors.ErrLoginIncorrect
 	}
-	return login, HashPasswd(pass), nil
+	return login, pass, nil
 }
 
 // EncryptData метод зашифровывает сообщение перед отправкой
 func (s *Sessions) EncryptData(sessionID, message string, label []byte) ([]byte, error) {
-	s.RLock()
-	userPublicKey := s.sessions[sessionID].userPublicKey
-	s.RUnlock()
-	hash := sha256.New()
-	ciphertext, err := rsa.EncryptOAEP(hash, rand.Reader, userPublicKey, []byte(message), label)
+	keys, err := s.sessionKeys(sessionID)
 	if err != nil {
 		return nil, err
 	}
-	return ciphertext, nil
+	return handshake.Seal(keys.ServerKey, []byte(message), label)
 }
 
 // DecryptPassword метод расшифровывает полученное сообщение
 func (s *Sessions) DecryptPassword(sessionID string, messageBZ, label []byte) (string, error) {
-	s.RLock()
-	privateKey := s.sessions[sessionID].privateKey
-	s.RUnlock()
-	hash := sha256.New()
-	message, err := rsa.DecryptOAEP(hash, rand.Reader, privateKey, messageBZ, label)
+	keys, err := s.sessionKeys(sessionID)
+	if err != nil {
+		return "", err
+	}
+	message, err := handshake.Open(keys.ClientKey, messageBZ, label)
 	if err != nil {
 		return "", err
 	}
-	return HashPasswd(string(message)), nil
+	return string(message), nil
 }
 
 // SignData метод создает подпись сервера для отправки сообщений
 func (s *Sessions) SignData(sessionID string) ([]byte, error) {
-	var opts rsa.PSSOptions
-	opts.SaltLength = rsa.PSSSaltLengthAuto
-	newhash := crypto.SHA256
-	pssh := newhash.New()
-	pssh.Write([]byte(``))
-	hashed := pssh.Sum(nil)
-	s.RLock()
-	privateKey := s.sessions[sessionID].privateKey
-	s.RUnlock()
-	sign, err := rsa.SignPSS(rand.Reader, privateKey, newhash, hashed, &opts)
+	keys, err := s.sessionKeys(sessionID)
 	if err != nil {
 		return nil, err
 	}
-	return sign, nil
+	return handshake.Sign(keys.ServerSign, []byte(``)), nil
+}
+
+// TouchSession метод отмечает время последнего запроса клиента и адрес, с которого он отправлен.
+func (s *Sessions) TouchSession(sessionID, remoteAddr string) error {
+	return s.store.TouchSession(sessionID, time.Now(), remoteAddr)
+}
+
+// UserSessions метод возвращает действующие сессии пользователя по их идентификаторам.
+// Для неаутентифицированного клиента возвращается ошибка ErrNotAuth.
+func (s *Sessions) UserSessions(userID string) (map[string]Session, error) {
+	if userID == "" {
+		return nil, gkerrors.ErrNotAuth
+	}
+	return s.store.UserSessions(userID)
+}
+
+// RevokeSession метод завершает сессию пользователя. Пользователь может завершить только свою сессию.
+// Сессии, в которых пользователь не аутентифицирован, не завершаются.
+func (s *Sessions) RevokeSession(userID, sessionID string) error {
+	if userID == "" {
+		return gkerrors.ErrNoSuchSession
+	}
+	session, err := s.store.LoadSession(sessionID)
+	if errors.Is(err, gkerrors.ErrExpired) {
+		return gkerrors.ErrNoSuchSession
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return gkerrors.ErrNoSuchSession
+	}
+	return s.store.DeleteSession(sessionID)
+}
+
+// DeleteUserSessions метод завершает все сессии пользователя, в том числе текущую.
+func (s *Sessions) DeleteUserSessions(userID string) error {
+	if userID == "" {
+		return gkerrors.ErrNotAuth
+	}
+	sessions, err := s.store.UserSessions(userID)
+	if err != nil {
+		return err
+	}
+	for sessionID := range sessions {
+		err = s.store.DeleteSession(sessionID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // AddUserID метод добавляет userID в сессию клиента после его аутентификации
-func (s *Sessions) AddUserID(sessionID, userID string) {
-	s.Lock()
-	user := s.sessions[sessionID]
-	user.userID = userID
-	s.sessions[sessionID] = user
-	s.Unlock()
+func (s *Sessions) AddUserID(sessionID, userID string) error {
+	return s.store.SetSessionUser(sessionID, userID)
 }
 
 // UserLogOut метод удаляет сессию клиента
-func (s *Sessions) UserLogOut(sessionID string) {
-	s.Lock()
-	delete(s.sessions, sessionID)
-	s.Unlock()
+func (s *Sessions) UserLogOut(sessionID string) error {
+	return s.store.DeleteSession(sessionID)
 }
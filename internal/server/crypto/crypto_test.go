@@ -0,0 +1,179 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+	"gophkeeper/internal/server/config"
+	"gophkeeper/internal/sign"
+)
+
+func TestCheckSign(t *testing.T) {
+	s := NewSessions(&config.Config{Expires: 2, LenghtSesionID: 16, SignWindow: 60}, NewMemoryStore())
+	userKey, err := handshake.NewKeyPair()
+	require.NoError(t, err)
+	sessionID, serverKey, err := s.NewSessionID(userKey.Public, "desktop", "v0.0.1", "127.0.0.1:5000")
+	require.NoError(t, err)
+	keys, err := userKey.ClientKeys(serverKey)
+	require.NoError(t, err)
+
+	const method = "/grpc.GophKeeper/UserData"
+	req := &pb.UserDataRequest{SessionID: sessionID}
+	signRequest := func(method string, timestamp int64, nonce uint64) []byte {
+		hashed, err := sign.RequestDigest(method, sessionID, timestamp, nonce, req)
+		require.NoError(t, err)
+		return handshake.Sign(keys.ClientSign, hashed)
+	}
+	now := time.Now().UnixMilli()
+
+	userSign := signRequest(method, now, 1)
+	_, err = s.CheckSign(sessionID, method, now, 1, req, userSign)
+	require.NoError(t, err)
+
+	// Повтор запроса с тем же номером
+	_, err = s.CheckSign(sessionID, method, now, 1, req, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrReplay)
+
+	// Подпись другого метода
+	userSign = signRequest("/grpc.GophKeeper/DeleteRecord", now, 2)
+	_, err = s.CheckSign(sessionID, method, now, 2, req, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrSignIncorrect)
+
+	// Измененное сообщение запроса
+	userSign = signRequest(method, now, 3)
+	_, err = s.CheckSign(sessionID, method, now, 3, &pb.UserDataRequest{SessionID: "other"}, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrSignIncorrect)
+
+	// Время подписи за пределами окна
+	old := time.Now().Add(-2 * time.Minute).UnixMilli()
+	userSign = signRequest(method, old, 4)
+	_, err = s.CheckSign(sessionID, method, old, 4, req, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrSignExpired)
+
+	userSign = signRequest(method, now, 5)
+	_, err = s.CheckSign("unknown", method, now, 5, req, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrExpired)
+	_, err = s.CheckSign(sessionID, method, now, 5, req, userSign)
+	require.NoError(t, err)
+}
+
+func TestSessionData(t *testing.T) {
+	s := NewSessions(&config.Config{Expires: 2, LenghtSesionID: 16, SignWindow: 60}, NewMemoryStore())
+	userKey, err := handshake.NewKeyPair()
+	require.NoError(t, err)
+	sessionID, serverKey, err := s.NewSessionID(userKey.Public, "desktop", "v0.0.1", "127.0.0.1:5000")
+	require.NoError(t, err)
+	keys, err := userKey.ClientKeys(serverKey)
+	require.NoError(t, err)
+
+	loginBZ, err := handshake.Seal(keys.ClientKey, []byte("user,pass"), []byte("login"))
+	require.NoError(t, err)
+	login, pass, err := s.DecryptLogin(sessionID, loginBZ)
+	require.NoError(t, err)
+	require.Equal(t, "user", login)
+	require.Equal(t, "pass", pass)
+	_, err = s.DecryptPassword(sessionID, loginBZ, []byte("oldPass"))
+	require.Error(t, err)
+
+	userIDBZ, err := s.EncryptData(sessionID, "userID", []byte("userID"))
+	require.NoError(t, err)
+	userID, err := handshake.Open(keys.ServerKey, userIDBZ, []byte("userID"))
+	require.NoError(t, err)
+	require.Equal(t, "userID", string(userID))
+
+	serverSign, err := s.SignData(sessionID)
+	require.NoError(t, err)
+	require.True(t, handshake.Verify(keys.ServerSign, []byte(``), serverSign))
+
+	_, _, err = s.NewSessionID([]byte("short"), "", "", "")
+	require.ErrorIs(t, err, gkerrors.ErrPublicKeyIncorrect)
+	_, err = s.EncryptData("unknown", "userID", []byte("userID"))
+	require.ErrorIs(t, err, gkerrors.ErrExpired)
+}
+
+func TestSharedStore(t *testing.T) {
+	cfg := &config.Config{Expires: 2, LenghtSesionID: 16, SignWindow: 60}
+	store := NewMemoryStore()
+	first := NewSessions(cfg, store)
+	second := NewSessions(cfg, store)
+
+	userKey, err := handshake.NewKeyPair()
+	require.NoError(t, err)
+	sessionID, serverKey, err := first.NewSessionID(userKey.Public, "desktop", "v0.0.1", "127.0.0.1:5000")
+	require.NoError(t, err)
+	keys, err := userKey.ClientKeys(serverKey)
+	require.NoError(t, err)
+	require.NoError(t, first.AddUserID(sessionID, "userID"))
+
+	const method = "/grpc.GophKeeper/UserData"
+	req := &pb.UserDataRequest{SessionID: sessionID}
+	now := time.Now().UnixMilli()
+	hashed, err := sign.RequestDigest(method, sessionID, now, 1, req)
+	require.NoError(t, err)
+	userSign := handshake.Sign(keys.ClientSign, hashed)
+
+	// Сессия, созданная одним экземпляром сервера, принимается другим
+	userID, err := second.CheckSign(sessionID, method, now, 1, req, userSign)
+	require.NoError(t, err)
+	require.Equal(t, "userID", userID)
+	require.Equal(t, "userID", first.GetUserID(sessionID))
+
+	// Повтор запроса отклоняется любым экземпляром сервера
+	_, err = first.CheckSign(sessionID, method, now, 1, req, userSign)
+	require.ErrorIs(t, err, gkerrors.ErrReplay)
+
+	require.NoError(t, second.UserLogOut(sessionID))
+	require.Equal(t, "", first.GetUserID(sessionID))
+	require.ErrorIs(t, first.AddUserID(sessionID, "userID"), gkerrors.ErrExpired)
+
+	require.NoError(t, store.SaveSession("expired", Session{Keys: keys, Expires: time.Now().Add(-time.Minute)}))
+	require.NoError(t, store.DeleteExpiredSessions())
+	_, err = store.LoadSession("expired")
+	require.ErrorIs(t, err, gkerrors.ErrExpired)
+}
+
+func TestRevokeSession(t *testing.T) {
+	s := NewSessions(&config.Config{Expires: 2, LenghtSesionID: 16, SignWindow: 60}, NewMemoryStore())
+	userKey, err := handshake.NewKeyPair()
+	require.NoError(t, err)
+	first, _, err := s.NewSessionID(userKey.Public, "desktop", "v0.0.1", "127.0.0.1:5000")
+	require.NoError(t, err)
+	second, _, err := s.NewSessionID(userKey.Public, "laptop", "v0.0.2", "127.0.0.1:5001")
+	require.NoError(t, err)
+	other, _, err := s.NewSessionID(userKey.Public, "phone", "v0.0.1", "127.0.0.1:5002")
+	require.NoError(t, err)
+	require.NoError(t, s.AddUserID(first, "userID"))
+	require.NoError(t, s.AddUserID(second, "userID"))
+	require.NoError(t, s.AddUserID(other, "otherID"))
+
+	require.NoError(t, s.TouchSession(second, "127.0.0.1:6000"))
+	sessions, err := s.UserSessions("userID")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, "v0.0.2", sessions[second].ClientVersion)
+	require.Equal(t, "127.0.0.1:6000", sessions[second].RemoteAddr)
+	require.False(t, sessions[second].LastSeen.Before(sessions[second].Created))
+
+	// Сессию другого пользователя завершить нельзя
+	require.ErrorIs(t, s.RevokeSession("userID", other), gkerrors.ErrNoSuchSession)
+	require.NoError(t, s.RevokeSession("userID", second))
+	require.ErrorIs(t, s.RevokeSession("userID", second), gkerrors.ErrNoSuchSession)
+	sessions, err = s.UserSessions("userID")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	// Без аутентификации нельзя получить или завершить сессии, в которых пользователь не вошел
+	anonymous, _, err := s.NewSessionID(userKey.Public, "unknown", "v0.0.1", "127.0.0.1:5003")
+	require.NoError(t, err)
+	_, err = s.UserSessions("")
+	require.ErrorIs(t, err, gkerrors.ErrNotAuth)
+	require.ErrorIs(t, s.RevokeSession("", anonymous), gkerrors.ErrNoSuchSession)
+	require.ErrorIs(t, s.DeleteUserSessions(""), gkerrors.ErrNotAuth)
+	_, err = s.SessionInfo(anonymous)
+	require.NoError(t, err)
+}
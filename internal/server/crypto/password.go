@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"gophkeeper/internal/server/config"
+)
+
+// passwordPrefix префикс версии формата хэша пароля argon2id.
+const passwordPrefix = "$argon2id$"
+
+// PasswordParams структура хранит параметры хэширования паролей argon2id.
+type PasswordParams struct {
+	Time    uint32 //Количество проходов
+	Memory  uint32 //Объем памяти, в килобайтах
+	Threads uint8  //Количество потоков
+	SaltLen uint32 //Длина соли, в байтах
+	KeyLen  uint32 //Длина хэша, в байтах
+}
+
+// NewPasswordParams функция формирует параметры хэширования паролей из конфигурации сервера.
+func NewPasswordParams(cfg *config.Config) PasswordParams {
+	return PasswordParams{
+		Time:    uint32(cfg.PasswordTime),
+		Memory:  uint32(cfg.PasswordMemory),
+		Threads: uint8(cfg.PasswordThreads),
+		SaltLen: 16,
+		KeyLen:  32,
+	}
+}
+
+// NewPasswordHash функция хэширует пароль клиента алгоритмом argon2id со случайной солью.
+// Результат содержит версию формата, параметры, соль и хэш: $argon2id$v=19$m=65536,t=1,p=4$<соль>$<хэш>.
+func NewPasswordHash(password string, params PasswordParams) (string, error) {
+	salt := make([]byte, params.SaltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", passwordPrefix, argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPasswd функция сравнивает пароль клиента с сохраненным хэшем за постоянное время.
+// Второе значение сообщает, что хэш сохранен в устаревшем формате или с устаревшими параметрами и его нужно пересчитать.
+func CheckPasswd(password, encoded string, params PasswordParams) (bool, bool) {
+	if !strings.HasPrefix(encoded, passwordPrefix) {
+		legacy := HashPasswd(password)
+		return subtle.ConstantTimeCompare([]byte(legacy), []byte(encoded)) == 1, true
+	}
+	var version int
+	var stored PasswordParams
+	parts := strings.Split(strings.TrimPrefix(encoded, passwordPrefix), "$")
+	if len(parts) != 4 {
+		return false, false
+	}
+	_, err := fmt.Sscanf(parts[0], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return false, false
+	}
+	_, err = fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &stored.Memory, &stored.Time, &stored.Threads)
+	if err != nil {
+		return false, false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, false
+	}
+	check := argon2.IDKey([]byte(password), salt, stored.Time, stored.Memory, stored.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(check, key) != 1 {
+		return false, false
+	}
+	rehash := stored.Time != params.Time || stored.Memory != params.Memory || stored.Threads != params.Threads ||
+		uint32(len(salt)) != params.SaltLen || uint32(len(key)) != params.KeyLen
+	return true, rehash
+}
@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordHash(t *testing.T) {
+	params := PasswordParams{Time: 1, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+	hash, err := NewPasswordHash("123", params)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	other, err := NewPasswordHash("123", params)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other)
+
+	ok, rehash := CheckPasswd("123", hash, params)
+	require.True(t, ok)
+	require.False(t, rehash)
+	ok, _ = CheckPasswd("456", hash, params)
+	require.False(t, ok)
+
+	// Изменение параметров требует пересчета хэша
+	ok, rehash = CheckPasswd("123", hash, PasswordParams{Time: 2, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32})
+	require.True(t, ok)
+	require.True(t, rehash)
+
+	// Устаревший хэш SHA-256
+	ok, rehash = CheckPasswd("123", HashPasswd("123"), params)
+	require.True(t, ok)
+	require.True(t, rehash)
+	ok, _ = CheckPasswd("456", HashPasswd("123"), params)
+	require.False(t, ok)
+
+	ok, _ = CheckPasswd("123", "$argon2id$broken", params)
+	require.False(t, ok)
+}
@@ -2,14 +2,12 @@ package crypto
 
 import (
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/hex"
-	math "math/rand"
-	"time"
 )
 
-// HashPasswd функция хэширует пароль клиента для хранения на сервере
+// HashPasswd функция вычисляет устаревший хэш SHA-256 пароля клиента.
+// Используется только для проверки паролей, сохраненных до перехода на argon2id.
 func HashPasswd(password string) string {
 	hash := sha256.New()
 	hash.Write([]byte(password))
@@ -17,33 +15,28 @@ func HashPasswd(password string) string {
 	return hex.EncodeToString(dst)
 }
 
-// RandomID функция генерирует рандомный идентификатор требуемой длины
-func RandomID(n int) string {
+// RandomID функция генерирует случайный идентификатор требуемой длины из букв и цифр.
+// Используется криптографически стойкий генератор, байты вне кратного длине алфавита диапазона отбрасываются,
+// поэтому все символы алфавита равновероятны.
+func RandomID(n int) (string, error) {
 	const letterBytes = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	bts := make([]byte, n)
-	math.Seed(time.Now().UnixNano())
-	for i := 0; i < n; i++ {
-		bts[i] = letterBytes[math.Intn(len(letterBytes))]
+	const maxByte = 256 - 256%len(letterBytes)
+	bts := make([]byte, 0, n)
+	buf := make([]byte, n)
+	for len(bts) < n {
+		_, err := rand.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) >= maxByte {
+				continue
+			}
+			bts = append(bts, letterBytes[int(b)%len(letterBytes)])
+			if len(bts) == n {
+				break
+			}
+		}
 	}
-	return string(bts)
-}
-
-// NewSymmetricalKey функция создает симметричный ключ клиента
-func NewSymmetricalKey(userID string) string {
-	if len(userID) >= 28 {
-		userID = userID[:16]
-	}
-	salt := RandomID(32 - len(userID))
-	key := []byte(userID + salt)
-	nonce := RandomID(12)
-	return string(key) + nonce
-}
-
-// GenerateKeys функция генерирует несимметричный ключ сессии пользователя
-func GenerateKeys() (*rsa.PrivateKey, error) {
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, err
-	}
-	return privateKey, nil
+	return string(bts), nil
 }
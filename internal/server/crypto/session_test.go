@@ -0,0 +1,20 @@
+package crypto
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-zA-Z]{32}$`)
+	ids := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		id, err := RandomID(32)
+		require.NoError(t, err)
+		require.Regexp(t, pattern, id)
+		ids[id] = struct{}{}
+	}
+	require.Len(t, ids, 100)
+}
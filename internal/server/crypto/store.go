@@ -0,0 +1,153 @@
+package crypto
+
+import (
+	"sync"
+	"time"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+)
+
+// Session структура для хранения данных одной сессии.
+type Session struct {
+	UserID        string         //Идентификатор пользователя, пустой до аутентификации
+	Keys          handshake.Keys //Ключи шифрования и подписи сессии
+	Expires       time.Time      //Время окончания сессии
+	Created       time.Time      //Время создания сессии
+	LastSeen      time.Time      //Время последнего запроса в сессии
+	DeviceName    string         //Имя устройства клиента
+	ClientVersion string         //Версия приложения клиента
+	RemoteAddr    string         //Адрес, с которого подключен клиент
+}
+
+// SessionStore интерфейс хранилища сессий клиентов.
+type SessionStore interface {
+	SaveSession(string, Session) error
+	LoadSession(string) (Session, error)
+	SetSessionUser(string, string) error
+	TouchSession(string, time.Time, string) error
+	UserSessions(string) (map[string]Session, error)
+	DeleteSession(string) error
+	DeleteExpiredSessions() error
+	AddNonce(string, uint64, time.Time, time.Duration) error
+}
+
+// MemoryStore структура для хранения сессий в памяти процесса сервера.
+type MemoryStore struct {
+	sessions map[string]Session
+	nonces   map[string]map[uint64]time.Time //Номера запросов сессий, полученных в пределах окна подписи, и время их подписи
+	sync.RWMutex
+}
+
+// NewMemoryStore функция генерирует хранилище сессий в памяти.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]Session),
+		nonces:   make(map[string]map[uint64]time.Time),
+	}
+}
+
+// SaveSession метод сохраняет новую сессию.
+func (m *MemoryStore) SaveSession(sessionID string, session Session) error {
+	m.Lock()
+	m.sessions[sessionID] = session
+	m.nonces[sessionID] = make(map[uint64]time.Time)
+	m.Unlock()
+	return nil
+}
+
+// LoadSession метод возвращает сессию по идентификатору.
+func (m *MemoryStore) LoadSession(sessionID string) (Session, error) {
+	m.RLock()
+	session, ok := m.sessions[sessionID]
+	m.RUnlock()
+	if !ok {
+		return Session{}, gkerrors.ErrExpired
+	}
+	return session, nil
+}
+
+// SetSessionUser метод добавляет userID в сессию.
+func (m *MemoryStore) SetSessionUser(sessionID, userID string) error {
+	m.Lock()
+	defer m.Unlock()
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return gkerrors.ErrExpired
+	}
+	session.UserID = userID
+	m.sessions[sessionID] = session
+	return nil
+}
+
+// TouchSession метод обновляет время последнего запроса и адрес клиента в сессии.
+func (m *MemoryStore) TouchSession(sessionID string, lastSeen time.Time, remoteAddr string) error {
+	m.Lock()
+	defer m.Unlock()
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return gkerrors.ErrExpired
+	}
+	session.LastSeen = lastSeen
+	if remoteAddr != "" {
+		session.RemoteAddr = remoteAddr
+	}
+	m.sessions[sessionID] = session
+	return nil
+}
+
+// UserSessions метод возвращает действующие сессии пользователя по их идентификаторам.
+func (m *MemoryStore) UserSessions(userID string) (map[string]Session, error) {
+	sessions := make(map[string]Session)
+	m.RLock()
+	for i, v := range m.sessions {
+		if v.UserID == userID && v.Expires.After(time.Now()) {
+			sessions[i] = v
+		}
+	}
+	m.RUnlock()
+	return sessions, nil
+}
+
+// DeleteSession метод удаляет сессию.
+func (m *MemoryStore) DeleteSession(sessionID string) error {
+	m.Lock()
+	delete(m.sessions, sessionID)
+	delete(m.nonces, sessionID)
+	m.Unlock()
+	return nil
+}
+
+// DeleteExpiredSessions метод удаляет сессии с истекшим сроком действия.
+func (m *MemoryStore) DeleteExpiredSessions() error {
+	m.Lock()
+	for i, v := range m.sessions {
+		if !v.Expires.After(time.Now()) {
+			delete(m.sessions, i)
+			delete(m.nonces, i)
+		}
+	}
+	m.Unlock()
+	return nil
+}
+
+// AddNonce метод сохраняет номер запроса сессии и удаляет номера, время подписи которых вышло за пределы окна.
+// Возвращает ошибку, если запрос с таким номером уже получен.
+func (m *MemoryStore) AddNonce(sessionID string, nonce uint64, signed time.Time, window time.Duration) error {
+	m.Lock()
+	defer m.Unlock()
+	nonces, ok := m.nonces[sessionID]
+	if !ok {
+		return gkerrors.ErrExpired
+	}
+	for i, v := range nonces {
+		if v.Before(time.Now().Add(-window)) {
+			delete(nonces, i)
+		}
+	}
+	if _, ok := nonces[nonce]; ok {
+		return gkerrors.ErrReplay
+	}
+	nonces[nonce] = signed
+	return nil
+}
@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры одноразовых паролей TOTP (RFC 6238), совместимые с распространенными приложениями-аутентификаторами.
+const (
+	totpPeriod    = 30 //Интервал действия кода, в секундах
+	totpDigits    = 6  //Количество цифр в коде
+	totpSecretLen = 20 //Длина секрета, в байтах
+	totpSkew      = 1  //Количество соседних интервалов, коды которых также принимаются
+	totpIssuer    = "GophKeeper"
+
+	recoveryCodeLen = 5 //Длина половины кода восстановления, в байтах
+)
+
+// totpEncoding кодировка секрета TOTP для ввода в приложение-аутентификатор.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret функция генерирует случайный секрет TOTP.
+func NewTOTPSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretLen)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// TOTPSecretString функция возвращает секрет TOTP в кодировке base32 для ручного ввода в приложение-аутентификатор.
+func TOTPSecretString(secret []byte) string {
+	return totpEncoding.EncodeToString(secret)
+}
+
+// TOTPURI функция формирует ссылку otpauth:// для добавления секрета в приложение-аутентификатор.
+func TOTPURI(account string, secret []byte) string {
+	values := url.Values{}
+	values.Set("secret", TOTPSecretString(secret))
+	values.Set("issuer", totpIssuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(totpDigits))
+	values.Set("period", fmt.Sprint(totpPeriod))
+	return fmt.Sprintf("otpauth://totp/%s?%s", url.PathEscape(totpIssuer+":"+account), values.Encode())
+}
+
+// TOTPCode функция вычисляет код TOTP для интервала step.
+func TOTPCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// TOTPStep функция возвращает номер интервала TOTP для момента времени t.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// CheckTOTP функция проверяет код TOTP для момента времени t с допуском в один интервал в обе стороны.
+// Возвращает номер интервала, которому соответствует код, чтобы повторное использование кода можно было отклонить.
+func CheckTOTP(secret []byte, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := TOTPStep(t)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(TOTPCode(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// NewRecoveryCodes функция генерирует n одноразовых кодов восстановления вида xxxxxxxxxx-xxxxxxxxxx.
+func NewRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		bz := make([]byte, 2*recoveryCodeLen)
+		_, err := rand.Read(bz)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = hex.EncodeToString(bz[:recoveryCodeLen]) + "-" + hex.EncodeToString(bz[recoveryCodeLen:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode функция вычисляет хэш кода восстановления для хранения на сервере.
+// Коды случайные и достаточно длинные, поэтому медленное хэширование для них не требуется.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
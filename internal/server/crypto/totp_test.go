@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	// Тестовые значения RFC 6238 для SHA1, последние 6 цифр
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.code, TOTPCode(secret, TOTPStep(time.Unix(tt.unix, 0))))
+	}
+
+	now := time.Unix(1111111109, 0)
+	step, ok := CheckTOTP(secret, "081804", now)
+	require.True(t, ok)
+	require.Equal(t, TOTPStep(now), step)
+	// Код предыдущего интервала принимается
+	step, ok = CheckTOTP(secret, "081804", now.Add(30*time.Second))
+	require.True(t, ok)
+	require.Equal(t, TOTPStep(now), step)
+	_, ok = CheckTOTP(secret, "081804", now.Add(90*time.Second))
+	require.False(t, ok)
+	_, ok = CheckTOTP(secret, "81804", now)
+	require.False(t, ok)
+
+	generated, err := NewTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, generated, totpSecretLen)
+	uri, err := url.Parse(TOTPURI("user@example.com", generated))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/GophKeeper:user@example.com", uri.Path)
+	require.Equal(t, TOTPSecretString(generated), uri.Query().Get("secret"))
+	require.Equal(t, "GophKeeper", uri.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Len(t, code, 21)
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+	require.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+strings.ToUpper(codes[0])+" "))
+	require.NotEqual(t, HashRecoveryCode(codes[0]), HashRecoveryCode(codes[1]))
+}
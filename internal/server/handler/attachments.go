@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// UploadAttachment сохраняет вложение, переданное клиентом потоком зашифрованных частей.
+// Первое сообщение потока содержит SessionID и общий размер частей, который резервируется в квоте пользователя.
+func (s *GophKeeperServer) UploadAttachment(stream pb.GophKeeper_UploadAttachmentServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	userID := s.rsa.GetUserID(in.SessionID)
+	if in.Size <= 0 {
+		return status.Error(codes.InvalidArgument, "attachment size incorrect")
+	}
+	// Прерванные загрузки пользователя удаляются до проверки квоты, чтобы освободить зарезервированный ими объем
+	err = s.files.Sweep(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("UploadAttachment Sweep error")
+	}
+	upload, err := s.files.Create(userID, in.Size)
+	if errors.Is(err, gkerrors.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, gkerrors.ErrQuotaExceeded.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("UploadAttachment Create error")
+		return status.Error(codes.Internal, "attachment storage error")
+	}
+	defer upload.Abort()
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		err = upload.Write(chunk.Data)
+		if errors.Is(err, gkerrors.ErrAttachmentSize) {
+			return status.Error(codes.InvalidArgument, gkerrors.ErrAttachmentSize.Error())
+		}
+		if errors.Is(err, gkerrors.ErrQuotaExceeded) {
+			return status.Error(codes.ResourceExhausted, gkerrors.ErrQuotaExceeded.Error())
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("UploadAttachment Write error")
+			return status.Error(codes.Internal, "attachment storage error")
+		}
+	}
+	err = upload.Commit()
+	if errors.Is(err, gkerrors.ErrAttachmentSize) {
+		return status.Error(codes.InvalidArgument, gkerrors.ErrAttachmentSize.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("UploadAttachment Commit error")
+		return status.Error(codes.Internal, "attachment storage error")
+	}
+	used, err := s.files.Usage(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("UploadAttachment Usage error")
+	}
+
+	var responce = pb.UploadAttachmentResponce{AttachmentID: upload.ID(), Used: used, Quota: s.files.Quota()}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("UploadAttachment signing error")
+		return status.Error(codes.Internal, "EncryptData error")
+	}
+	return stream.SendAndClose(&responce)
+}
+
+// DownloadAttachment передает клиенту сохраненное вложение потоком зашифрованных частей.
+// Первое сообщение потока содержит объем вложения и подпись сервера.
+func (s *GophKeeperServer) DownloadAttachment(in *pb.DownloadAttachmentRequest, stream pb.GophKeeper_DownloadAttachmentServer) error {
+	userID := s.rsa.GetUserID(in.SessionID)
+	reader, err := s.files.Open(userID, in.AttachmentID)
+	if errors.Is(err, gkerrors.ErrNoSuchAttachment) {
+		return status.Error(codes.NotFound, gkerrors.ErrNoSuchAttachment.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("DownloadAttachment Open error")
+		return status.Error(codes.Internal, "attachment storage error")
+	}
+	defer reader.Close()
+
+	var responce = pb.AttachmentChunk{Size: reader.Size()}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DownloadAttachment signing error")
+		return status.Error(codes.Internal, "EncryptData error")
+	}
+	for {
+		responce.Data, err = reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("DownloadAttachment Next error")
+			return status.Error(codes.Internal, "attachment storage error")
+		}
+		err = stream.Send(&responce)
+		if err != nil {
+			return err
+		}
+		responce = pb.AttachmentChunk{}
+	}
+}
+
+// DeleteAttachment освобождает вложение пользователя, на которое больше не ссылаются его текущие данные.
+// Вложение удаляется и освобождает объем в квоте, когда на него не может ссылаться ни одна версия данных в истории.
+func (s *GophKeeperServer) DeleteAttachment(ctx context.Context, in *pb.DeleteAttachmentRequest) (*pb.DeleteAttachmentResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	err := s.files.Release(userID, in.AttachmentID)
+	if errors.Is(err, gkerrors.ErrNoSuchAttachment) {
+		return nil, status.Error(codes.NotFound, gkerrors.ErrNoSuchAttachment.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAttachment Release error")
+		return nil, status.Error(codes.Internal, "attachment storage error")
+	}
+	err = s.files.Sweep(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAttachment Sweep error")
+	}
+
+	var responce pb.DeleteAttachmentResponce
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAttachment signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"net"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/storage"
+)
+
+// Параметры мастер-ключа для незарегистрированных логинов, совпадают с параметрами новых пользователей на клиенте.
+const (
+	fakeKeyTime    = 3
+	fakeKeyMemory  = 64 * 1024
+	fakeKeyThreads = 4
+	// fakeLegacyShare каждый такой по счету незарегистрированный логин отвечает как пользователь с ключом данных,
+	// созданным сервером, чтобы признак Legacy не отличал зарегистрированные логины от незарегистрированных
+	fakeLegacyShare = 4
+)
+
+// authCounter структура описывает счетчик неудачных попыток входа и допустимое количество попыток.
+type authCounter struct {
+	key      string //Ключ счетчика: логин, пользователь или адрес клиента
+	attempts int    //Количество неудачных попыток до временной блокировки
+}
+
+// authCounters метод возвращает счетчики неудачных попыток входа для учетной записи и адреса клиента.
+// Первым всегда идет счетчик учетной записи.
+func (s *GophKeeperServer) authCounters(ctx context.Context, account string) []authCounter {
+	counters := []authCounter{{key: account, attempts: s.cfg.LoginAttempts}}
+	if p, ok := peer.FromContext(ctx); ok {
+		host := p.Addr.String()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		counters = append(counters, authCounter{key: "peer:" + host, attempts: s.cfg.PeerAttempts})
+	}
+	return counters
+}
+
+// authAttempt структура описывает попытку входа, заранее учтенную во всех счетчиках как неудачная.
+// Попытка завершается методом failed, succeeded или release.
+type authAttempt struct {
+	s        *GophKeeperServer
+	counters []authCounter
+	reserved []time.Time //Время блокировки, установленное при учете попытки в каждом счетчике
+	done     bool        //Попытка признана неудачной или удачной
+}
+
+// reserveAttempt метод проверяет блокировку попыток входа и одновременно учитывает попытку во всех счетчиках,
+// чтобы параллельные попытки не проходили проверку до учета предыдущих. Если попытки по одному из счетчиков
+// временно заблокированы, учет в остальных отменяется и возвращается ошибка ResourceExhausted.
+// После проверки пароля или кода вызывающий завершает попытку, а при любом другом исходе учет отменяется отложенным release.
+func (s *GophKeeperServer) reserveAttempt(counters []authCounter) (*authAttempt, error) {
+	attempt := &authAttempt{s: s, counters: counters}
+	for _, counter := range counters {
+		until, ok, err := s.strg.AuthAttempt(counter.key, counter.attempts)
+		if err != nil {
+			attempt.release()
+			log.Error().Err(err).Msg("AuthAttempt error")
+			return nil, status.Error(codes.Internal, "AuthAttempt error")
+		}
+		if !ok {
+			attempt.release()
+			return nil, status.Errorf(codes.ResourceExhausted, "%s, retry after %s", gkerrors.ErrTooManyAttempts, until.Format(time.RFC3339))
+		}
+		attempt.reserved = append(attempt.reserved, until)
+	}
+	return attempt, nil
+}
+
+// failed метод оставляет попытку учтенной как неудачная и возвращает единую ошибку неверных учетных данных,
+// одинаковую для незарегистрированного логина и неверного пароля.
+func (a *authAttempt) failed() error {
+	a.done = true
+	return status.Error(codes.PermissionDenied, gkerrors.ErrInvalidCredentials.Error())
+}
+
+// succeeded метод сбрасывает счетчик неудачных попыток учетной записи и отменяет учет попытки в счетчике адреса клиента.
+// Счетчик адреса клиента не сбрасывается, чтобы вход в свою учетную запись не снимал ограничение на подбор паролей к чужим.
+func (a *authAttempt) succeeded() {
+	a.done = true
+	err := a.s.strg.AuthSucceeded(a.counters[0].key)
+	if err != nil {
+		log.Error().Err(err).Msg("AuthSucceeded error")
+	}
+	for i := 1; i < len(a.reserved); i++ {
+		err = a.s.strg.AuthReleased(a.counters[i].key, a.reserved[i])
+		if err != nil {
+			log.Error().Err(err).Msg("AuthReleased error")
+		}
+	}
+}
+
+// release метод отменяет учет попытки, которая не была признана ни неудачной, ни удачной,
+// например при внутренней ошибке или запросе второго фактора.
+func (a *authAttempt) release() {
+	if a.done {
+		return
+	}
+	a.done = true
+	for i, until := range a.reserved {
+		err := a.s.strg.AuthReleased(a.counters[i].key, until)
+		if err != nil {
+			log.Error().Err(err).Msg("AuthReleased error")
+		}
+	}
+}
+
+// fakeKeyParams метод вычисляет для незарегистрированного логина постоянные параметры мастер-ключа,
+// неотличимые от параметров зарегистрированного пользователя, чтобы KeyParams не раскрывал наличие логина.
+// Признак ключа данных, созданного сервером, тоже вычисляется из логина и не меняется между запросами.
+func (s *GophKeeperServer) fakeKeyParams(login string) storage.UserKey {
+	mac := hmac.New(sha256.New, []byte(s.cfg.LoginSecret))
+	mac.Write([]byte(login))
+	sum := mac.Sum(nil)
+	if sum[16]%fakeLegacyShare == 0 {
+		return storage.UserKey{}
+	}
+	return storage.UserKey{Salt: sum[:16], Time: fakeKeyTime, Memory: fakeKeyMemory, Threads: fakeKeyThreads}
+}
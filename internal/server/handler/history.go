@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// ListHistory передает клиенту список сохраненных предыдущих версий данных пользователя.
+func (s *GophKeeperServer) ListHistory(ctx context.Context, in *pb.ListHistoryRequest) (*pb.ListHistoryResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	history, err := s.strg.ListHistory(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListHistory error")
+		return nil, status.Error(codes.Internal, "ListHistory error")
+	}
+
+	var responce = pb.ListHistoryResponce{Versions: make([]*pb.HistoryVersion, 0, len(history))}
+	for _, version := range history {
+		responce.Versions = append(responce.Versions, &pb.HistoryVersion{Version: version.Version, TimeStamp: version.TimeStamp})
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListHistory EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// RestoreVersion восстанавливает предыдущую версию данных пользователя из истории.
+func (s *GophKeeperServer) RestoreVersion(ctx context.Context, in *pb.RestoreVersionRequest) (*pb.RestoreVersionResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	timeStamp, version, reclaimed, err := s.strg.RestoreVersion(userID, in.SessionID, in.Version, in.CurrentVersion)
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
+	}
+	if errors.Is(err, gkerrors.ErrNoSuchVersion) {
+		return nil, status.Error(codes.NotFound, "users data version not found in history")
+	}
+	if errors.Is(err, gkerrors.ErrDataVersionNotEqual) {
+		return nil, status.Error(codes.FailedPrecondition, "users data version not equal to servers")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("RestoreVersion error")
+		return nil, status.Error(codes.Internal, "RestoreVersion error")
+	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED, Version: version, TimeStamp: timeStamp})
+
+	var responce = pb.RestoreVersionResponce{Status: true, TimeStamp: timeStamp, Version: version, Attachments: reclaimed}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("RestoreVersion EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/storage"
+)
+
+// KeyParams передает клиенту параметры вычисления мастер-ключа пользователя перед авторизацией.
+// Для незарегистрированного логина возвращаются постоянные вычисленные параметры, поэтому ответ не раскрывает наличие пользователя.
+func (s *GophKeeperServer) KeyParams(ctx context.Context, in *pb.KeyParamsRequest) (*pb.KeyParamsResponce, error) {
+	userLogin, err := s.rsa.DecryptPassword(in.SessionID, in.Login, []byte(`kdfLogin`))
+	if err != nil {
+		log.Error().Err(err).Msg("KeyParams DecryptPassword error")
+		return nil, status.Error(codes.Internal, "DecryptLogin error")
+	}
+
+	key, err := s.strg.UserKeyParams(userLogin)
+	if errors.Is(err, gkerrors.ErrNoSuchUser) {
+		log.Debug().Msgf("KeyParams UserKeyParams ErrNoSuchUser, %s", userLogin)
+		key, err = s.fakeKeyParams(userLogin), nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("KeyParams UserKeyParams error")
+		return nil, status.Error(codes.Internal, "UserKeyParams error")
+	}
+
+	var responce = pb.KeyParamsResponce{Legacy: key.Legacy()}
+	if !key.Legacy() {
+		responce.MasterKey = &pb.MasterKey{Salt: key.Salt, Time: key.Time, Memory: key.Memory, Threads: uint32(key.Threads)}
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("KeyParams EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// userKey функция проверяет полученные от клиента параметры мастер-ключа и зашифрованный ключ данных.
+func userKey(in *pb.MasterKey) (storage.UserKey, error) {
+	if in == nil || len(in.Salt) < 16 || in.Time == 0 || in.Memory == 0 || in.Threads == 0 || in.Threads > 255 || len(in.WrappedKey) == 0 {
+		return storage.UserKey{}, status.Error(codes.InvalidArgument, gkerrors.ErrKeyParamsIncorrect.Error())
+	}
+	return storage.UserKey{Salt: in.Salt, Time: in.Time, Memory: in.Memory, Threads: uint8(in.Threads), WrappedKey: in.WrappedKey}, nil
+}
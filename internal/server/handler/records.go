@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// CreateRecord сохраняет новую запись пользователя.
+func (s *GophKeeperServer) CreateRecord(ctx context.Context, in *pb.CreateRecordRequest) (*pb.RecordResponce, error) {
+	if in.Record == nil || in.Record.RecordID == "" {
+		return nil, status.Error(codes.InvalidArgument, "record is empty")
+	}
+	userID := s.rsa.GetUserID(in.SessionID)
+	rec, err := s.strg.CreateRecord(userID, in.SessionID, recordFromPB(in.Record))
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
+	}
+	if errors.Is(err, gkerrors.ErrRecordExists) {
+		return nil, status.Error(codes.AlreadyExists, "record with such ID exists")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("CreateRecord error")
+		return nil, status.Error(codes.Internal, "CreateRecord error")
+	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED, Revision: rec.Revision, TimeStamp: rec.TimeStamp})
+	return s.recordResponce(in.SessionID, rec)
+}
+
+// UpdateRecord обновляет запись пользователя.
+func (s *GophKeeperServer) UpdateRecord(ctx context.Context, in *pb.UpdateRecordRequest) (*pb.RecordResponce, error) {
+	if in.Record == nil || in.Record.RecordID == "" {
+		return nil, status.Error(codes.InvalidArgument, "record is empty")
+	}
+	userID := s.rsa.GetUserID(in.SessionID)
+	rec, err := s.strg.UpdateRecord(userID, in.SessionID, recordFromPB(in.Record))
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
+	}
+	if errors.Is(err, gkerrors.ErrNoSuchRecord) {
+		return nil, status.Error(codes.NotFound, "record with such ID not found")
+	}
+	if errors.Is(err, gkerrors.ErrVersionNotEqual) {
+		return nil, status.Error(codes.FailedPrecondition, "record version not equal to servers")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("UpdateRecord error")
+		return nil, status.Error(codes.Internal, "UpdateRecord error")
+	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED, Revision: rec.Revision, TimeStamp: rec.TimeStamp})
+	return s.recordResponce(in.SessionID, rec)
+}
+
+// DeleteRecord удаляет запись пользователя.
+func (s *GophKeeperServer) DeleteRecord(ctx context.Context, in *pb.DeleteRecordRequest) (*pb.DeleteRecordResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	err := s.strg.DeleteRecord(userID, in.SessionID, in.RecordID, in.Version)
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
+	}
+	if errors.Is(err, gkerrors.ErrNoSuchRecord) {
+		return nil, status.Error(codes.NotFound, "record with such ID not found")
+	}
+	if errors.Is(err, gkerrors.ErrVersionNotEqual) {
+		return nil, status.Error(codes.FailedPrecondition, "record version not equal to servers")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteRecord error")
+		return nil, status.Error(codes.Internal, "DeleteRecord error")
+	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED})
+
+	var responce = pb.DeleteRecordResponce{Status: true}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteRecord EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// ListRecords передает клиенту список записей пользователя без зашифрованных данных.
+func (s *GophKeeperServer) ListRecords(ctx context.Context, in *pb.ListRecordsRequest) (*pb.ListRecordsResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	recs, err := s.strg.ListRecords(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListRecords error")
+		return nil, status.Error(codes.Internal, "ListRecords error")
+	}
+
+	var responce = pb.ListRecordsResponce{Records: make([]*pb.Record, 0, len(recs))}
+	for _, rec := range recs {
+		responce.Records = append(responce.Records, recordToPB(rec))
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListRecords EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// GetRecord передает клиенту запись пользователя.
+func (s *GophKeeperServer) GetRecord(ctx context.Context, in *pb.GetRecordRequest) (*pb.RecordResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	rec, err := s.strg.GetRecord(userID, in.RecordID)
+	if errors.Is(err, gkerrors.ErrNoSuchRecord) {
+		return nil, status.Error(codes.NotFound, "record with such ID not found")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("GetRecord error")
+		return nil, status.Error(codes.Internal, "GetRecord error")
+	}
+	return s.recordResponce(in.SessionID, rec)
+}
+
+// Sync сохраняет измененные клиентом записи и передает клиенту записи, измененные после известной ему ревизии.
+func (s *GophKeeperServer) Sync(ctx context.Context, in *pb.SyncRequest) (*pb.SyncResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	changes := make([]records.Record, 0, len(in.Records))
+	for _, rec := range in.Records {
+		if rec.RecordID == "" {
+			return nil, status.Error(codes.InvalidArgument, "record is empty")
+		}
+		changes = append(changes, recordFromPB(rec))
+	}
+	revision, changed, conflicts, err := s.strg.SyncRecords(userID, in.SessionID, in.Revision, changes)
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Sync SyncRecords error")
+		return nil, status.Error(codes.Internal, "SyncRecords error")
+	}
+	// другие сессии уведомляются, если сохранена хотя бы одна запись: записи с конфликтом и записи,
+	// уже удаленные на сервере, не изменяются. Сохраненные записи получают ревизию этой синхронизации.
+	if len(changes) > 0 && savedInRevision(changed, revision) {
+		s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED, Revision: revision})
+	}
+
+	var responce = pb.SyncResponce{Revision: revision, Records: make([]*pb.Record, 0, len(changed)), Conflicts: make([]*pb.Record, 0, len(conflicts))}
+	for _, rec := range changed {
+		responce.Records = append(responce.Records, recordToPB(rec))
+	}
+	for _, rec := range conflicts {
+		responce.Conflicts = append(responce.Conflicts, recordToPB(rec))
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("Sync EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// savedInRevision функция проверяет, есть ли среди измененных записей записи, сохраненные в ревизии revision.
+func savedInRevision(changed []records.Record, revision int64) bool {
+	for _, rec := range changed {
+		if rec.Revision == revision {
+			return true
+		}
+	}
+	return false
+}
+
+// recordResponce формирует подписанный ответ с записью пользователя.
+func (s *GophKeeperServer) recordResponce(sessionID string, rec records.Record) (*pb.RecordResponce, error) {
+	var responce = pb.RecordResponce{Record: recordToPB(rec)}
+	var err error
+	responce.Sign, err = s.rsa.SignData(sessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("recordResponce EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// recordFromPB функция преобразует запись из формата gRPC.
+func recordFromPB(in *pb.Record) records.Record {
+	return records.Record{ID: in.RecordID, Type: in.RecordType, Version: in.Version, TimeStamp: in.TimeStamp, Data: in.RecordData, Revision: in.Revision, Deleted: in.Deleted}
+}
+
+// recordToPB функция преобразует запись в формат gRPC.
+func recordToPB(rec records.Record) *pb.Record {
+	return &pb.Record{RecordID: rec.ID, RecordType: rec.Type, Version: rec.Version, TimeStamp: rec.TimeStamp, RecordData: rec.Data, Revision: rec.Revision, Deleted: rec.Deleted}
+}
@@ -2,19 +2,18 @@
 package handler
 
 import (
-	"bytes"
 	"context"
-	"crypto/rsa"
 	"database/sql"
-	"encoding/gob"
 	"errors"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	pb "gophkeeper/api/grpc/proto"
 	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/attachments"
 	"gophkeeper/internal/server/config"
 	"gophkeeper/internal/server/crypto"
 	"gophkeeper/internal/server/storage"
@@ -23,48 +22,34 @@ import (
 // GophKeeperServer поддерживает все необходимые методы сервера.
 type GophKeeperServer struct {
 	pb.GophKeeperServer
-	cfg  *config.Config
-	strg storage.Storager
-	rsa  *crypto.Sessions
+	cfg   *config.Config
+	strg  storage.Storager
+	rsa   *crypto.Sessions
+	watch *watchers          //Подписки сессий на уведомления об изменениях данных
+	files *attachments.Store //Вложения пользователей в каталоге DatabaseDirectory
 }
 
 // NewGophKeeperServer генерирует структуру для gRPC сервера.
 func NewGophKeeperServer(cfg *config.Config, strg storage.Storager, rsa *crypto.Sessions) *GophKeeperServer {
-	return &GophKeeperServer{cfg: cfg, strg: strg, rsa: rsa}
+	files := attachments.NewStore(cfg.DatabaseDirectory, int64(cfg.AttachmentQuota)<<20, strg)
+	return &GophKeeperServer{cfg: cfg, strg: strg, rsa: rsa, watch: newWatchers(), files: files}
 }
 
-// NewSessionID генерирует sessionID и rsa-ключ для нового подключения клиента.
+// NewSessionID генерирует sessionID и вычисляет ключи сессии по эфемерному ключу X25519 нового подключения клиента.
 func (s *GophKeeperServer) NewSessionID(ctx context.Context, in *pb.NewSessionIDRequest) (*pb.NewSessionIDResponce, error) {
-	var buf bytes.Buffer
-	_, err := buf.Write(in.UserPublicKeyBZ)
-	if err != nil {
-		log.Error().Err(err).Msg("NewSessionID writing to buf error")
-		return nil, status.Error(codes.Internal, "writing to buf error")
-	}
-
-	var userPublicKey = rsa.PublicKey{}
-	dec := gob.NewDecoder(&buf)
-	err = dec.Decode(&userPublicKey)
-	if err != nil {
-		log.Error().Err(err).Msg("NewSessionID decoding publicKey error")
-		return nil, status.Error(codes.Internal, "decoding publicKey erro")
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
 	}
-
-	sessionID, publicKey, err := s.rsa.NewSessionID(&userPublicKey)
-	if err != nil {
-		log.Error().Err(err).Msg("NewSessionID GenerateKeys error")
-		return nil, status.Error(codes.Internal, "GenerateKeys error")
+	sessionID, publicKey, err := s.rsa.NewSessionID(in.UserPublicKeyBZ, in.DeviceName, in.ClientVersion, remoteAddr)
+	if errors.Is(err, gkerrors.ErrPublicKeyIncorrect) {
+		return nil, status.Error(codes.InvalidArgument, "public key incorrect")
 	}
-
-	var publicKeyBZ bytes.Buffer
-	enc := gob.NewEncoder(&publicKeyBZ)
-	err = enc.Encode(&publicKey)
 	if err != nil {
-		log.Error().Err(err).Msg("NewUser encoding publicKey error")
-		return nil, status.Error(codes.Internal, "encoding publicKey error")
+		log.Error().Err(err).Msg("NewSessionID key exchange error")
+		return nil, status.Error(codes.Internal, "key exchange error")
 	}
-
-	return &pb.NewSessionIDResponce{SessionID: sessionID, PublicKeyBZ: publicKeyBZ.Bytes()}, nil
+	return &pb.NewSessionIDResponce{SessionID: sessionID, PublicKeyBZ: publicKey}, nil
 }
 
 // NewUser создает нового пользователя.
@@ -77,6 +62,10 @@ func (s *GophKeeperServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (
 		log.Error().Err(err).Msg("NewUser EncryptLogin error")
 		return nil, status.Error(codes.Internal, "EncryptLogin error")
 	}
+	key, err := userKey(in.MasterKey)
+	if err != nil {
+		return nil, err
+	}
 
 	exist, err := s.strg.CheckUser(userLogin)
 	if exist {
@@ -88,12 +77,16 @@ func (s *GophKeeperServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (
 		return nil, status.Error(codes.Internal, "CheckUser error")
 	}
 
-	userID, symKey, timeStamp, err := s.strg.RegisterUser(userLogin, userPass)
+	userID, timeStamp, err := s.strg.RegisterUser(userLogin, userPass, key)
 	if err != nil {
 		log.Error().Err(err).Msg("NewUser RegisterUser error")
 		return nil, status.Error(codes.Internal, "RegisterUser error")
 	}
-	s.rsa.AddUserID(in.SessionID, userID)
+	err = s.rsa.AddUserID(in.SessionID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("NewUser AddUserID error")
+		return nil, status.Error(codes.Internal, "AddUserID error")
+	}
 
 	var responce = pb.NewUserResponce{TimeStamp: timeStamp}
 	responce.UserID, err = s.rsa.EncryptData(in.SessionID, userID, []byte(`userID`))
@@ -102,12 +95,6 @@ func (s *GophKeeperServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (
 		return nil, status.Error(codes.Internal, "EncryptData error")
 	}
 
-	responce.SymKey, err = s.rsa.EncryptData(in.SessionID, symKey, []byte(`key`))
-	if err != nil {
-		log.Error().Err(err).Msg("NewUser EncryptOAEP SymmetricalKey error")
-		return nil, status.Error(codes.Internal, "EncryptData error")
-	}
-
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
 		log.Error().Err(err).Msg("NewUser EncryptOAEP signing error")
@@ -118,6 +105,7 @@ func (s *GophKeeperServer) NewUser(ctx context.Context, in *pb.NewUserRequest) (
 }
 
 // LoginUser авторизует пользователя.
+// Неудачные попытки учитываются по логину и адресу клиента, после нескольких попыток вход временно блокируется.
 func (s *GophKeeperServer) LoginUser(ctx context.Context, in *pb.LoginUserRequest) (*pb.LoginUserResponce, error) {
 	userLogin, userPass, err := s.rsa.DecryptLogin(in.SessionID, in.LoginUser)
 	if errors.Is(err, gkerrors.ErrLoginIncorrect) {
@@ -128,21 +116,32 @@ func (s *GophKeeperServer) LoginUser(ctx context.Context, in *pb.LoginUserReques
 		return nil, status.Error(codes.Internal, "DecryptLogin error")
 	}
 
-	userID, err := s.strg.AuthUser(userLogin, userPass)
-	if errors.Is(err, gkerrors.ErrNoSuchUser) {
-		log.Debug().Msgf("LoginUser AuthUser ErrNoSuchUser, %s", userLogin)
-		return nil, status.Error(codes.NotFound, "user with such login not registered")
+	counters := s.authCounters(ctx, storage.LoginAuthKey(userLogin))
+	attempt, err := s.reserveAttempt(counters)
+	if err != nil {
+		return nil, err
 	}
-	if errors.Is(err, gkerrors.ErrWrongPassword) {
-		log.Debug().Msgf("LoginUser AuthUser ErrWrongPassword")
-		return nil, status.Error(codes.InvalidArgument, "password incorrect")
+	defer attempt.release()
+	userID, key, err := s.strg.AuthUser(userLogin, userPass)
+	if errors.Is(err, gkerrors.ErrNoSuchUser) || errors.Is(err, gkerrors.ErrWrongPassword) {
+		log.Debug().Err(err).Msgf("LoginUser AuthUser failed, %s", userLogin)
+		return nil, attempt.failed()
 	}
 	if err != nil {
 		log.Error().Err(err).Msg("LoginUser AuthUser error")
 		return nil, status.Error(codes.Internal, "AuthUser error")
 	}
+	err = s.loginSecondFactor(in, userID, attempt)
+	if err != nil {
+		return nil, err
+	}
+	attempt.succeeded()
 
-	s.rsa.AddUserID(in.SessionID, userID)
+	err = s.rsa.AddUserID(in.SessionID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("LoginUser AddUserID error")
+		return nil, status.Error(codes.Internal, "AddUserID error")
+	}
 	log.Debug().Msgf("LoginUser AddUserID return")
 	var responce pb.LoginUserResponce
 	responce.UserID, err = s.rsa.EncryptData(in.SessionID, userID, []byte(`userID`))
@@ -151,6 +150,15 @@ func (s *GophKeeperServer) LoginUser(ctx context.Context, in *pb.LoginUserReques
 		log.Error().Err(err).Msgf("LoginUser EncryptData error, %s", userLogin)
 		return nil, status.Error(codes.Internal, "EncryptData error")
 	}
+	if key.Legacy() {
+		responce.SymKey, err = s.rsa.EncryptData(in.SessionID, key.LegacyKey, []byte(`key`))
+		if err != nil {
+			log.Error().Err(err).Msgf("LoginUser EncryptOAEP SymmetricalKey error, %s", userLogin)
+			return nil, status.Error(codes.Internal, "EncryptData error")
+		}
+	} else {
+		responce.MasterKey = &pb.MasterKey{Salt: key.Salt, Time: key.Time, Memory: key.Memory, Threads: uint32(key.Threads), WrappedKey: key.WrappedKey}
+	}
 
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	log.Debug().Msgf("LoginUser responce.Sign return")
@@ -166,14 +174,10 @@ func (s *GophKeeperServer) LoginUser(ctx context.Context, in *pb.LoginUserReques
 func (s *GophKeeperServer) UserData(ctx context.Context, in *pb.UserDataRequest) (*pb.UserDataResponce, error) {
 	userID := s.rsa.GetUserID(in.SessionID)
 	var responce pb.UserDataResponce
-	userData, timeStamp, symKey, err := s.strg.UsersData(userID)
+	userData, timeStamp, version, err := s.strg.UsersData(userID)
 	if errors.Is(err, gkerrors.ErrNoUserData) {
 		responce.TimeStamp = timeStamp
-		responce.SymKey, err = s.rsa.EncryptData(in.SessionID, symKey, []byte(`key`))
-		if err != nil {
-			log.Error().Err(err).Msg("UserData EncryptOAEP SymmetricalKey error")
-			return nil, status.Error(codes.Internal, "EncryptData error")
-		}
+		responce.Version = version
 		responce.Sign, err = s.rsa.SignData(in.SessionID)
 		if err != nil {
 			log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
@@ -187,11 +191,7 @@ func (s *GophKeeperServer) UserData(ctx context.Context, in *pb.UserDataRequest)
 	}
 	responce.UserData = userData
 	responce.TimeStamp = timeStamp
-	responce.SymKey, err = s.rsa.EncryptData(in.SessionID, symKey, []byte(`key`))
-	if err != nil {
-		log.Error().Err(err).Msg("UserData EncryptOAEP SymmetricalKey error")
-		return nil, status.Error(codes.Internal, "EncryptData error")
-	}
+	responce.Version = version
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
 		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
@@ -204,13 +204,16 @@ func (s *GophKeeperServer) UserData(ctx context.Context, in *pb.UserDataRequest)
 func (s *GophKeeperServer) TimeStamp(ctx context.Context, in *pb.TimeStampRequest) (*pb.TimeStampResponce, error) {
 	userID := s.rsa.GetUserID(in.SessionID)
 	log.Error().Msgf("TimeStamp userID = %s", userID)
-	timeStamp, locked, timeLocked, err := s.strg.UsersTimeStamp(userID)
+	timeStamp, version, locked, lock, err := s.strg.UsersTimeStamp(userID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		log.Error().Err(err).Msg("TimeStamp error")
 		return nil, status.Error(codes.Internal, "TimeStamp error")
 	}
 
-	var responce = pb.TimeStampResponce{TimeStamp: timeStamp, Locked: locked, TimeLocked: timeLocked}
+	var responce = pb.TimeStampResponce{TimeStamp: timeStamp, Version: version, Locked: locked, TimeLocked: lock.TimeLock}
+	if locked {
+		responce.Owner = lockOwner(lock, in.SessionID)
+	}
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
 		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
@@ -220,12 +223,63 @@ func (s *GophKeeperServer) TimeStamp(ctx context.Context, in *pb.TimeStampReques
 	return &responce, nil
 }
 
-// DataLock помечает данные клиента, как заблокированные на изменение другими пользователями
+// DataLock помечает данные клиента, как заблокированные на изменение другими пользователями.
+// При takeOver блокировка другой сессии того же пользователя перехватывается текущей сессией.
 func (s *GophKeeperServer) DataLock(ctx context.Context, in *pb.DataLockRequest) (*pb.DataLockResponce, error) {
+	session, err := s.rsa.SessionInfo(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DataLock SessionInfo error")
+		return nil, status.Error(codes.Unauthenticated, "sessionID expired")
+	}
+	owner := storage.LockInfo{SessionID: in.SessionID, DeviceName: session.DeviceName, ClientVersion: session.ClientVersion}
+	locked, lock := s.strg.UsersDataLock(session.UserID, owner, in.TakeOver)
+	var responce = pb.DataLockResponce{Locked: locked, TimeLocked: lock.TimeLock}
+	if lock.SessionID != "" {
+		responce.Owner = lockOwner(lock, in.SessionID)
+	}
+	if locked {
+		s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_LOCKED, TimeLocked: lock.TimeLock})
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+
+	return &responce, nil
+}
+
+// ReleaseLock снимает блокировку данных пользователя на изменение, установленную текущей сессией.
+func (s *GophKeeperServer) ReleaseLock(ctx context.Context, in *pb.ReleaseLockRequest) (*pb.ReleaseLockResponce, error) {
 	userID := s.rsa.GetUserID(in.SessionID)
-	locked, timeLocked := s.strg.UsersDataLock(userID, in.SessionID)
-	var responce = pb.DataLockResponce{Locked: locked, TimeLocked: timeLocked}
-	var err error
+	err := s.strg.ReleaseLock(userID, in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("ReleaseLock error")
+		return nil, status.Error(codes.Internal, "ReleaseLock error")
+	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_UNLOCKED})
+	var responce = pb.ReleaseLockResponce{Status: true}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+
+	return &responce, nil
+}
+
+// RenewLock продлевает блокировку данных пользователя на изменение, установленную текущей сессией.
+func (s *GophKeeperServer) RenewLock(ctx context.Context, in *pb.RenewLockRequest) (*pb.RenewLockResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	timeLocked, err := s.strg.RenewLock(userID, in.SessionID)
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return nil, status.Error(codes.FailedPrecondition, "users data isn't locked by session")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("RenewLock error")
+		return nil, status.Error(codes.Internal, "RenewLock error")
+	}
+	var responce = pb.RenewLockResponce{Locked: true, TimeLocked: timeLocked}
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
 		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
@@ -238,20 +292,21 @@ func (s *GophKeeperServer) DataLock(ctx context.Context, in *pb.DataLockRequest)
 // UpdateData принимает от клиента обновленные данные пользователя.
 func (s *GophKeeperServer) UpdateData(ctx context.Context, in *pb.UpdateDataRequest) (*pb.UpdateDataResponce, error) {
 	userID := s.rsa.GetUserID(in.SessionID)
-	save, timeStamp, err := s.strg.UpdateUserData(userID, in.SessionID, in.TimeStamp, in.UserData)
+	save, timeStamp, version, err := s.strg.UpdateUserData(userID, in.SessionID, in.Version, in.UserData)
 
 	if errors.Is(err, gkerrors.ErrLocked) {
 		return nil, status.Error(codes.PermissionDenied, "users data changes locked by another user")
 	}
-	if errors.Is(err, gkerrors.ErrTimeNotEqual) {
-		return nil, status.Error(codes.FailedPrecondition, "users data timeStamp not equal to servers")
+	if errors.Is(err, gkerrors.ErrDataVersionNotEqual) {
+		return nil, status.Error(codes.FailedPrecondition, "users data version not equal to servers")
 	}
 	if err != nil {
 		log.Error().Err(err).Msg("UpdateData error")
 		return nil, status.Error(codes.Internal, "UpdateData error")
 	}
+	s.notifyChange(in.SessionID, &pb.ChangeEvent{Kind: pb.ChangeKind_SAVED, Version: version, TimeStamp: timeStamp})
 
-	var responce = pb.UpdateDataResponce{Status: save, TimeStamp: timeStamp}
+	var responce = pb.UpdateDataResponce{Status: save, TimeStamp: timeStamp, Version: version}
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
 		log.Error().Err(err).Msg("UserData EncryptOAEP signing error")
@@ -263,11 +318,16 @@ func (s *GophKeeperServer) UpdateData(ctx context.Context, in *pb.UpdateDataRequ
 
 // LogOut закрывает сессию пользователя.
 func (s *GophKeeperServer) LogOut(ctx context.Context, in *pb.LogOutRequest) (*pb.LogOutResponce, error) {
-	s.rsa.UserLogOut(in.SessionID)
+	err := s.rsa.UserLogOut(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("LogOut UserLogOut error")
+		return &pb.LogOutResponce{Status: false}, nil
+	}
 	return &pb.LogOutResponce{Status: true}, nil
 }
 
-// ChangePassword метод обновляет пароль пользователя.
+// ChangePassword метод обновляет пароль пользователя и ключ данных, зашифрованный новым мастер-ключом.
+// Неверный текущий пароль учитывается так же, как неудачная попытка входа.
 func (s *GophKeeperServer) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.ChangePasswordResponce, error) {
 	userID := s.rsa.GetUserID(in.SessionID)
 	old, err := s.rsa.DecryptPassword(in.SessionID, in.OldPassword, []byte("oldPass"))
@@ -280,15 +340,26 @@ func (s *GophKeeperServer) ChangePassword(ctx context.Context, in *pb.ChangePass
 		log.Error().Err(err).Msg("NewUser DecryptLogin error")
 		return nil, status.Error(codes.Internal, "DecryptLogin error")
 	}
+	key, err := userKey(in.MasterKey)
+	if err != nil {
+		return nil, err
+	}
 
-	update, err := s.strg.ChangeUserPassword(userID, old, new)
+	counters := s.authCounters(ctx, storage.UserAuthKey(userID))
+	attempt, err := s.reserveAttempt(counters)
+	if err != nil {
+		return nil, err
+	}
+	defer attempt.release()
+	update, err := s.strg.ChangeUserPassword(userID, old, new, key)
 	if errors.Is(err, gkerrors.ErrWrongPassword) {
-		return nil, status.Error(codes.InvalidArgument, "password incorrect")
+		return nil, attempt.failed()
 	}
 	if err != nil {
 		log.Error().Err(err).Msg("ChangePassword ChangeUserPassword error")
 		return nil, status.Error(codes.Internal, "ChangeUserPassword error")
 	}
+	attempt.succeeded()
 	var responce = pb.ChangePasswordResponce{Status: update}
 	responce.Sign, err = s.rsa.SignData(in.SessionID)
 	if err != nil {
@@ -298,3 +369,63 @@ func (s *GophKeeperServer) ChangePassword(ctx context.Context, in *pb.ChangePass
 
 	return &responce, nil
 }
+
+// DeleteAccount удаляет учетную запись пользователя после проверки текущего пароля.
+// Вместе с учетной записью удаляются все данные пользователя и завершаются все его сессии.
+func (s *GophKeeperServer) DeleteAccount(ctx context.Context, in *pb.DeleteAccountRequest) (*pb.DeleteAccountResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	pass, err := s.rsa.DecryptPassword(in.SessionID, in.Password, []byte("deletePass"))
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAccount DecryptPassword error")
+		return nil, status.Error(codes.Internal, "DecryptData error")
+	}
+
+	counters := s.authCounters(ctx, storage.UserAuthKey(userID))
+	attempt, err := s.reserveAttempt(counters)
+	if err != nil {
+		return nil, err
+	}
+	defer attempt.release()
+	err = s.strg.DeleteUser(userID, pass)
+	if errors.Is(err, gkerrors.ErrWrongPassword) {
+		return nil, attempt.failed()
+	}
+	if errors.Is(err, gkerrors.ErrNoSuchUser) {
+		return nil, status.Error(codes.NotFound, gkerrors.ErrNoSuchUser.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAccount DeleteUser error")
+		return nil, status.Error(codes.Internal, "DeleteUser error")
+	}
+	attempt.succeeded()
+	// Файлы вложений удаляются только после фиксации удаления учетной записи в базе данных.
+	// Ошибка удаления файлов не отменяет удаление учетной записи и передается клиенту в ответе.
+	var responce = pb.DeleteAccountResponce{Status: true}
+	err = s.files.DeleteUser(userID)
+	if err != nil {
+		log.Error().Err(err).Msgf("DeleteAccount attachments DeleteUser error. userID = %s", userID)
+		responce.AttachmentsKept = true
+	}
+
+	// Ответ подписывается до завершения сессий, пока ключи текущей сессии еще доступны
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAccount signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	err = s.rsa.DeleteUserSessions(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("DeleteAccount DeleteUserSessions error")
+	}
+	return &responce, nil
+}
+
+// lockOwner функция формирует для клиента описание сессии, установившей блокировку данных.
+func lockOwner(lock storage.LockInfo, sessionID string) *pb.LockOwner {
+	return &pb.LockOwner{
+		DeviceName:    lock.DeviceName,
+		ClientVersion: lock.ClientVersion,
+		TimeAcquired:  lock.TimeAcquired,
+		Current:       lock.SessionID == sessionID,
+	}
+}
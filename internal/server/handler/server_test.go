@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -9,8 +16,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	grpcStatus "google.golang.org/grpc/status"
 
 	"gophkeeper/api/grpc/proto"
 	clientCNFG "gophkeeper/internal/client/config"
@@ -21,9 +30,11 @@ import (
 	clientInterceptor "gophkeeper/internal/client/interceptor"
 	"gophkeeper/internal/logger"
 	"gophkeeper/internal/mocks"
+	"gophkeeper/internal/records"
 	"gophkeeper/internal/server/config"
 	"gophkeeper/internal/server/crypto"
 	"gophkeeper/internal/server/interceptor"
+	"gophkeeper/internal/server/storage"
 )
 
 func TestServer(t *testing.T) {
@@ -37,14 +48,16 @@ func TestServer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	strg := mocks.NewMockStorager(ctrl)
-	rsa := crypto.NewSessions(cnfg)
+	rsa := crypto.NewSessions(cnfg, crypto.NewMemoryStore())
+	cnfg.DatabaseDirectory = t.TempDir()
+	cnfg.AttachmentQuota = 1
 	gRPCconf := NewGophKeeperServer(cnfg, strg, rsa)
 	listen, err := net.Listen("tcp", cnfg.RunAddress)
 	if err != nil {
 		log.Fatal().Err(err).Msg("gRPC server announce error")
 	}
 	interceptor := interceptor.NewAuthInterceptor(rsa)
-	server := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()))
+	server := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()), grpc.StreamInterceptor(interceptor.Stream()))
 	proto.RegisterGophKeeperServer(server, gRPCconf)
 	reflection.Register(server)
 	go func() {
@@ -64,11 +77,31 @@ func TestServer(t *testing.T) {
 		log.Fatal().Err(err).Msg("NewUserSession generating key error")
 	}
 	auth := clientInterceptor.NewAuthClient(clientRsa)
-	conn, err := grpc.Dial(clientCnfg.RunAddress, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(auth.Unary()))
+	conn, err := grpc.Dial(clientCnfg.RunAddress, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.Unary()), grpc.WithStreamInterceptor(auth.Stream()))
 	if err != nil {
 		log.Fatal().Err(err).Msg("gRPC connection error")
 	}
 	client := sender.NewGophKeeperClient(conn, clientRsa, clientStrg)
+	client.Device = "desktop"
+
+	// Ожидаемая проверка счетчиков неудачных попыток входа для учетной записи и адреса клиента.
+	// Попытка учитывается в счетчиках до проверки пароля, неверный пароль оставляет ее учтенной
+	reserved := time.Now().Add(time.Second)
+	expectAuthAllowed := func(account string) {
+		strg.EXPECT().AuthAttempt(account, cnfg.LoginAttempts).Return(reserved, true, nil)
+		strg.EXPECT().AuthAttempt(gomock.Any(), cnfg.PeerAttempts).Return(reserved, true, nil)
+	}
+	// Удачная попытка сбрасывает счетчик учетной записи и отменяет учет в счетчике адреса клиента
+	expectAuthSucceeded := func(account string) {
+		strg.EXPECT().AuthSucceeded(account).Return(nil)
+		strg.EXPECT().AuthReleased(gomock.Not(account), reserved).Return(nil)
+	}
+	// Попытка, завершенная без проверки пароля или кода, отменяет учет во всех счетчиках
+	expectAuthReleased := func(account string) {
+		strg.EXPECT().AuthReleased(account, reserved).Return(nil)
+		strg.EXPECT().AuthReleased(gomock.Not(account), reserved).Return(nil)
+	}
 
 	// Начинаем тестирование
 
@@ -89,23 +122,38 @@ func TestServer(t *testing.T) {
 	err = client.ReqSessionID()
 	require.NoError(t, err)
 
+	// Сессии нельзя получить или завершить до входа пользователя
+	_, err = client.ListSessions()
+	require.Equal(t, codes.Unauthenticated, grpcStatus.Code(err))
+	err = client.RevokeSession("anotherSession")
+	require.Equal(t, codes.Unauthenticated, grpcStatus.Code(err))
+
 	// Проверка регистрации при занятом логине
 	strg.EXPECT().CheckUser("userName1").Return(true, gkerrors.ErrLoginExist)
 	err = client.RegisterUser("userName1", "123")
 	require.Error(t, err)
 
 	// Успешная регистрация
-	userID := crypto.RandomID(12)
-	symKey := crypto.NewSymmetricalKey(userID)
+	userID, err := crypto.RandomID(12)
+	require.NoError(t, err)
 	timeStamp := time.Now().Format(time.RFC3339)
 	timeLock := time.Now().Add(time.Minute * 5).Format(time.RFC3339)
+	laptopLock := storage.LockInfo{SessionID: "laptopSession", DeviceName: "laptop", ClientVersion: "v0.0.2", TimeAcquired: timeStamp, TimeLock: timeLock}
+	var registered storage.UserKey
+	var registeredAuth string
 	first := strg.EXPECT().CheckUser("userName2").Return(false, nil).MaxTimes(1)
-	strg.EXPECT().RegisterUser("userName2", crypto.HashPasswd("123")).Return(userID, symKey, timeStamp, nil).After(first)
+	strg.EXPECT().RegisterUser("userName2", gomock.Not("123"), gomock.Any()).DoAndReturn(func(login, authKey string, key storage.UserKey) (string, string, error) {
+		registered, registeredAuth = key, authKey
+		return userID, timeStamp, nil
+	}).After(first)
 	err = client.RegisterUser("userName2", "123")
 	require.NoError(t, err)
+	params := clientCRPT.KeyParams{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}
+	require.Equal(t, clientCRPT.DeriveMasterKey("123", params).AuthKey, registeredAuth)
+	require.NotEmpty(t, registered.WrappedKey)
 
 	// Проверка на скачивание данных, при их отсутствии
-	strg.EXPECT().UsersData(userID).Return(nil, timeStamp, symKey, gkerrors.ErrNoUserData)
+	strg.EXPECT().UsersData(userID).Return(nil, timeStamp, int64(0), gkerrors.ErrNoUserData)
 	err = client.Download()
 	require.NoError(t, err)
 
@@ -117,10 +165,13 @@ func TestServer(t *testing.T) {
 	err = client.ReqSessionID()
 	require.NoError(t, err)
 
-	// Авторизация несуществующим пользователем
-	strg.EXPECT().AuthUser("userName3", crypto.HashPasswd("123")).Return("", gkerrors.ErrNoSuchUser)
+	// Авторизация несуществующим пользователем: параметры мастер-ключа и ошибка не отличаются от неверного пароля
+	strg.EXPECT().UserKeyParams("userName3").Return(storage.UserKey{}, gkerrors.ErrNoSuchUser)
+	expectAuthAllowed("login:userName3")
+	strg.EXPECT().AuthUser("userName3", gomock.Any()).Return("", storage.UserKey{}, gkerrors.ErrNoSuchUser)
 	err = client.UserLogin("userName3", "123")
-	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+	notFoundErr := err
 
 	// Проверка на запрос без авторизации
 	err = client.Download()
@@ -144,68 +195,629 @@ func TestServer(t *testing.T) {
 	require.Equal(t, false, status)
 
 	// Проверка авторизации с неверным паролем
-	strg.EXPECT().AuthUser("userName4", crypto.HashPasswd("123")).Return("", gkerrors.ErrWrongPassword)
-	err = client.UserLogin("userName4", "123")
+	strg.EXPECT().UserKeyParams("userName4").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	expectAuthAllowed("login:userName4")
+	strg.EXPECT().AuthUser("userName4", clientCRPT.DeriveMasterKey("234", params).AuthKey).Return("", storage.UserKey{}, gkerrors.ErrWrongPassword)
+	err = client.UserLogin("userName4", "234")
+	require.Equal(t, notFoundErr.Error(), err.Error())
+
+	// Попытка авторизации при временной блокировке входа не проверяет пароль
+	strg.EXPECT().UserKeyParams("userName4").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	strg.EXPECT().AuthAttempt("login:userName4", cnfg.LoginAttempts).Return(time.Now().Add(time.Minute), false, nil)
+	err = client.UserLogin("userName4", "234")
+	require.Equal(t, codes.ResourceExhausted, grpcStatus.Code(err))
+
+	// При блокировке входа с адреса клиента учет попытки в счетчике учетной записи отменяется
+	strg.EXPECT().UserKeyParams("userName4").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	strg.EXPECT().AuthAttempt("login:userName4", cnfg.LoginAttempts).Return(reserved, true, nil)
+	strg.EXPECT().AuthAttempt(gomock.Any(), cnfg.PeerAttempts).Return(time.Now().Add(time.Minute), false, nil)
+	strg.EXPECT().AuthReleased("login:userName4", reserved).Return(nil)
+	err = client.UserLogin("userName4", "234")
+	require.Equal(t, codes.ResourceExhausted, grpcStatus.Code(err))
+
+	// Авторизация пользователя с ключом данных, созданным сервером, и замена ключа
+	strg.EXPECT().UserKeyParams("userName6").Return(storage.UserKey{}, nil)
+	expectAuthAllowed("login:userName6")
+	strg.EXPECT().AuthUser("userName6", "345").Return("1234567890", storage.UserKey{LegacyKey: "01234567890123456789012345678901abcdefghijkl"}, nil)
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName6"}, nil)
+	expectAuthSucceeded("login:userName6")
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().ChangeUserPassword("1234567890", "345", gomock.Not("345"), gomock.Any()).Return(true, nil)
+	expectAuthSucceeded("user:1234567890")
+	err = client.UserLogin("userName6", "345")
+	require.NoError(t, err)
+
+	// Запись, зашифрованная устаревшим ключом, шифруется повторно, после чего устаревший ключ удаляется
+	legacyBlock, _ := aes.NewCipher([]byte("01234567890123456789012345678901"))
+	legacyGCM, _ := cipher.NewGCM(legacyBlock)
+	legacyBZ := legacyGCM.Seal(nil, []byte("abcdefghijkl"), []byte(`{"Name":"old","Data":"legacy"}`), nil)
+	legacyRecord := records.Record{ID: "legacy1", Type: records.TypeText, Version: 1, TimeStamp: time.Now().Format(time.RFC3339), Data: legacyBZ, Revision: 1}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(0), gomock.Len(0)).Return(int64(1), []records.Record{legacyRecord}, nil, nil)
+	_, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 1, client.Strg.ChangedCount())
+	var retired storage.UserKey
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(1), gomock.Len(1)).DoAndReturn(func(userID, sessionID string, revision int64, changes []records.Record) (int64, []records.Record, []records.Record, error) {
+		changes[0].Version, changes[0].Revision = 2, 2
+		return int64(2), changes, nil, nil
+	})
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().ChangeUserPassword("1234567890", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(userID, oldAuthKey, authKey string, key storage.UserKey) (bool, error) {
+		require.Equal(t, oldAuthKey, authKey)
+		retired = key
+		return true, nil
+	})
+	expectAuthSucceeded("user:1234567890")
+	_, err = client.Sync()
+	require.NoError(t, err)
+	require.Len(t, retired.WrappedKey, 12+32+16)
+	_, err = clientRsa.DecryptUserData(legacyBZ)
 	require.Error(t, err)
+	client.Strg = clientSTRG.NewUserStorage()
 
 	// Успешная авторизация
-	strg.EXPECT().AuthUser("userName5", crypto.HashPasswd("234")).Return("1234567890", nil)
-	err = client.UserLogin("userName5", "234")
+	strg.EXPECT().UserKeyParams("userName5").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	expectAuthAllowed("login:userName5")
+	strg.EXPECT().AuthUser("userName5", registeredAuth).Return("1234567890", registered, nil)
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5"}, nil)
+	expectAuthSucceeded("login:userName5")
+	err = client.UserLogin("userName5", "123")
 	require.NoError(t, err)
 
 	// Успешное скачивание данных
-	strg.EXPECT().UsersData("1234567890").Return(nil, timeStamp, symKey, gkerrors.ErrNoUserData)
+	strg.EXPECT().UsersData("1234567890").Return(nil, timeStamp, int64(0), gkerrors.ErrNoUserData)
 	err = client.Download()
 	require.NoError(t, err)
 
 	// Проверка актуальности данных при наличии блокировки
-	strg.EXPECT().UsersTimeStamp("1234567890").Return(timeStamp, true, timeLock, nil)
+	strg.EXPECT().UsersTimeStamp("1234567890").Return(timeStamp, int64(0), true, laptopLock, nil)
 	err = client.CheckTimeStamp()
 	require.NoError(t, err)
 
 	// Попытка блокировки данных, при ее наличии
-	strg.EXPECT().UsersDataLock("1234567890", clientRsa.GetSessionID()).Return(false, timeLock)
+	desktopOwner := storage.LockInfo{SessionID: clientRsa.GetSessionID(), DeviceName: "desktop"}
+	desktopLock := storage.LockInfo{SessionID: clientRsa.GetSessionID(), DeviceName: "desktop", TimeAcquired: timeStamp, TimeLock: timeLock}
+	strg.EXPECT().UsersDataLock("1234567890", desktopOwner, false).Return(false, laptopLock)
 	err = client.LockUserData()
-	require.Error(t, err)
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+
+	// Перехват блокировки у другой сессии пользователя
+	strg.EXPECT().UsersDataLock("1234567890", desktopOwner, true).Return(true, desktopLock)
+	err = client.TakeOverLock()
+	require.NoError(t, err)
+	locked, _ := client.LockState()
+	require.True(t, locked)
+	strg.EXPECT().ReleaseLock("1234567890", clientRsa.GetSessionID()).Return(nil)
+	err = client.ReleaseLock()
+	require.NoError(t, err)
 
-	jsonBZ, _ := client.Strg.ExportUserData()
-	messageBZ, _ := clientRsa.EncryptUserData(jsonBZ)
+	recs, _ := client.Strg.ExportRecords()
+	messageBZ, _ := records.Pack(recs)
 	// Попытка записи при наличии блокировки данных
-	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), timeStamp, messageBZ).Return(false, timeLock, gkerrors.ErrLocked)
+	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), int64(0), messageBZ).Return(false, timeLock, int64(0), gkerrors.ErrLocked)
 	err = client.SaveData()
 	require.Error(t, err)
 
-	// Попытка записи при неактуальных данных
-	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), timeStamp, messageBZ).Return(false, timeLock, gkerrors.ErrTimeNotEqual)
+	// Попытка записи при неактуальной версии данных
+	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), int64(0), messageBZ).Return(false, timeLock, int64(0), gkerrors.ErrDataVersionNotEqual)
 	err = client.SaveData()
 	require.Error(t, err)
 
 	// Проверка актуальности данных при отсутствии блокировки
-	strg.EXPECT().UsersTimeStamp("1234567890").Return(timeStamp, false, "", nil)
+	strg.EXPECT().UsersTimeStamp("1234567890").Return(timeStamp, int64(0), false, storage.LockInfo{}, nil)
 	err = client.CheckTimeStamp()
 	require.NoError(t, err)
 
 	// Успешная блокировка данных
-	strg.EXPECT().UsersDataLock("1234567890", clientRsa.GetSessionID()).Return(true, timeLock)
+	strg.EXPECT().UsersDataLock("1234567890", desktopOwner, false).Return(true, desktopLock)
+	err = client.LockUserData()
+	require.NoError(t, err)
+
+	// Продление блокировки данных
+	strg.EXPECT().RenewLock("1234567890", clientRsa.GetSessionID()).Return(timeLock, nil)
+	err = client.RenewLock()
+	require.NoError(t, err)
+
+	// Продление блокировки, перехваченной другой сессией
+	strg.EXPECT().RenewLock("1234567890", clientRsa.GetSessionID()).Return("", gkerrors.ErrLocked)
+	err = client.RenewLock()
+	require.ErrorIs(t, err, gkerrors.ErrNotLocked)
+	locked, _ = client.LockState()
+	require.False(t, locked)
+
+	// Снятие блокировки данных и повторная блокировка
+	strg.EXPECT().ReleaseLock("1234567890", clientRsa.GetSessionID()).Return(nil)
+	err = client.ReleaseLock()
+	require.NoError(t, err)
+	strg.EXPECT().UsersDataLock("1234567890", desktopOwner, false).Return(true, desktopLock)
 	err = client.LockUserData()
 	require.NoError(t, err)
 
 	// Успешная запись данных
-	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), timeStamp, messageBZ).Return(true, timeStamp, nil)
+	strg.EXPECT().UpdateUserData("1234567890", clientRsa.GetSessionID(), int64(0), messageBZ).Return(true, timeStamp, int64(1), nil)
 	err = client.SaveData()
 	require.NoError(t, err)
+	require.Equal(t, int64(1), client.Strg.Version)
+
+	// Создание отдельной записи
+	recordBZ, _ := clientRsa.EncryptUserData([]byte("record data"))
+	record := records.Record{ID: "rec1", Type: records.TypeText, Version: 1, TimeStamp: timeStamp, Data: recordBZ}
+	strg.EXPECT().CreateRecord("1234567890", clientRsa.GetSessionID(), gomock.Any()).Return(record, nil)
+	created, err := client.CreateRecord(records.Record{ID: "rec1", Type: records.TypeText, Data: []byte("record data")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("record data"), created.Data)
+
+	// Создание записи с занятым идентификатором
+	strg.EXPECT().CreateRecord("1234567890", clientRsa.GetSessionID(), gomock.Any()).Return(records.Record{}, gkerrors.ErrRecordExists)
+	_, err = client.CreateRecord(records.Record{ID: "rec1", Type: records.TypeText, Data: []byte("record data")})
+	require.Error(t, err)
+
+	// Обновление записи с устаревшей версией
+	strg.EXPECT().UpdateRecord("1234567890", clientRsa.GetSessionID(), gomock.Any()).Return(records.Record{}, gkerrors.ErrVersionNotEqual)
+	_, err = client.UpdateRecord(records.Record{ID: "rec1", Type: records.TypeText, Version: 0, Data: []byte("new data")})
+	require.Error(t, err)
+
+	// Изменение записей, заблокированных другой сессией
+	strg.EXPECT().UpdateRecord("1234567890", clientRsa.GetSessionID(), gomock.Any()).Return(records.Record{}, gkerrors.ErrLocked)
+	_, err = client.UpdateRecord(records.Record{ID: "rec1", Type: records.TypeText, Version: 1, Data: []byte("new data")})
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+	strg.EXPECT().DeleteRecord("1234567890", clientRsa.GetSessionID(), "rec1", int64(1)).Return(gkerrors.ErrLocked)
+	err = client.DeleteRecord("rec1", 1)
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+
+	// Получение списка записей
+	strg.EXPECT().ListRecords("1234567890").Return([]records.Record{{ID: "rec1", Type: records.TypeText, Version: 1, TimeStamp: timeStamp}}, nil)
+	list, err := client.ListRecords()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	// Получение записи
+	strg.EXPECT().GetRecord("1234567890", "rec1").Return(record, nil)
+	got, err := client.GetRecord("rec1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("record data"), got.Data)
+
+	// Получение несуществующей записи
+	strg.EXPECT().GetRecord("1234567890", "rec2").Return(records.Record{}, gkerrors.ErrNoSuchRecord)
+	_, err = client.GetRecord("rec2")
+	require.Error(t, err)
+
+	// Удаление записи
+	strg.EXPECT().DeleteRecord("1234567890", clientRsa.GetSessionID(), "rec1", int64(1)).Return(nil)
+	err = client.DeleteRecord("rec1", 1)
+	require.NoError(t, err)
+
+	// Синхронизация данных, заблокированных другой сессией
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(0), gomock.Any()).Return(int64(0), nil, nil, gkerrors.ErrLocked)
+	_, err = client.Sync()
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+	require.Equal(t, int64(0), client.Strg.Revision)
+
+	// Синхронизация записей, измененных на сервере
+	syncBZ, _ := clientRsa.EncryptUserData([]byte(`{"Name":"note","Data":"text"}`))
+	synced := records.Record{ID: "rec3", Type: records.TypeText, Version: 1, TimeStamp: timeStamp, Data: syncBZ, Revision: 5}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(0), gomock.Any()).Return(int64(5), []records.Record{synced}, nil, nil)
+	conflicts, err := client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 0, conflicts)
+	require.Equal(t, int64(5), client.Strg.Revision)
+	require.Len(t, client.Strg.Texts, 1)
+	require.Equal(t, "note", client.Strg.Texts[0].Name)
+
+	// Синхронизация с конфликтом локальных изменений
+	client.Strg.EditUsersText(0, &clientSTRG.Text{Name: "note", Data: "local"})
+	syncBZ, _ = clientRsa.EncryptUserData([]byte(`{"Name":"note","Data":"remote"}`))
+	synced = records.Record{ID: "rec3", Type: records.TypeText, Version: 2, TimeStamp: timeStamp, Data: syncBZ, Revision: 6}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(5), gomock.Any()).Return(int64(6), nil, []records.Record{synced}, nil)
+	conflicts, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 1, conflicts)
+	require.Len(t, client.Strg.Texts, 2)
+
+	// Запись одновременно в списке измененных и в списке конфликтов: локальные изменения не теряются
+	client.Strg.EditUsersText(0, &clientSTRG.Text{Name: "draft", Data: "local edit"})
+	localID := client.Strg.Texts[0].ID
+	syncBZ, _ = clientRsa.EncryptUserData([]byte(`{"Name":"draft","Data":"server edit"}`))
+	synced = records.Record{ID: localID, Type: records.TypeText, Version: 5, TimeStamp: timeStamp, Data: syncBZ, Revision: 7}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(6), gomock.Any()).Return(int64(7), []records.Record{synced}, []records.Record{synced}, nil)
+	conflicts, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 1, conflicts)
+	texts := make(map[string]string)
+	for _, text := range client.Strg.Texts {
+		texts[text.Name] = text.Data
+	}
+	require.Equal(t, "server edit", texts["draft"])
+	require.Equal(t, "local edit", texts["draft (конфликт)"])
+
+	// Повторная отправка изменения, уже сохраненного на сервере, например из кэша, не обновленного после сбоя:
+	// совпадающая серверная копия принимается без конфликта и без копии записи
+	pending, err := client.Strg.ChangedRecords()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	syncBZ, _ = clientRsa.EncryptUserData(pending[0].Data)
+	synced = records.Record{ID: pending[0].ID, Type: records.TypeText, Version: 3, TimeStamp: timeStamp, Data: syncBZ, Revision: 8}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(7), gomock.Len(1)).Return(int64(8), nil, []records.Record{synced}, nil)
+	conflicts, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 0, conflicts)
+	require.Len(t, client.Strg.Texts, len(texts))
+	require.Equal(t, 0, client.Strg.ChangedCount())
+
+	// Получение истории версий данных
+	strg.EXPECT().ListHistory("1234567890").Return([]storage.History{{Version: 1, TimeStamp: timeStamp}, {Version: 0, TimeStamp: timeStamp}}, nil)
+	history, err := client.ListHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, int64(1), history[0].Version)
+
+	// Восстановление отсутствующей в истории версии
+	strg.EXPECT().RestoreVersion("1234567890", clientRsa.GetSessionID(), int64(5), int64(1)).Return("", int64(0), nil, gkerrors.ErrNoSuchVersion)
+	err = client.RestoreVersion(5)
+	require.Error(t, err)
+
+	// Восстановление версии из истории. Вложение, снова сохраняемое сервером, но не используемое
+	// восстановленными записями, клиент освобождает после загрузки данных
+	strg.EXPECT().RestoreVersion("1234567890", clientRsa.GetSessionID(), int64(0), int64(1)).Return(timeStamp, int64(2), []string{"reclaimed1"}, nil)
+	strg.EXPECT().UsersData("1234567890").Return(nil, timeStamp, int64(2), gkerrors.ErrNoUserData)
+	strg.EXPECT().ReleaseAttachment("1234567890", "reclaimed1").Return(nil)
+	strg.EXPECT().ExpiredAttachments("1234567890").Return(nil, nil)
+	strg.EXPECT().StaleAttachments("1234567890", gomock.Any()).Return(nil, nil)
+	err = client.RestoreVersion(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), client.Strg.Version)
+
+	// Вход пользователя со второго устройства
+	laptopRsa, err := clientCRPT.NewUserSession()
+	require.NoError(t, err)
+	laptopAuth := clientInterceptor.NewAuthClient(laptopRsa)
+	laptopConn, err := grpc.Dial(clientCnfg.RunAddress, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(laptopAuth.Unary()), grpc.WithStreamInterceptor(laptopAuth.Stream()))
+	require.NoError(t, err)
+	defer laptopConn.Close()
+	laptop := sender.NewGophKeeperClient(laptopConn, laptopRsa, clientSTRG.NewUserStorage())
+	laptop.Version = "v0.0.2"
+	laptop.Device = "laptop"
+	err = laptop.ReqSessionID()
+	require.NoError(t, err)
+	strg.EXPECT().UserKeyParams("userName5").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	expectAuthAllowed("login:userName5")
+	strg.EXPECT().AuthUser("userName5", registeredAuth).Return("1234567890", registered, nil)
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5"}, nil)
+	expectAuthSucceeded("login:userName5")
+	err = laptop.UserLogin("userName5", "123")
+	require.NoError(t, err)
+
+	// Список активных сессий пользователя
+	sessions, err := client.ListSessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.True(t, sessions[0].Current)
+	require.Equal(t, clientRsa.GetSessionID(), sessions[0].SessionID)
+	require.False(t, sessions[1].Current)
+	require.Equal(t, laptopRsa.GetSessionID(), sessions[1].SessionID)
+	require.Equal(t, "v0.0.2", sessions[1].ClientVersion)
+	require.Equal(t, "laptop", sessions[1].DeviceName)
+	require.Equal(t, "desktop", sessions[0].DeviceName)
+	require.NotEmpty(t, sessions[1].RemoteAddr)
+
+	// Запрос, подписанный своей сессией, но с чужим SessionID в сообщении, отклоняется
+	_, err = proto.NewGophKeeperClient(conn).ListSessions(context.Background(), &proto.ListSessionsRequest{SessionID: laptopRsa.GetSessionID()})
+	require.Equal(t, codes.Unauthenticated, grpcStatus.Code(err))
+	_, err = proto.NewGophKeeperClient(conn).RevokeSession(context.Background(), &proto.RevokeSessionRequest{SessionID: laptopRsa.GetSessionID(), RevokeSessionID: clientRsa.GetSessionID()})
+	require.Equal(t, codes.Unauthenticated, grpcStatus.Code(err))
+
+	// Уведомление второго устройства о сохранении записи первым устройством
+	events := make(chan sender.ChangeEvent, 1)
+	stopWatch := laptop.StartWatch(func(event sender.ChangeEvent) {
+		events <- event
+	})
+	require.Eventually(t, func() bool {
+		gRPCconf.watch.mu.Lock()
+		defer gRPCconf.watch.mu.Unlock()
+		return len(gRPCconf.watch.users["1234567890"]) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	record = records.Record{ID: "rec2", Type: records.TypeText, Version: 1, Revision: 9, TimeStamp: timeStamp, Data: recordBZ}
+	strg.EXPECT().CreateRecord("1234567890", clientRsa.GetSessionID(), gomock.Any()).Return(record, nil)
+	_, err = client.CreateRecord(records.Record{ID: "rec2", Type: records.TypeText, Data: []byte("record data")})
+	require.NoError(t, err)
+	select {
+	case event := <-events:
+		require.Equal(t, sender.ChangeSaved, event.Kind)
+		require.Equal(t, int64(9), event.Revision)
+		require.Equal(t, "desktop", event.DeviceName)
+		require.True(t, laptop.IsNewer(event))
+	case <-time.After(5 * time.Second):
+		t.Fatal("change event not received")
+	}
+
+	// Запись неизвестного типа, сохраненная более новой версией клиента, не применяется и не отбрасывается молча:
+	// синхронизация завершается ошибкой, ревизия клиента не меняется, и запись будет получена повторно
+	revision := client.Strg.Revision
+	unknownBZ, _ := clientRsa.EncryptUserData([]byte(`{"Name":"key"}`))
+	unknown := records.Record{ID: "passkey1", Type: "passkey", Version: 1, Revision: revision + 1, TimeStamp: timeStamp, Data: unknownBZ}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), revision, gomock.Any()).Return(revision+1, []records.Record{unknown}, nil, nil)
+	_, err = client.Sync()
+	require.ErrorIs(t, err, gkerrors.ErrRecordTypeUnknown)
+	require.Equal(t, revision, client.Strg.Revision)
+
+	// Синхронизация, в которой ни одна запись не сохранена, других сессий не уведомляет:
+	// новая запись оказалась конфликтом, а удаленная запись уже удалена на сервере
+	client.Strg.AddUsersText(&clientSTRG.Text{Name: "offline", Data: "local"})
+	require.True(t, client.Strg.DeleteUsersText(0))
+	pending, err = client.Strg.ChangedRecords()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	var offlineID string
+	for _, rec := range pending {
+		if !rec.Deleted {
+			offlineID = rec.ID
+		}
+	}
+	serverBZ, _ := clientRsa.EncryptUserData([]byte(`{"Name":"offline","Data":"server"}`))
+	conflicted := records.Record{ID: offlineID, Type: records.TypeText, Version: 1, Revision: 9, TimeStamp: timeStamp, Data: serverBZ}
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), gomock.Any(), gomock.Len(2)).Return(int64(10), nil, []records.Record{conflicted}, nil)
+	conflicts, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 1, conflicts)
+
+	// Синхронизация с сохраненной записью уведомляет другие сессии
+	strg.EXPECT().SyncRecords("1234567890", clientRsa.GetSessionID(), int64(10), gomock.Any()).DoAndReturn(func(userID, sessionID string, revision int64, changes []records.Record) (int64, []records.Record, []records.Record, error) {
+		saved := make([]records.Record, len(changes))
+		for i, rec := range changes {
+			saved[i] = rec
+			saved[i].Version, saved[i].Revision, saved[i].TimeStamp = rec.Version+1, 11, timeStamp
+		}
+		return 11, saved, nil, nil
+	})
+	conflicts, err = client.Sync()
+	require.NoError(t, err)
+	require.Equal(t, 0, conflicts)
+	select {
+	case event := <-events:
+		require.Equal(t, sender.ChangeSaved, event.Kind)
+		require.Equal(t, int64(11), event.Revision)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change event not received")
+	}
+	stopWatch()
+
+	// Объем вложений учитывается в базе данных
+	ledger := make(map[string]int64)
+	usage := func(userID string) (int64, error) {
+		var used int64
+		for _, size := range ledger {
+			used += size
+		}
+		return used, nil
+	}
+	strg.EXPECT().StaleAttachments("1234567890", gomock.Any()).Return(nil, nil).AnyTimes()
+	strg.EXPECT().AttachmentsUsage("1234567890").DoAndReturn(usage).AnyTimes()
+	strg.EXPECT().ReserveAttachment("1234567890", gomock.Any(), gomock.Any(), int64(1<<20)).DoAndReturn(func(userID, id string, size, quota int64) error {
+		used, _ := usage(userID)
+		if used+size > quota {
+			return gkerrors.ErrQuotaExceeded
+		}
+		ledger[id] += size
+		return nil
+	}).AnyTimes()
+	strg.EXPECT().CommitAttachment("1234567890", gomock.Any(), gomock.Any()).DoAndReturn(func(userID, id string, size int64) error {
+		ledger[id] = size
+		return nil
+	}).AnyTimes()
+	strg.EXPECT().DropAttachment("1234567890", gomock.Any()).DoAndReturn(func(userID, id string) error {
+		delete(ledger, id)
+		return nil
+	}).AnyTimes()
+	// Версий данных в истории нет, поэтому освобожденные вложения сразу удаляются
+	released := make(map[string]bool)
+	strg.EXPECT().ReleaseAttachment("1234567890", gomock.Any()).DoAndReturn(func(userID, id string) error {
+		if _, ok := ledger[id]; !ok || released[id] {
+			return gkerrors.ErrNoSuchAttachment
+		}
+		released[id] = true
+		return nil
+	}).AnyTimes()
+	strg.EXPECT().ExpiredAttachments("1234567890").DoAndReturn(func(userID string) ([]string, error) {
+		ids := make([]string, 0, len(released))
+		for id := range released {
+			ids = append(ids, id)
+			delete(released, id)
+		}
+		return ids, nil
+	}).AnyTimes()
+
+	// Загрузка большого файла вложением и сохранение его содержимого в файл
+	dir := t.TempDir()
+	content := bytes.Repeat([]byte("attachment data "), 40000)
+	err = os.WriteFile(filepath.Join(dir, "large.bin"), content, 0600)
+	require.NoError(t, err)
+	var binary clientSTRG.Binary
+	err = client.ReadBinary(filepath.Join(dir, "large.bin"), &binary)
+	require.NoError(t, err)
+	require.NotEmpty(t, binary.Attachment)
+	require.Nil(t, binary.Data)
+	require.Equal(t, int64(len(content)), binary.Size)
+	require.Equal(t, "large.bin", binary.Name)
+	err = client.SaveBinaryFile(binary, filepath.Join(dir, "copy.bin"))
+	require.NoError(t, err)
+	saved, err := os.ReadFile(filepath.Join(dir, "copy.bin"))
+	require.NoError(t, err)
+	require.Equal(t, content, saved)
+
+	// Несовпадение хэша содержимого вложения
+	corrupted := binary
+	corrupted.Hash = "00"
+	err = client.SaveBinaryFile(corrupted, filepath.Join(dir, "corrupted.bin"))
+	require.ErrorIs(t, err, gkerrors.ErrAttachmentHash)
+	_, err = os.Stat(filepath.Join(dir, "corrupted.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	var out bytes.Buffer
+	err = client.WriteBinary(corrupted, &out)
+	require.ErrorIs(t, err, gkerrors.ErrAttachmentHash)
+	require.Zero(t, out.Len())
+
+	// Превышение квоты вложений пользователя
+	err = os.WriteFile(filepath.Join(dir, "huge.bin"), bytes.Repeat([]byte{1}, 1<<20), 0600)
+	require.NoError(t, err)
+	var huge clientSTRG.Binary
+	err = client.ReadBinary(filepath.Join(dir, "huge.bin"), &huge)
+	require.ErrorIs(t, err, gkerrors.ErrQuotaExceeded)
+
+	// Маленький файл сохраняется в самой записи
+	err = os.WriteFile(filepath.Join(dir, "small.bin"), []byte("small"), 0600)
+	require.NoError(t, err)
+	var small clientSTRG.Binary
+	err = client.ReadBinary(filepath.Join(dir, "small.bin"), &small)
+	require.NoError(t, err)
+	require.Empty(t, small.Attachment)
+	require.Equal(t, []byte("small"), small.Data)
+
+	// Удаление вложения
+	err = client.DeleteAttachment(binary.Attachment)
+	require.NoError(t, err)
+	err = client.DeleteAttachment(binary.Attachment)
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+	err = client.SaveBinaryFile(binary, filepath.Join(dir, "deleted.bin"))
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchAttachment)
+
+	// Текущая сессия не завершается методом RevokeSession
+	err = client.RevokeSession(clientRsa.GetSessionID())
+	require.Error(t, err)
+
+	// Завершение сессии на втором устройстве: блокировка данных, установленная этой сессией, снимается,
+	// ее поток уведомлений закрывается сразу, а другие сессии получают уведомление о снятии блокировки
+	stopLaptopWatch := laptop.StartWatch(func(sender.ChangeEvent) {})
+	unlocked := make(chan sender.ChangeEvent, 1)
+	stopWatch = client.StartWatch(func(event sender.ChangeEvent) {
+		unlocked <- event
+	})
+	require.Eventually(t, func() bool {
+		gRPCconf.watch.mu.Lock()
+		defer gRPCconf.watch.mu.Unlock()
+		return len(gRPCconf.watch.users["1234567890"]) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	strg.EXPECT().ReleaseLock("1234567890", laptopRsa.GetSessionID()).Return(nil)
+	err = client.RevokeSession(laptopRsa.GetSessionID())
+	require.NoError(t, err)
+	gRPCconf.watch.mu.Lock()
+	require.Len(t, gRPCconf.watch.users["1234567890"], 1)
+	gRPCconf.watch.mu.Unlock()
+	select {
+	case event := <-unlocked:
+		require.Equal(t, sender.ChangeUnlocked, event.Kind)
+		require.Equal(t, "laptop", event.DeviceName)
+	case <-time.After(5 * time.Second):
+		t.Fatal("unlock event not received")
+	}
+	stopLaptopWatch()
+	stopWatch()
+	err = laptop.CheckTimeStamp()
+	require.Error(t, err)
+	err = client.RevokeSession(laptopRsa.GetSessionID())
+	require.Error(t, err)
+	sessions, err = client.ListSessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
 
 	// Попытка смены пароля при неверном пароле
-	strg.EXPECT().ChangeUserPassword("1234567890", crypto.HashPasswd("456"), crypto.HashPasswd("123")).Return(false, gkerrors.ErrWrongPassword)
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().ChangeUserPassword("1234567890", clientCRPT.DeriveMasterKey("456", params).AuthKey, gomock.Any(), gomock.Any()).Return(false, gkerrors.ErrWrongPassword)
 	status, err = client.ChangePassword("456", "123")
-	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
 	require.Equal(t, false, status)
 
-	// Успешная смена пароля
-	strg.EXPECT().ChangeUserPassword("1234567890", crypto.HashPasswd("123"), crypto.HashPasswd("456")).Return(true, nil)
+	// Успешная смена пароля с повторным шифрованием ключа данных
+	var changed storage.UserKey
+	var changedAuth string
+	expectAuthAllowed("user:1234567890")
+	expectAuthSucceeded("user:1234567890")
+	strg.EXPECT().ChangeUserPassword("1234567890", registeredAuth, gomock.Any(), gomock.Any()).DoAndReturn(func(userID, oldAuthKey, authKey string, key storage.UserKey) (bool, error) {
+		changed, changedAuth = key, authKey
+		return true, nil
+	})
 	status, err = client.ChangePassword("123", "456")
 	require.NoError(t, err)
 	require.Equal(t, true, status)
+	newParams := clientCRPT.KeyParams{Salt: changed.Salt, Time: changed.Time, Memory: changed.Memory, Threads: changed.Threads}
+	newMaster := clientCRPT.DeriveMasterKey("456", newParams)
+	require.Equal(t, newMaster.AuthKey, changedAuth)
+	oldKey, err := clientCRPT.DeriveMasterKey("123", params).Unwrap(registered.WrappedKey)
+	require.NoError(t, err)
+	newKey, err := newMaster.Unwrap(changed.WrappedKey)
+	require.NoError(t, err)
+	require.Equal(t, oldKey, newKey)
+
+	// Выдача секрета двухфакторной аутентификации
+	var secret []byte
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5"}, nil)
+	strg.EXPECT().SetTOTP("1234567890", gomock.Any(), gomock.Len(10)).DoAndReturn(func(userID string, totpSecret []byte, hashes []string) error {
+		secret = totpSecret
+		return nil
+	})
+	setup, err := client.EnableTOTP()
+	require.NoError(t, err)
+	require.Equal(t, crypto.TOTPSecretString(secret), setup.Secret)
+	require.Equal(t, crypto.TOTPURI("userName5", secret), setup.URI)
+	require.Len(t, setup.RecoveryCodes, 10)
+
+	// Подтверждение секрета неверным и верным кодом, неверный код учитывается в счетчике неудачных попыток
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5", Secret: secret}, nil)
+	expectAuthAllowed("user:1234567890")
+	err = client.ConfirmTOTP("abcdef")
+	require.ErrorIs(t, err, gkerrors.ErrTOTPCodeIncorrect)
+	step := crypto.TOTPStep(time.Now())
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5", Secret: secret}, nil)
+	strg.EXPECT().AuthAttempt("user:1234567890", cnfg.LoginAttempts).Return(time.Now().Add(time.Minute), false, nil)
+	err = client.ConfirmTOTP(crypto.TOTPCode(secret, step))
+	require.Equal(t, codes.ResourceExhausted, grpcStatus.Code(err))
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5", Secret: secret}, nil)
+	expectAuthAllowed("user:1234567890")
+	expectAuthSucceeded("user:1234567890")
+	strg.EXPECT().EnableTOTP("1234567890", gomock.Any()).Return(nil)
+	err = client.ConfirmTOTP(crypto.TOTPCode(secret, step))
+	require.NoError(t, err)
+
+	// Повторная выдача секрета при включенной двухфакторной аутентификации
+	enabled := storage.TOTPInfo{Login: "userName5", Secret: secret, Enabled: true, LastStep: step - 1}
+	strg.EXPECT().UserTOTP("1234567890").Return(enabled, nil)
+	_, err = client.EnableTOTP()
+	require.ErrorIs(t, err, gkerrors.ErrTOTPEnabled)
+
+	// Авторизация без кода, с неверным и с верным кодом
+	expectLogin := func() {
+		strg.EXPECT().UserKeyParams("userName5").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+		expectAuthAllowed("login:userName5")
+		strg.EXPECT().AuthUser("userName5", registeredAuth).Return("1234567890", registered, nil)
+		strg.EXPECT().UserTOTP("1234567890").Return(enabled, nil)
+	}
+	expectLogin()
+	expectAuthReleased("login:userName5")
+	err = client.UserLogin("userName5", "123")
+	require.ErrorIs(t, err, gkerrors.ErrTOTPRequired)
+	expectLogin()
+	strg.EXPECT().UseRecoveryCode("1234567890", crypto.HashRecoveryCode("wrong-code")).Return(false, nil)
+	err = client.UserLoginTOTP("userName5", "123", "wrong-code")
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+	expectLogin()
+	strg.EXPECT().UseTOTPStep("1234567890", step).Return(true, nil)
+	expectAuthSucceeded("login:userName5")
+	err = client.UserLoginTOTP("userName5", "123", crypto.TOTPCode(secret, step))
+	require.NoError(t, err)
+
+	// Отключение двухфакторной аутентификации кодом восстановления
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().UserTOTP("1234567890").Return(enabled, nil)
+	strg.EXPECT().UseRecoveryCode("1234567890", crypto.HashRecoveryCode(setup.RecoveryCodes[0])).Return(true, nil)
+	expectAuthSucceeded("user:1234567890")
+	strg.EXPECT().DisableTOTP("1234567890").Return(nil)
+	err = client.DisableTOTP(setup.RecoveryCodes[0])
+	require.NoError(t, err)
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5"}, nil)
+	expectAuthReleased("user:1234567890")
+	err = client.DisableTOTP(setup.RecoveryCodes[1])
+	require.ErrorIs(t, err, gkerrors.ErrTOTPNotEnabled)
 
 	// Закрытие сессии
 	client.UserLogOut()
@@ -213,9 +825,53 @@ func TestServer(t *testing.T) {
 	// Закрытие удаленной сессии (возврат ошибки)
 	client.UserLogOut()
 
+	// Удаление учетной записи с неверным и верным паролем
+	err = client.ReqSessionID()
+	require.NoError(t, err)
+	strg.EXPECT().UserKeyParams("userName5").Return(storage.UserKey{Salt: registered.Salt, Time: registered.Time, Memory: registered.Memory, Threads: registered.Threads}, nil)
+	expectAuthAllowed("login:userName5")
+	strg.EXPECT().AuthUser("userName5", registeredAuth).Return("1234567890", registered, nil)
+	strg.EXPECT().UserTOTP("1234567890").Return(storage.TOTPInfo{Login: "userName5"}, nil)
+	expectAuthSucceeded("login:userName5")
+	err = client.UserLogin("userName5", "123")
+	require.NoError(t, err)
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().DeleteUser("1234567890", clientCRPT.DeriveMasterKey("456", params).AuthKey).Return(gkerrors.ErrWrongPassword)
+	err = client.DeleteAccount("456")
+	require.Equal(t, codes.PermissionDenied, grpcStatus.Code(err))
+	expectAuthAllowed("user:1234567890")
+	strg.EXPECT().DeleteUser("1234567890", registeredAuth).Return(nil)
+	expectAuthSucceeded("user:1234567890")
+	err = client.DeleteAccount("123")
+	require.NoError(t, err)
+
+	// Сессии удаленного пользователя завершены
+	_, err = client.ListSessions()
+	require.Equal(t, codes.Unauthenticated, grpcStatus.Code(err))
+
 	// Оканчиваем тестирование
 
 	strg.EXPECT().CloseDB()
 	server.GracefulStop()
 	strg.CloseDB()
 }
+
+func TestFakeKeyParams(t *testing.T) {
+	s := &GophKeeperServer{cfg: &config.Config{LoginSecret: "0123456789abcdef"}}
+	var legacy, master int
+	for i := 0; i < 100; i++ {
+		login := "login" + strconv.Itoa(i)
+		key := s.fakeKeyParams(login)
+		// Параметры постоянны для логина, иначе повторный запрос раскроет отсутствие пользователя
+		require.Equal(t, key, s.fakeKeyParams(login))
+		if key.Legacy() {
+			legacy++
+			continue
+		}
+		require.Len(t, key.Salt, 16)
+		master++
+	}
+	// Незарегистрированные логины отвечают обоими вариантами, как и зарегистрированные пользователи
+	require.Greater(t, legacy, 0)
+	require.Greater(t, master, legacy)
+}
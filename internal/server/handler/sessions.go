@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/interceptor"
+)
+
+// ListSessions передает клиенту список действующих сессий пользователя, упорядоченный по времени создания.
+func (s *GophKeeperServer) ListSessions(ctx context.Context, in *pb.ListSessionsRequest) (*pb.ListSessionsResponce, error) {
+	sessionID := interceptor.SessionID(ctx)
+	userID := s.rsa.GetUserID(sessionID)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user is not authenticated")
+	}
+	sessions, err := s.rsa.UserSessions(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListSessions UserSessions error")
+		return nil, status.Error(codes.Internal, "UserSessions error")
+	}
+
+	sessionIDs := make([]string, 0, len(sessions))
+	for id := range sessions {
+		sessionIDs = append(sessionIDs, id)
+	}
+	sort.Slice(sessionIDs, func(i, j int) bool {
+		return sessions[sessionIDs[i]].Created.Before(sessions[sessionIDs[j]].Created)
+	})
+	var responce = pb.ListSessionsResponce{Sessions: make([]*pb.SessionInfo, 0, len(sessions))}
+	for _, id := range sessionIDs {
+		session := sessions[id]
+		responce.Sessions = append(responce.Sessions, &pb.SessionInfo{
+			SessionID:     id,
+			Created:       session.Created.Format(time.RFC3339),
+			LastSeen:      session.LastSeen.Format(time.RFC3339),
+			DeviceName:    session.DeviceName,
+			ClientVersion: session.ClientVersion,
+			RemoteAddr:    session.RemoteAddr,
+			Current:       id == sessionID,
+		})
+	}
+	responce.Sign, err = s.rsa.SignData(sessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("ListSessions signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// RevokeSession завершает другую сессию пользователя, например на утерянном устройстве.
+// Текущая сессия завершается методом LogOut.
+func (s *GophKeeperServer) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest) (*pb.RevokeSessionResponce, error) {
+	sessionID := interceptor.SessionID(ctx)
+	if in.RevokeSessionID == sessionID {
+		return nil, status.Error(codes.InvalidArgument, "current session is closed by LogOut")
+	}
+	userID := s.rsa.GetUserID(sessionID)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user is not authenticated")
+	}
+	// Данные завершаемой сессии нужны для уведомления о снятии ее блокировки, после завершения они недоступны
+	revoked, _ := s.rsa.SessionInfo(in.RevokeSessionID)
+	err := s.rsa.RevokeSession(userID, in.RevokeSessionID)
+	if errors.Is(err, gkerrors.ErrNoSuchSession) {
+		return nil, status.Error(codes.NotFound, "users session not found")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("RevokeSession error")
+		return nil, status.Error(codes.Internal, "RevokeSession error")
+	}
+	// Поток уведомлений завершенной сессии закрывается сразу, не дожидаясь очередной проверки сессии
+	s.watch.closeSession(userID, in.RevokeSessionID)
+	// Блокировка данных, установленная завершенной сессией, снимается сразу, а не по истечении LockingTime
+	err = s.strg.ReleaseLock(userID, in.RevokeSessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("RevokeSession ReleaseLock error")
+		return nil, status.Error(codes.Internal, "ReleaseLock error")
+	}
+	s.watch.notify(userID, in.RevokeSessionID, &pb.ChangeEvent{
+		Kind:  pb.ChangeKind_UNLOCKED,
+		Owner: &pb.LockOwner{DeviceName: revoked.DeviceName, ClientVersion: revoked.ClientVersion},
+	})
+
+	var responce = pb.RevokeSessionResponce{Status: true}
+	responce.Sign, err = s.rsa.SignData(sessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("RevokeSession signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "gophkeeper/api/grpc/proto"
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/server/crypto"
+	"gophkeeper/internal/server/storage"
+)
+
+// recoveryCodesCount количество одноразовых кодов восстановления, выдаваемых вместе с секретом TOTP.
+const recoveryCodesCount = 10
+
+// EnableTOTP включает двухфакторную аутентификацию в два шага.
+// Запрос без кода выдает новый секрет, ссылку для приложения-аутентификатора и коды восстановления,
+// запрос с кодом из приложения подтверждает секрет и включает проверку кода при входе.
+// Неверные коды подтверждения учитываются в счетчике неудачных попыток так же, как неверный пароль.
+func (s *GophKeeperServer) EnableTOTP(ctx context.Context, in *pb.EnableTOTPRequest) (*pb.EnableTOTPResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	info, err := s.strg.UserTOTP(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("EnableTOTP UserTOTP error")
+		return nil, status.Error(codes.Internal, "UserTOTP error")
+	}
+	if info.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPEnabled.Error())
+	}
+
+	var responce pb.EnableTOTPResponce
+	if len(in.Code) == 0 {
+		err = s.newTOTPSecret(in.SessionID, userID, info.Login, &responce)
+		if errors.Is(err, gkerrors.ErrTOTPEnabled) {
+			return nil, status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPEnabled.Error())
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("EnableTOTP newTOTPSecret error")
+			return nil, status.Error(codes.Internal, "newTOTPSecret error")
+		}
+	} else {
+		if len(info.Secret) == 0 {
+			return nil, status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPNotEnabled.Error())
+		}
+		code, err := s.rsa.DecryptPassword(in.SessionID, in.Code, []byte("totpCode"))
+		if err != nil {
+			log.Error().Err(err).Msg("EnableTOTP DecryptPassword error")
+			return nil, status.Error(codes.Internal, "DecryptData error")
+		}
+		attempt, err := s.reserveAttempt(s.authCounters(ctx, storage.UserAuthKey(userID)))
+		if err != nil {
+			return nil, err
+		}
+		defer attempt.release()
+		step, ok := crypto.CheckTOTP(info.Secret, code, time.Now())
+		if !ok {
+			// Попытка остается учтенной, клиенту возвращается прежняя ошибка, чтобы он предложил повторить ввод кода
+			attempt.failed()
+			return nil, status.Error(codes.InvalidArgument, gkerrors.ErrTOTPCodeIncorrect.Error())
+		}
+		attempt.succeeded()
+		err = s.strg.EnableTOTP(userID, step)
+		if errors.Is(err, gkerrors.ErrTOTPNotEnabled) {
+			return nil, status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPNotEnabled.Error())
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("EnableTOTP error")
+			return nil, status.Error(codes.Internal, "EnableTOTP error")
+		}
+		responce.Enabled = true
+	}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("EnableTOTP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// newTOTPSecret метод генерирует и сохраняет секрет TOTP и коды восстановления,
+// а затем зашифровывает их для передачи клиенту по каналу сессии.
+func (s *GophKeeperServer) newTOTPSecret(sessionID, userID, login string, responce *pb.EnableTOTPResponce) error {
+	secret, err := crypto.NewTOTPSecret()
+	if err != nil {
+		return err
+	}
+	recoveryCodes, err := crypto.NewRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return err
+	}
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = crypto.HashRecoveryCode(code)
+	}
+	err = s.strg.SetTOTP(userID, secret, hashes)
+	if err != nil {
+		return err
+	}
+	responce.Secret, err = s.rsa.EncryptData(sessionID, crypto.TOTPSecretString(secret), []byte("totpSecret"))
+	if err != nil {
+		return err
+	}
+	responce.Uri, err = s.rsa.EncryptData(sessionID, crypto.TOTPURI(login, secret), []byte("totpURI"))
+	if err != nil {
+		return err
+	}
+	responce.RecoveryCodes, err = s.rsa.EncryptData(sessionID, strings.Join(recoveryCodes, "\n"), []byte("recoveryCodes"))
+	return err
+}
+
+// DisableTOTP отключает двухфакторную аутентификацию по текущему коду TOTP или коду восстановления.
+// Неверные коды учитываются в счетчике неудачных попыток так же, как неверный пароль.
+func (s *GophKeeperServer) DisableTOTP(ctx context.Context, in *pb.DisableTOTPRequest) (*pb.DisableTOTPResponce, error) {
+	userID := s.rsa.GetUserID(in.SessionID)
+	code, err := s.rsa.DecryptPassword(in.SessionID, in.Code, []byte("totpCode"))
+	if err != nil {
+		log.Error().Err(err).Msg("DisableTOTP DecryptPassword error")
+		return nil, status.Error(codes.Internal, "DecryptData error")
+	}
+	counters := s.authCounters(ctx, storage.UserAuthKey(userID))
+	attempt, err := s.reserveAttempt(counters)
+	if err != nil {
+		return nil, err
+	}
+	defer attempt.release()
+	info, err := s.strg.UserTOTP(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("DisableTOTP UserTOTP error")
+		return nil, status.Error(codes.Internal, "UserTOTP error")
+	}
+	if !info.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPNotEnabled.Error())
+	}
+	ok, err := s.checkSecondFactor(userID, info, code)
+	if err != nil {
+		log.Error().Err(err).Msg("DisableTOTP checkSecondFactor error")
+		return nil, status.Error(codes.Internal, "checkSecondFactor error")
+	}
+	if !ok {
+		return nil, attempt.failed()
+	}
+	attempt.succeeded()
+	err = s.strg.DisableTOTP(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("DisableTOTP error")
+		return nil, status.Error(codes.Internal, "DisableTOTP error")
+	}
+	var responce = pb.DisableTOTPResponce{Status: true}
+	responce.Sign, err = s.rsa.SignData(in.SessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("DisableTOTP signing error")
+		return nil, status.Error(codes.Internal, "EncryptData error")
+	}
+	return &responce, nil
+}
+
+// loginSecondFactor метод проверяет код TOTP при входе пользователя, у которого включена двухфакторная аутентификация.
+// Без кода возвращает FailedPrecondition, чтобы клиент запросил код у пользователя, неверный код учитывается как неудачная попытка входа.
+func (s *GophKeeperServer) loginSecondFactor(in *pb.LoginUserRequest, userID string, attempt *authAttempt) error {
+	info, err := s.strg.UserTOTP(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("LoginUser UserTOTP error")
+		return status.Error(codes.Internal, "UserTOTP error")
+	}
+	if !info.Enabled {
+		return nil
+	}
+	if len(in.TotpCode) == 0 {
+		return status.Error(codes.FailedPrecondition, gkerrors.ErrTOTPRequired.Error())
+	}
+	code, err := s.rsa.DecryptPassword(in.SessionID, in.TotpCode, []byte("totpCode"))
+	if err != nil {
+		log.Error().Err(err).Msg("LoginUser DecryptPassword error")
+		return status.Error(codes.Internal, "DecryptData error")
+	}
+	ok, err := s.checkSecondFactor(userID, info, code)
+	if err != nil {
+		log.Error().Err(err).Msg("LoginUser checkSecondFactor error")
+		return status.Error(codes.Internal, "checkSecondFactor error")
+	}
+	if !ok {
+		return attempt.failed()
+	}
+	return nil
+}
+
+// checkSecondFactor метод проверяет код TOTP или код восстановления пользователя.
+// Принятый код TOTP и использованный код восстановления повторно не принимаются.
+func (s *GophKeeperServer) checkSecondFactor(userID string, info storage.TOTPInfo, code string) (bool, error) {
+	if step, ok := crypto.CheckTOTP(info.Secret, code, time.Now()); ok {
+		return s.strg.UseTOTPStep(userID, step)
+	}
+	return s.strg.UseRecoveryCode(userID, crypto.HashRecoveryCode(code))
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "gophkeeper/api/grpc/proto"
+	"gophkeeper/internal/server/interceptor"
+)
+
+// watchBuffer размер очереди уведомлений одной сессии. При переполнении очереди уведомление пропускается:
+// клиент получит следующее уведомление и синхронизирует все изменения сразу.
+const watchBuffer = 16
+
+// watchCheckInterval интервал проверки действия сессии, подписанной на уведомления.
+const watchCheckInterval = time.Minute
+
+// watcher структура подписки сессии на уведомления об изменениях данных пользователя.
+type watcher struct {
+	sessionID string
+	events    chan *pb.ChangeEvent
+}
+
+// watchers структура рассылает уведомления об изменениях данных пользователя его подключенным сессиям.
+// Подписки хранятся в памяти процесса, поэтому уведомления получают сессии, подключенные к тому же экземпляру сервера.
+type watchers struct {
+	mu     sync.Mutex
+	users  map[string]map[*watcher]bool //Подписки по идентификатору пользователя
+	closed bool
+}
+
+// newWatchers функция создает пустой список подписок.
+func newWatchers() *watchers {
+	return &watchers{users: make(map[string]map[*watcher]bool)}
+}
+
+// subscribe метод подписывает сессию на уведомления об изменениях данных пользователя.
+// Возвращаемая функция отменяет подписку. Канал подписки закрывается при завершении сессии или остановке сервера.
+func (w *watchers) subscribe(userID, sessionID string) (*watcher, func()) {
+	sub := &watcher{sessionID: sessionID, events: make(chan *pb.ChangeEvent, watchBuffer)}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		close(sub.events)
+		return sub, func() {}
+	}
+	if w.users[userID] == nil {
+		w.users[userID] = make(map[*watcher]bool)
+	}
+	w.users[userID][sub] = true
+	return sub, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if !w.users[userID][sub] {
+			return
+		}
+		delete(w.users[userID], sub)
+		if len(w.users[userID]) == 0 {
+			delete(w.users, userID)
+		}
+	}
+}
+
+// notify метод передает уведомление всем сессиям пользователя, кроме сессии, выполнившей изменение.
+func (w *watchers) notify(userID, sessionID string, event *pb.ChangeEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.users[userID] {
+		if sub.sessionID == sessionID {
+			continue
+		}
+		select {
+		case sub.events <- proto.Clone(event).(*pb.ChangeEvent):
+		default:
+			log.Info().Msgf("watch queue is full, event skipped. sessionID = %s", sub.sessionID)
+		}
+	}
+}
+
+// closeSession метод закрывает каналы подписок завершенной сессии, после чего ее потоки уведомлений завершаются.
+func (w *watchers) closeSession(userID, sessionID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.users[userID] {
+		if sub.sessionID != sessionID {
+			continue
+		}
+		close(sub.events)
+		delete(w.users[userID], sub)
+	}
+	if len(w.users[userID]) == 0 {
+		delete(w.users, userID)
+	}
+}
+
+// close метод закрывает каналы всех подписок, после чего потоки уведомлений завершаются.
+func (w *watchers) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	for userID, subs := range w.users {
+		for sub := range subs {
+			close(sub.events)
+		}
+		delete(w.users, userID)
+	}
+}
+
+// WatchChanges передает клиенту уведомления о сохранении данных и блокировке данных другими сессиями пользователя.
+// Поток завершается при отключении клиента, окончании сессии или остановке сервера.
+// Сессия подписки берется из подписи запроса, проверенной перехватчиком, а не из тела запроса.
+func (s *GophKeeperServer) WatchChanges(in *pb.WatchChangesRequest, stream pb.GophKeeper_WatchChangesServer) error {
+	sessionID := interceptor.SessionID(stream.Context())
+	userID := s.rsa.GetUserID(sessionID)
+	if userID == "" {
+		return status.Error(codes.Unauthenticated, "user is not authenticated")
+	}
+	sub, cancel := s.watch.subscribe(userID, sessionID)
+	defer cancel()
+	ticker := time.NewTicker(watchCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+			if s.rsa.GetUserID(sessionID) == "" {
+				return status.Error(codes.Unauthenticated, "sessionID expired")
+			}
+		case event, ok := <-sub.events:
+			if !ok {
+				if s.rsa.GetUserID(sessionID) == "" {
+					return status.Error(codes.Unauthenticated, "sessionID expired")
+				}
+				return status.Error(codes.Unavailable, "server is stopping")
+			}
+			var err error
+			event.Sign, err = s.rsa.SignData(sessionID)
+			if err != nil {
+				log.Error().Err(err).Msg("WatchChanges signing error")
+				return status.Error(codes.Unauthenticated, "sessionID expired")
+			}
+			err = stream.Send(event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StopWatching метод завершает все потоки уведомлений перед остановкой сервера.
+func (s *GophKeeperServer) StopWatching() {
+	s.watch.close()
+}
+
+// notifyChange метод уведомляет другие сессии пользователя об изменении, выполненном сессией sessionID.
+func (s *GophKeeperServer) notifyChange(sessionID string, event *pb.ChangeEvent) {
+	session, err := s.rsa.SessionInfo(sessionID)
+	if err != nil {
+		log.Error().Err(err).Msg("notifyChange SessionInfo error")
+		return
+	}
+	event.Owner = &pb.LockOwner{DeviceName: session.DeviceName, ClientVersion: session.ClientVersion}
+	s.watch.notify(session.UserID, sessionID, event)
+}
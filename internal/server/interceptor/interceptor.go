@@ -3,17 +3,24 @@ package interceptor
 import (
 	"context"
 	"encoding/hex"
+	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"gophkeeper/internal/server/crypto"
 )
 
+// sessionField имя поля сообщения запроса, содержащего SessionID пользователя.
+const sessionField = "sessionID"
+
 type AuthInterceptor struct {
 	rsa *crypto.Sessions
 }
@@ -28,26 +35,122 @@ func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		if strings.Contains(info.FullMethod, "NewSessionID") {
 			return handler(ctx, req)
 		}
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
-		}
-		session := md.Get("userSession")
-		sign := md.Get("userSign")
-		userSign, _ := hex.DecodeString(sign[0])
-		userID, err := interceptor.rsa.CheckSign(session[0], userSign)
+		err := interceptor.authorize(ctx, info.FullMethod, req)
 		if err != nil {
-			log.Error().Err(err).Msg("UserData CheckSign error")
-			return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
-		}
-		if strings.Contains(info.FullMethod, "NewUser") || strings.Contains(info.FullMethod, "LoginUser") {
-			return handler(ctx, req)
-		}
-		if userID == "" {
-			log.Error().Err(err).Msg("UserData userID empty")
-			return nil, status.Error(codes.Unauthenticated, "incorrect sign encryption")
+			return nil, err
 		}
-
 		return handler(ctx, req)
 	}
 }
+
+// Stream метод проверяет подпись потоковых запросов. Подписывается первое сообщение потока,
+// поэтому проверка выполняется при его получении, до передачи сообщения обработчику.
+func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		log.Debug().Msgf("interceptor received stream method = %s", info.FullMethod)
+		return handler(srv, &signedStream{ServerStream: ss, interceptor: interceptor, method: info.FullMethod})
+	}
+}
+
+// signedStream структура потока запросов, первое сообщение которого проверяется по подписи из метаданных.
+type signedStream struct {
+	grpc.ServerStream
+	interceptor *AuthInterceptor
+	method      string
+	checked     bool //Подпись первого сообщения проверена
+}
+
+// RecvMsg метод получает сообщение потока и проверяет подпись первого сообщения.
+func (s *signedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil || s.checked {
+		return err
+	}
+	err = s.interceptor.authorize(s.Context(), s.method, m)
+	if err != nil {
+		return err
+	}
+	s.checked = true
+	return nil
+}
+
+// authorize метод проверяет подпись запроса и авторизацию сессии, из которой он отправлен.
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string, req interface{}) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "metadata is not provided")
+	}
+	session := md.Get("userSession")
+	sign := md.Get("userSign")
+	signTime := md.Get("userTime")
+	signNonce := md.Get("userNonce")
+	if len(session) == 0 || len(sign) == 0 || len(signTime) == 0 || len(signNonce) == 0 {
+		return status.Error(codes.Unauthenticated, "sign is not provided")
+	}
+	userSign, err := hex.DecodeString(sign[0])
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "incorrect sign encryption")
+	}
+	timestamp, err := strconv.ParseInt(signTime[0], 10, 64)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "incorrect sign time")
+	}
+	nonce, err := strconv.ParseUint(signNonce[0], 10, 64)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "incorrect sign nonce")
+	}
+	message, ok := req.(proto.Message)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "request is not a protobuf message")
+	}
+	// обработчики используют SessionID из сообщения, поэтому он должен совпадать с подписанной сессией
+	if sessionID, ok := requestSession(message); !ok || sessionID != session[0] {
+		log.Error().Msgf("request session does not match signed session, method = %s", method)
+		return status.Error(codes.Unauthenticated, "request session mismatch")
+	}
+	userID, err := interceptor.rsa.CheckSign(session[0], method, timestamp, nonce, message, userSign)
+	if err != nil {
+		log.Error().Err(err).Msg("UserData CheckSign error")
+		return status.Error(codes.Unauthenticated, "incorrect sign encryption")
+	}
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+	err = interceptor.rsa.TouchSession(session[0], remoteAddr)
+	if err != nil {
+		log.Error().Err(err).Msg("TouchSession error")
+	}
+	if strings.Contains(method, "NewUser") || strings.Contains(method, "LoginUser") || strings.Contains(method, "KeyParams") {
+		return nil
+	}
+	if userID == "" {
+		log.Error().Err(err).Msg("UserData userID empty")
+		return status.Error(codes.Unauthenticated, "incorrect sign encryption")
+	}
+	return nil
+}
+
+// SessionID функция возвращает SessionID, которым подписан запрос. Подпись и совпадение SessionID
+// с полем запроса проверяются перехватчиком до передачи запроса обработчику.
+func SessionID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	session := md.Get("userSession")
+	if len(session) == 0 {
+		return ""
+	}
+	return session[0]
+}
+
+// requestSession функция возвращает значение поля sessionID сообщения запроса, или false, если поля нет.
+func requestSession(message proto.Message) (string, bool) {
+	msg := message.ProtoReflect()
+	field := msg.Descriptor().Fields().ByName(sessionField)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return "", false
+	}
+	return msg.Get(field).String(), true
+}
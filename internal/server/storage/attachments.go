@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// ReserveAttachment метод резервирует size байт в квоте пользователя для загружаемого вложения.
+// Объем всех вложений пользователя проверяется под блокировкой строки пользователя, поэтому квота соблюдается
+// при одновременных загрузках, в том числе на разных экземплярах сервера. При превышении квоты возвращается ошибка ErrQuotaExceeded.
+func (s *Storage) ReserveAttachment(userID, attachmentID string, size, quota int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = lockUserRow(tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gkerrors.ErrNoSuchUser
+	}
+	if err != nil {
+		return err
+	}
+	var used int64
+	err = tx.QueryRow("SELECT COALESCE(sum(size), 0) FROM GophKeeperAttachments WHERE user_id = $1", userID).Scan(&used)
+	if err != nil {
+		return err
+	}
+	if size <= 0 || used+size > quota {
+		return gkerrors.ErrQuotaExceeded
+	}
+	result, err := tx.Exec(`INSERT INTO GophKeeperAttachments(user_id, attachment_id, size) VALUES($1, $2, $3)
+		ON CONFLICT (user_id, attachment_id) DO UPDATE SET size = GophKeeperAttachments.size + EXCLUDED.size WHERE NOT GophKeeperAttachments.stored`,
+		userID, attachmentID, size)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	return tx.Commit()
+}
+
+// CommitAttachment метод отмечает загрузку вложения завершенной и учитывает в квоте фактический объем вложения size байт.
+func (s *Storage) CommitAttachment(userID, attachmentID string, size int64) error {
+	result, err := s.db.Exec("UPDATE GophKeeperAttachments SET size = $3, stored = true WHERE user_id = $1 AND attachment_id = $2 AND NOT stored",
+		userID, attachmentID, size)
+	return affectedAttachment(result, err)
+}
+
+// ReleaseAttachment метод отмечает, что на сохраненное вложение больше не ссылаются текущие данные пользователя.
+// Вложение остается в квоте и хранилище, пока на него могут ссылаться версии данных в истории.
+// Если вложение не найдено или уже освобождено, возвращается ошибка ErrNoSuchAttachment.
+func (s *Storage) ReleaseAttachment(userID, attachmentID string) error {
+	result, err := s.db.Exec(`UPDATE GophKeeperAttachments SET released_version = (SELECT version FROM GophKeeper WHERE user_id = $1)
+		WHERE user_id = $1 AND attachment_id = $2 AND stored AND released_version IS NULL`, userID, attachmentID)
+	return affectedAttachment(result, err)
+}
+
+// ExpiredAttachments метод возвращает идентификаторы освобожденных вложений пользователя, на которые не может ссылаться
+// ни одна версия данных в истории: все версии, сохраненные до освобождения вложения, удалены из истории.
+func (s *Storage) ExpiredAttachments(userID string) ([]string, error) {
+	return attachmentIDs(s.db, `SELECT attachment_id FROM GophKeeperAttachments a WHERE user_id = $1 AND released_version IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM GophKeeperHistory h WHERE h.user_id = a.user_id AND h.version <= a.released_version)`, userID)
+}
+
+// DropAttachment метод удаляет вложение из учета квоты пользователя.
+func (s *Storage) DropAttachment(userID, attachmentID string) error {
+	result, err := s.db.Exec("DELETE FROM GophKeeperAttachments WHERE user_id = $1 AND attachment_id = $2", userID, attachmentID)
+	return affectedAttachment(result, err)
+}
+
+// AttachmentsUsage метод возвращает объем вложений пользователя, учтенный в квоте, вместе с незавершенными загрузками.
+func (s *Storage) AttachmentsUsage(userID string) (int64, error) {
+	var used int64
+	err := s.db.QueryRow("SELECT COALESCE(sum(size), 0) FROM GophKeeperAttachments WHERE user_id = $1", userID).Scan(&used)
+	return used, err
+}
+
+// StaleAttachments метод возвращает идентификаторы незавершенных загрузок вложений пользователя, начатых до before.
+// Такие загрузки прерваны, например при остановке сервера, и их объем нужно освободить.
+func (s *Storage) StaleAttachments(userID string, before time.Time) ([]string, error) {
+	return attachmentIDs(s.db, "SELECT attachment_id FROM GophKeeperAttachments WHERE user_id = $1 AND NOT stored AND created < $2", userID, before)
+}
+
+// reclaimAttachments функция снова отмечает используемыми вложения, освобожденные не раньше версии данных version,
+// и возвращает их идентификаторы.
+func reclaimAttachments(tx *sql.Tx, userID string, version int64) ([]string, error) {
+	return attachmentIDs(tx, "UPDATE GophKeeperAttachments SET released_version = NULL WHERE user_id = $1 AND released_version >= $2 RETURNING attachment_id", userID, version)
+}
+
+// attachmentIDs функция выполняет запрос и возвращает полученные идентификаторы вложений.
+func attachmentIDs(q querier, query string, args ...any) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// affectedAttachment функция возвращает ошибку ErrNoSuchAttachment, если запрос не изменил ни одного вложения.
+func affectedAttachment(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gkerrors.ErrNoSuchAttachment
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import "time"
+
+// LoginAuthKey функция возвращает ключ счетчика неудачных попыток входа по логину.
+func LoginAuthKey(login string) string {
+	return "login:" + login
+}
+
+// UserAuthKey функция возвращает ключ счетчика неудачных попыток подтверждения пароля или кода пользователем.
+func UserAuthKey(userID string) string {
+	return "user:" + userID
+}
+
+// AuthAttempt метод проверяет, разрешена ли попытка входа по ключу счетчика, и заранее учитывает ее как неудачную.
+// Проверка и учет выполняются одной транзакцией под блокировкой строки счетчика, поэтому одновременные попытки
+// не проходят проверку вместе до того, как учтена хотя бы одна из них. Ключ счетчика описывает логин, пользователя или адрес клиента.
+// Если попытки временно заблокированы, возвращает время окончания блокировки и false, попытка не учитывается.
+// Иначе возвращает время, до которого отклоняются следующие попытки, и true. Это время передается в AuthReleased,
+// если попытка оказалась удачной. Счетчик начинается заново, если с последней неудачной попытки прошло больше LoginLockout минут.
+func (s *Storage) AuthAttempt(authKey string, attempts int) (time.Time, bool, error) {
+	// Время округляется до точности timestamptz, чтобы AuthReleased мог сравнить его с сохраненным
+	now := time.Now().Truncate(time.Microsecond)
+	lockout := time.Minute * time.Duration(s.cfg.LoginLockout)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer tx.Rollback()
+	var failures int
+	var lastFailure, blocked time.Time
+	err = tx.QueryRow(`INSERT INTO GophKeeperAuthFailures(auth_key, failures, last_failure, blocked_until) VALUES($1, 0, $2, $2)
+		ON CONFLICT (auth_key) DO UPDATE SET auth_key = EXCLUDED.auth_key
+		RETURNING failures, last_failure, blocked_until`, authKey, now).Scan(&failures, &lastFailure, &blocked)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	// Время попытки отсчитывается после блокировки строки, когда все предыдущие попытки уже учтены
+	now = time.Now().Truncate(time.Microsecond)
+	if blocked.After(now) {
+		return blocked, false, tx.Commit()
+	}
+	if lastFailure.Before(now.Add(-lockout)) {
+		failures = 0
+	}
+	failures++
+	blocked = now.Add(authDelay(failures, attempts, time.Second*time.Duration(s.cfg.LoginBackoff), lockout))
+	_, err = tx.Exec("UPDATE GophKeeperAuthFailures SET failures = $1, last_failure = $2, blocked_until = $3 WHERE auth_key = $4",
+		failures, now, blocked, authKey)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return blocked, true, tx.Commit()
+}
+
+// AuthReleased метод отменяет учет попытки, разрешенной AuthAttempt, если она не оказалась неудачной.
+// Время блокировки сбрасывается, только если после этой попытки не была учтена другая, иначе задержка другой попытки сохраняется.
+func (s *Storage) AuthReleased(authKey string, reserved time.Time) error {
+	_, err := s.db.Exec(`UPDATE GophKeeperAuthFailures SET failures = GREATEST(failures-1, 0),
+		blocked_until = CASE WHEN blocked_until = $2 THEN $3 ELSE blocked_until END WHERE auth_key = $1`, authKey, reserved, time.Now())
+	return err
+}
+
+// AuthSucceeded метод сбрасывает счетчик неудачных попыток входа.
+func (s *Storage) AuthSucceeded(authKey string) error {
+	_, err := s.db.Exec("DELETE FROM GophKeeperAuthFailures WHERE auth_key = $1", authKey)
+	return err
+}
+
+// authDelay функция вычисляет задержку до следующей попытки входа после failures неудачных попыток.
+// Задержка удваивается с каждой попыткой, начиная с backoff, а после attempts попыток равна времени блокировки lockout.
+func authDelay(failures, attempts int, backoff, lockout time.Duration) time.Duration {
+	if failures >= attempts {
+		return lockout
+	}
+	delay := backoff
+	for i := 1; i < failures && delay < lockout; i++ {
+		delay *= 2
+	}
+	if delay > lockout {
+		return lockout
+	}
+	return delay
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	gkerrors "gophkeeper/internal/errors"
+)
+
+// History структура описывает сохраненную предыдущую версию данных пользователя.
+type History struct {
+	Version   int64  //Версия данных
+	TimeStamp string //Отметка времени сохранения версии данных
+}
+
+// ListHistory метод возвращает список сохраненных предыдущих версий данных пользователя, начиная с последней.
+func (s *Storage) ListHistory(userID string) ([]History, error) {
+	rows, err := s.db.Query("SELECT version, time_stamp FROM GophKeeperHistory WHERE user_id = $1 ORDER BY version DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := make([]History, 0)
+	for rows.Next() {
+		var version History
+		err = rows.Scan(&version.Version, &version.TimeStamp)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, version)
+	}
+	return history, rows.Err()
+}
+
+// RestoreVersion метод сохраняет предыдущую версию данных пользователя как новую версию, если текущая версия на сервере не изменилась.
+// Заменяемые данные сохраняются в истории, поэтому восстановление можно отменить.
+// Вложения, освобожденные после восстановленной версии, снова считаются используемыми, их идентификаторы возвращаются:
+// восстановленные записи могут на них ссылаться, а освободить лишние может только клиент.
+// Данные, заблокированные другой сессией, не восстанавливаются: возвращается время окончания блокировки и ошибка ErrLocked.
+func (s *Storage) RestoreVersion(userID, sessionID string, version, userVersion int64) (string, int64, []string, error) {
+	var userData []byte
+	err := s.db.QueryRow("SELECT user_data FROM GophKeeperHistory WHERE user_id = $1 AND version = $2", userID, version).Scan(&userData)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", 0, nil, gkerrors.ErrNoSuchVersion
+	}
+	if err != nil {
+		return "", 0, nil, err
+	}
+	var reclaimed []string
+	timeStamp, newVersion, err := s.saveUserData(userID, sessionID, userVersion, userData, func(tx *sql.Tx) (err error) {
+		reclaimed, err = reclaimAttachments(tx, userID, version)
+		return err
+	})
+	if err != nil {
+		return timeStamp, 0, nil, err
+	}
+	return timeStamp, newVersion, reclaimed, nil
+}
+
+// saveHistory функция сохраняет текущую версию данных пользователя в истории перед их изменением.
+func saveHistory(tx *sql.Tx, userID string) error {
+	var version int64
+	var timeStamp string
+	var userData []byte
+	err := tx.QueryRow("SELECT version, time_stamp, user_data FROM GophKeeper WHERE user_id = $1", userID).Scan(&version, &timeStamp, &userData)
+	if err != nil {
+		return err
+	}
+	packed, err := packedRecords(tx, userID)
+	if err != nil {
+		return err
+	}
+	if packed != nil {
+		userData = packed
+	}
+	if len(userData) == 0 {
+		return nil
+	}
+	_, err = tx.Exec("INSERT INTO GophKeeperHistory(user_id, version, time_stamp, user_data) VALUES($1, $2, $3, $4) ON CONFLICT (user_id, version) DO NOTHING",
+		userID, version, timeStamp, userData)
+	return err
+}
+
+// trimHistory функция удаляет из истории версии данных пользователя сверх заданной глубины.
+func trimHistory(tx *sql.Tx, userID string, depth int) error {
+	_, err := tx.Exec("DELETE FROM GophKeeperHistory WHERE user_id = $1 AND version NOT IN (SELECT version FROM GophKeeperHistory WHERE user_id = $1 ORDER BY version DESC LIMIT $2)",
+		userID, depth)
+	return err
+}
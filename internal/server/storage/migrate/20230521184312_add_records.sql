@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS GophKeeperRecords(user_id text, record_id text, record_type text, version bigint, time_stamp text, record_data bytea, PRIMARY KEY(user_id, record_id));
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperRecords;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeper ADD COLUMN revision bigint NOT NULL DEFAULT 0;
+ALTER TABLE GophKeeperRecords ADD COLUMN revision bigint NOT NULL DEFAULT 0;
+ALTER TABLE GophKeeperRecords ADD COLUMN deleted boolean NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS GophKeeperRecordsRevision ON GophKeeperRecords(user_id, revision);
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS GophKeeperRecordsRevision;
+ALTER TABLE GophKeeperRecords DROP COLUMN deleted;
+ALTER TABLE GophKeeperRecords DROP COLUMN revision;
+ALTER TABLE GophKeeper DROP COLUMN revision;
+SELECT 'down SQL query';
+-- +goose StatementEnd
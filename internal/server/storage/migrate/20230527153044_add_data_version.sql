@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeper ADD COLUMN version bigint NOT NULL DEFAULT 0;
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE GophKeeper DROP COLUMN version;
+SELECT 'down SQL query';
+-- +goose StatementEnd
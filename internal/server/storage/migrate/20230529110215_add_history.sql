@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS GophKeeperHistory(user_id text, version bigint, time_stamp text, user_data bytea, PRIMARY KEY(user_id, version));
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperHistory;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeper ADD COLUMN kdf_salt bytea, ADD COLUMN kdf_time integer NOT NULL DEFAULT 0, ADD COLUMN kdf_memory integer NOT NULL DEFAULT 0, ADD COLUMN kdf_threads integer NOT NULL DEFAULT 0, ADD COLUMN wrapped_key bytea;
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE GophKeeper DROP COLUMN kdf_salt, DROP COLUMN kdf_time, DROP COLUMN kdf_memory, DROP COLUMN kdf_threads, DROP COLUMN wrapped_key;
+SELECT 'down SQL query';
+-- +goose StatementEnd
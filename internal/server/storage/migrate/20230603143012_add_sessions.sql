@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS GophKeeperSessions(session_id text PRIMARY KEY, user_id text NOT NULL DEFAULT '', session_keys bytea NOT NULL, expires timestamptz NOT NULL);
+CREATE TABLE IF NOT EXISTS GophKeeperNonces(session_id text REFERENCES GophKeeperSessions(session_id) ON DELETE CASCADE, nonce bigint, signed_at timestamptz NOT NULL, PRIMARY KEY(session_id, nonce));
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperNonces;
+DROP TABLE IF EXISTS GophKeeperSessions;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeperSessions ADD COLUMN IF NOT EXISTS created timestamptz NOT NULL DEFAULT now();
+ALTER TABLE GophKeeperSessions ADD COLUMN IF NOT EXISTS last_seen timestamptz NOT NULL DEFAULT now();
+ALTER TABLE GophKeeperSessions ADD COLUMN IF NOT EXISTS client_version text NOT NULL DEFAULT '';
+ALTER TABLE GophKeeperSessions ADD COLUMN IF NOT EXISTS remote_addr text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS gophkeeper_sessions_user_id ON GophKeeperSessions(user_id);
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS gophkeeper_sessions_user_id;
+ALTER TABLE GophKeeperSessions DROP COLUMN IF EXISTS created;
+ALTER TABLE GophKeeperSessions DROP COLUMN IF EXISTS last_seen;
+ALTER TABLE GophKeeperSessions DROP COLUMN IF EXISTS client_version;
+ALTER TABLE GophKeeperSessions DROP COLUMN IF EXISTS remote_addr;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeperSessions ADD COLUMN IF NOT EXISTS device_name text NOT NULL DEFAULT '';
+ALTER TABLE GophKeeperLocks ADD COLUMN IF NOT EXISTS device_name text NOT NULL DEFAULT '';
+ALTER TABLE GophKeeperLocks ADD COLUMN IF NOT EXISTS client_version text NOT NULL DEFAULT '';
+ALTER TABLE GophKeeperLocks ADD COLUMN IF NOT EXISTS time_acquired text NOT NULL DEFAULT '';
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE GophKeeperSessions DROP COLUMN IF EXISTS device_name;
+ALTER TABLE GophKeeperLocks DROP COLUMN IF EXISTS device_name;
+ALTER TABLE GophKeeperLocks DROP COLUMN IF EXISTS client_version;
+ALTER TABLE GophKeeperLocks DROP COLUMN IF EXISTS time_acquired;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS GophKeeperAuthFailures(auth_key text PRIMARY KEY, failures integer NOT NULL, last_failure timestamptz NOT NULL, blocked_until timestamptz NOT NULL);
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperAuthFailures;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE GophKeeper ADD COLUMN IF NOT EXISTS totp_secret bytea;
+ALTER TABLE GophKeeper ADD COLUMN IF NOT EXISTS totp_enabled boolean NOT NULL DEFAULT false;
+ALTER TABLE GophKeeper ADD COLUMN IF NOT EXISTS totp_last_step bigint NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS GophKeeperRecoveryCodes(user_id text NOT NULL, code_hash text NOT NULL, PRIMARY KEY (user_id, code_hash));
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperRecoveryCodes;
+ALTER TABLE GophKeeper DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE GophKeeper DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE GophKeeper DROP COLUMN IF EXISTS totp_secret;
+SELECT 'down SQL query';
+-- +goose StatementEnd
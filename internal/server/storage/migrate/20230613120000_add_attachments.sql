@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS GophKeeperAttachments(user_id text NOT NULL, attachment_id text NOT NULL, size bigint NOT NULL, stored boolean NOT NULL DEFAULT false, created timestamptz NOT NULL DEFAULT now(), released_version bigint, PRIMARY KEY(user_id, attachment_id));
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS GophKeeperAttachments;
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,327 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+)
+
+// CreateRecord метод сохраняет новую запись пользователя, если данные не заблокированы другой сессией.
+func (s *Storage) CreateRecord(userID, sessionID string, rec records.Record) (records.Record, error) {
+	err := s.changeRecords(userID, sessionID, func(tx *sql.Tx, revision int64) error {
+		rec.Revision = revision
+		rec.TimeStamp = time.Now().Format(time.RFC3339)
+		rec.Deleted = false
+		return insertRecord(tx, userID, &rec)
+	})
+	if err != nil {
+		return records.Record{}, err
+	}
+	return rec, nil
+}
+
+// UpdateRecord метод обновляет запись пользователя, если ее версия на сервере не изменилась и данные не заблокированы другой сессией.
+func (s *Storage) UpdateRecord(userID, sessionID string, rec records.Record) (records.Record, error) {
+	err := s.changeRecords(userID, sessionID, func(tx *sql.Tx, revision int64) error {
+		rec.Revision = revision
+		rec.TimeStamp = time.Now().Format(time.RFC3339)
+		rec.Deleted = false
+		return updateRecord(tx, userID, &rec)
+	})
+	if err != nil {
+		return records.Record{}, err
+	}
+	return rec, nil
+}
+
+// DeleteRecord метод помечает запись пользователя удаленной, если ее версия на сервере не изменилась и данные не заблокированы другой сессией.
+func (s *Storage) DeleteRecord(userID, sessionID, recordID string, version int64) error {
+	return s.changeRecords(userID, sessionID, func(tx *sql.Tx, revision int64) error {
+		var rec = records.Record{ID: recordID, Version: version, Deleted: true, TimeStamp: time.Now().Format(time.RFC3339), Revision: revision}
+		return deleteRecord(tx, userID, &rec)
+	})
+}
+
+// ListRecords метод возвращает список записей пользователя без зашифрованных данных.
+func (s *Storage) ListRecords(userID string) ([]records.Record, error) {
+	rows, err := s.db.Query("SELECT record_id, record_type, version, time_stamp, revision FROM GophKeeperRecords WHERE user_id = $1 AND NOT deleted ORDER BY record_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	recs := make([]records.Record, 0)
+	for rows.Next() {
+		var rec records.Record
+		err = rows.Scan(&rec.ID, &rec.Type, &rec.Version, &rec.TimeStamp, &rec.Revision)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, rows.Err()
+}
+
+// GetRecord метод возвращает запись пользователя вместе с зашифрованными данными.
+func (s *Storage) GetRecord(userID, recordID string) (records.Record, error) {
+	var rec = records.Record{ID: recordID}
+	err := s.db.QueryRow("SELECT record_type, version, time_stamp, record_data, revision FROM GophKeeperRecords WHERE user_id = $1 AND record_id = $2 AND NOT deleted", userID, recordID).
+		Scan(&rec.Type, &rec.Version, &rec.TimeStamp, &rec.Data, &rec.Revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return records.Record{}, gkerrors.ErrNoSuchRecord
+	}
+	if err != nil {
+		return records.Record{}, err
+	}
+	return rec, nil
+}
+
+// SyncRecords метод сохраняет измененные клиентом записи и возвращает записи, измененные после ревизии клиента.
+// Записи, измененные клиентом на основе устаревшей версии, не сохраняются и возвращаются как конфликты.
+// Если данные заблокированы другой сессией, изменения не сохраняются и возвращается ошибка ErrLocked.
+func (s *Storage) SyncRecords(userID, sessionID string, lastRevision int64, changes []records.Record) (int64, []records.Record, []records.Record, error) {
+	var revision int64
+	var changed []records.Record
+	conflicts := make([]records.Record, 0)
+	if len(changes) == 0 {
+		err := s.db.QueryRow("SELECT revision FROM GophKeeper WHERE user_id = $1", userID).Scan(&revision)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		changed, err = changedRecords(s.db, userID, lastRevision, conflicts)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		return revision, changed, conflicts, nil
+	}
+
+	err := s.changeRecords(userID, sessionID, func(tx *sql.Tx, next int64) error {
+		revision = next
+		timeStamp := time.Now().Format(time.RFC3339)
+		for _, rec := range changes {
+			rec.Revision = revision
+			rec.TimeStamp = timeStamp
+			var err error
+			switch {
+			case rec.Deleted:
+				err = deleteRecord(tx, userID, &rec)
+			case rec.Version == 0:
+				err = insertRecord(tx, userID, &rec)
+			default:
+				err = updateRecord(tx, userID, &rec)
+			}
+			if errors.Is(err, gkerrors.ErrVersionNotEqual) || errors.Is(err, gkerrors.ErrRecordExists) || errors.Is(err, gkerrors.ErrNoSuchRecord) {
+				var server records.Record
+				server, err = recordWithDeleted(tx, userID, rec.ID)
+				if errors.Is(err, gkerrors.ErrNoSuchRecord) && !rec.Deleted {
+					// запись не известна серверу, сохраняем ее как новую
+					err = insertRecord(tx, userID, &rec)
+				} else if err == nil && !(rec.Deleted && server.Deleted) {
+					conflicts = append(conflicts, server)
+					continue
+				} else if errors.Is(err, gkerrors.ErrNoSuchRecord) {
+					continue
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		var err error
+		changed, err = changedRecords(tx, userID, lastRevision, conflicts)
+		return err
+	})
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return revision, changed, conflicts, nil
+}
+
+// changedRecords функция возвращает записи пользователя, измененные после ревизии клиента.
+// Записи с конфликтом возвращаются только в списке конфликтов, чтобы клиент сохранил свою версию.
+func changedRecords(q querier, userID string, lastRevision int64, conflicts []records.Record) ([]records.Record, error) {
+	rows, err := q.Query("SELECT record_id, record_type, version, time_stamp, record_data, revision, deleted FROM GophKeeperRecords WHERE user_id = $1 AND revision > $2 ORDER BY revision, record_id", userID, lastRevision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	conflicted := make(map[string]bool, len(conflicts))
+	for _, rec := range conflicts {
+		conflicted[rec.ID] = true
+	}
+	changed := make([]records.Record, 0)
+	for rows.Next() {
+		var rec records.Record
+		err = rows.Scan(&rec.ID, &rec.Type, &rec.Version, &rec.TimeStamp, &rec.Data, &rec.Revision, &rec.Deleted)
+		if err != nil {
+			return nil, err
+		}
+		if conflicted[rec.ID] {
+			continue
+		}
+		changed = append(changed, rec)
+	}
+	return changed, rows.Err()
+}
+
+// changeRecords метод изменяет записи пользователя одной транзакцией под блокировкой строки пользователя.
+// Блокировка данных проверяется в той же транзакции, что и при сохранении данных единым массивом: если данные
+// заблокированы другой сессией, записи не изменяются и возвращается ошибка ErrLocked.
+// Текущая версия данных сохраняется в истории, поэтому изменение отдельных записей можно отменить так же,
+// как сохранение данных единым массивом. Функция change изменяет записи с новой ревизией данных.
+func (s *Storage) changeRecords(userID, sessionID string, change func(tx *sql.Tx, revision int64) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = lockUserRow(tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gkerrors.ErrNoSuchUser
+	}
+	if err != nil {
+		return err
+	}
+	locked, _, err := lockedByOther(tx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return gkerrors.ErrLocked
+	}
+	err = saveHistory(tx, userID)
+	if err != nil {
+		return err
+	}
+	revision, err := nextRevision(tx, userID)
+	if err != nil {
+		return err
+	}
+	err = change(tx, revision)
+	if err != nil {
+		return err
+	}
+	err = trimHistory(tx, userID, s.cfg.HistoryDepth)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// nextRevision функция увеличивает ревизию и версию данных пользователя.
+func nextRevision(tx *sql.Tx, userID string) (int64, error) {
+	var revision int64
+	err := tx.QueryRow("UPDATE GophKeeper SET revision=revision+1, version=version+1 WHERE user_id=$1 RETURNING revision", userID).Scan(&revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, gkerrors.ErrNoSuchUser
+	}
+	return revision, err
+}
+
+// insertRecord функция сохраняет новую запись или восстанавливает запись, помеченную удаленной.
+func insertRecord(tx *sql.Tx, userID string, rec *records.Record) error {
+	err := tx.QueryRow(`INSERT INTO GophKeeperRecords(user_id, record_id, record_type, version, time_stamp, record_data, revision, deleted) VALUES($1, $2, $3, 1, $4, $5, $6, false)
+		ON CONFLICT (user_id, record_id) DO UPDATE SET record_type=EXCLUDED.record_type, record_data=EXCLUDED.record_data, time_stamp=EXCLUDED.time_stamp, version=GophKeeperRecords.version+1, revision=EXCLUDED.revision, deleted=false
+		WHERE GophKeeperRecords.deleted RETURNING version`,
+		userID, rec.ID, rec.Type, rec.TimeStamp, rec.Data, rec.Revision).Scan(&rec.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gkerrors.ErrRecordExists
+	}
+	return err
+}
+
+// updateRecord функция обновляет запись, если ее версия на сервере совпадает с версией записи.
+func updateRecord(tx *sql.Tx, userID string, rec *records.Record) error {
+	err := tx.QueryRow("UPDATE GophKeeperRecords SET record_type=$1, record_data=$2, time_stamp=$3, revision=$4, version=version+1 WHERE user_id=$5 AND record_id=$6 AND version=$7 AND NOT deleted RETURNING version",
+		rec.Type, rec.Data, rec.TimeStamp, rec.Revision, userID, rec.ID, rec.Version).Scan(&rec.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return recordVersionError(tx, userID, rec.ID)
+	}
+	return err
+}
+
+// deleteRecord функция помечает запись удаленной, если ее версия на сервере совпадает с версией записи.
+func deleteRecord(tx *sql.Tx, userID string, rec *records.Record) error {
+	err := tx.QueryRow("UPDATE GophKeeperRecords SET record_data=NULL, deleted=true, time_stamp=$1, revision=$2, version=version+1 WHERE user_id=$3 AND record_id=$4 AND version=$5 AND NOT deleted RETURNING version",
+		rec.TimeStamp, rec.Revision, userID, rec.ID, rec.Version).Scan(&rec.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return recordVersionError(tx, userID, rec.ID)
+	}
+	return err
+}
+
+// recordVersionError функция определяет причину, по которой запись не была изменена.
+func recordVersionError(tx *sql.Tx, userID, recordID string) error {
+	var deleted bool
+	err := tx.QueryRow("SELECT deleted FROM GophKeeperRecords WHERE user_id = $1 AND record_id = $2", userID, recordID).Scan(&deleted)
+	if errors.Is(err, sql.ErrNoRows) || deleted {
+		return gkerrors.ErrNoSuchRecord
+	}
+	if err != nil {
+		return err
+	}
+	return gkerrors.ErrVersionNotEqual
+}
+
+// recordWithDeleted функция возвращает запись пользователя, в том числе помеченную удаленной.
+func recordWithDeleted(tx *sql.Tx, userID, recordID string) (records.Record, error) {
+	var rec = records.Record{ID: recordID}
+	err := tx.QueryRow("SELECT record_type, version, time_stamp, record_data, revision, deleted FROM GophKeeperRecords WHERE user_id = $1 AND record_id = $2", userID, recordID).
+		Scan(&rec.Type, &rec.Version, &rec.TimeStamp, &rec.Data, &rec.Revision, &rec.Deleted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return records.Record{}, gkerrors.ErrNoSuchRecord
+	}
+	return rec, err
+}
+
+// querier интерфейс выполнения запросов, общий для соединения с БД и транзакции.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// packedRecords функция собирает все записи пользователя в единый массив данных для метода UserData.
+func packedRecords(q querier, userID string) ([]byte, error) {
+	rows, err := q.Query("SELECT record_id, record_type, version, time_stamp, record_data, revision FROM GophKeeperRecords WHERE user_id = $1 AND NOT deleted ORDER BY record_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	recs := make([]records.Record, 0)
+	for rows.Next() {
+		var rec records.Record
+		err = rows.Scan(&rec.ID, &rec.Type, &rec.Version, &rec.TimeStamp, &rec.Data, &rec.Revision)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, nil
+	}
+	return records.Pack(recs)
+}
+
+// replaceRecords функция разбирает полученный от метода UpdateData массив данных на отдельные записи.
+// Измененные записи получают новую версию, отсутствующие в массиве записи помечаются удаленными.
+func replaceRecords(tx *sql.Tx, userID, timeStamp string, revision int64, recs []records.Record) error {
+	var err error
+	ids := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		_, err = tx.Exec(`INSERT INTO GophKeeperRecords(user_id, record_id, record_type, version, time_stamp, record_data, revision, deleted) VALUES($1, $2, $3, 1, $4, $5, $6, false)
+			ON CONFLICT (user_id, record_id) DO UPDATE SET record_type=EXCLUDED.record_type, record_data=EXCLUDED.record_data, time_stamp=EXCLUDED.time_stamp, version=GophKeeperRecords.version+1, revision=EXCLUDED.revision, deleted=false
+			WHERE GophKeeperRecords.deleted OR GophKeeperRecords.record_data IS DISTINCT FROM EXCLUDED.record_data OR GophKeeperRecords.record_type IS DISTINCT FROM EXCLUDED.record_type`,
+			userID, rec.ID, rec.Type, timeStamp, rec.Data, revision)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, rec.ID)
+	}
+	_, err = tx.Exec("UPDATE GophKeeperRecords SET record_data=NULL, deleted=true, time_stamp=$1, revision=$2, version=version+1 WHERE user_id=$3 AND NOT deleted AND NOT (record_id = ANY($4))",
+		timeStamp, revision, userID, ids)
+	return err
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+	"gophkeeper/internal/server/config"
+	"gophkeeper/internal/server/crypto"
+)
+
+// SessionStore структура для хранения сессий клиентов в базе данных, общей для нескольких экземпляров сервера.
+// Ключи сессий хранятся зашифрованными мастер-ключом сервера.
+type SessionStore struct {
+	db        *sql.DB
+	masterKey []byte
+}
+
+// NewSessionStore функция генерирует хранилище сессий в базе данных.
+func NewSessionStore(cfg *config.Config) (*SessionStore, error) {
+	masterKey, err := hex.DecodeString(cfg.SessionKey)
+	if err != nil || len(masterKey) != handshake.KeyLen {
+		return nil, gkerrors.ErrSessionKeyIncorrect
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionStore{db: db, masterKey: masterKey}, nil
+}
+
+// SaveSession метод сохраняет новую сессию. Ключи сессии зашифровываются мастер-ключом, идентификатор сессии используется как метка.
+func (s *SessionStore) SaveSession(sessionID string, session crypto.Session) error {
+	keysBZ := make([]byte, 0, 4*handshake.KeyLen)
+	keysBZ = append(keysBZ, session.Keys.ClientKey...)
+	keysBZ = append(keysBZ, session.Keys.ServerKey...)
+	keysBZ = append(keysBZ, session.Keys.ClientSign...)
+	keysBZ = append(keysBZ, session.Keys.ServerSign...)
+	sealed, err := handshake.Seal(s.masterKey, keysBZ, []byte(sessionID))
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("INSERT INTO GophKeeperSessions (session_id, user_id, session_keys, expires, created, last_seen, device_name, client_version, remote_addr) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		sessionID, session.UserID, sealed, session.Expires, session.Created, session.LastSeen, session.DeviceName, session.ClientVersion, session.RemoteAddr)
+	return err
+}
+
+// LoadSession метод возвращает сессию по идентификатору и расшифровывает ее ключи.
+func (s *SessionStore) LoadSession(sessionID string) (crypto.Session, error) {
+	var session crypto.Session
+	var sealed []byte
+	err := s.db.QueryRow("SELECT user_id, session_keys, expires, created, last_seen, device_name, client_version, remote_addr FROM GophKeeperSessions WHERE session_id = $1", sessionID).
+		Scan(&session.UserID, &sealed, &session.Expires, &session.Created, &session.LastSeen, &session.DeviceName, &session.ClientVersion, &session.RemoteAddr)
+	if errors.Is(err, sql.ErrNoRows) {
+		return crypto.Session{}, gkerrors.ErrExpired
+	}
+	if err != nil {
+		return crypto.Session{}, err
+	}
+	keysBZ, err := handshake.Open(s.masterKey, sealed, []byte(sessionID))
+	if err != nil {
+		return crypto.Session{}, err
+	}
+	if len(keysBZ) != 4*handshake.KeyLen {
+		return crypto.Session{}, gkerrors.ErrSessionKeyIncorrect
+	}
+	session.Keys = handshake.Keys{
+		ClientKey:  keysBZ[:handshake.KeyLen],
+		ServerKey:  keysBZ[handshake.KeyLen : 2*handshake.KeyLen],
+		ClientSign: keysBZ[2*handshake.KeyLen : 3*handshake.KeyLen],
+		ServerSign: keysBZ[3*handshake.KeyLen:],
+	}
+	return session, nil
+}
+
+// SetSessionUser метод добавляет userID в сессию.
+func (s *SessionStore) SetSessionUser(sessionID, userID string) error {
+	result, err := s.db.Exec("UPDATE GophKeeperSessions SET user_id = $1 WHERE session_id = $2", userID, sessionID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gkerrors.ErrExpired
+	}
+	return nil
+}
+
+// TouchSession метод обновляет время последнего запроса и адрес клиента в сессии.
+func (s *SessionStore) TouchSession(sessionID string, lastSeen time.Time, remoteAddr string) error {
+	result, err := s.db.Exec("UPDATE GophKeeperSessions SET last_seen = $1, remote_addr = CASE WHEN $2 = '' THEN remote_addr ELSE $2 END WHERE session_id = $3", lastSeen, remoteAddr, sessionID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gkerrors.ErrExpired
+	}
+	return nil
+}
+
+// UserSessions метод возвращает действующие сессии пользователя по их идентификаторам без ключей сессий.
+func (s *SessionStore) UserSessions(userID string) (map[string]crypto.Session, error) {
+	rows, err := s.db.Query("SELECT session_id, expires, created, last_seen, device_name, client_version, remote_addr FROM GophKeeperSessions WHERE user_id = $1 AND expires > $2", userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sessions := make(map[string]crypto.Session)
+	for rows.Next() {
+		var sessionID string
+		session := crypto.Session{UserID: userID}
+		err = rows.Scan(&sessionID, &session.Expires, &session.Created, &session.LastSeen, &session.DeviceName, &session.ClientVersion, &session.RemoteAddr)
+		if err != nil {
+			return nil, err
+		}
+		sessions[sessionID] = session
+	}
+	return sessions, rows.Err()
+}
+
+// DeleteSession метод удаляет сессию и номера ее запросов.
+func (s *SessionStore) DeleteSession(sessionID string) error {
+	_, err := s.db.Exec("DELETE FROM GophKeeperSessions WHERE session_id = $1", sessionID)
+	return err
+}
+
+// DeleteExpiredSessions метод удаляет сессии с истекшим сроком действия.
+func (s *SessionStore) DeleteExpiredSessions() error {
+	_, err := s.db.Exec("DELETE FROM GophKeeperSessions WHERE expires <= $1", time.Now())
+	return err
+}
+
+// AddNonce метод сохраняет номер запроса сессии и удаляет номера, время подписи которых вышло за пределы окна.
+// Возвращает ошибку, если запрос с таким номером уже получен любым экземпляром сервера.
+func (s *SessionStore) AddNonce(sessionID string, nonce uint64, signed time.Time, window time.Duration) error {
+	_, err := s.db.Exec("DELETE FROM GophKeeperNonces WHERE session_id = $1 AND signed_at < $2", sessionID, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	result, err := s.db.Exec("INSERT INTO GophKeeperNonces (session_id, nonce, signed_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", sessionID, int64(nonce), signed)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gkerrors.ErrReplay
+	}
+	return nil
+}
+
+// CloseDB метод закрывает подключение хранилища сессий к базе данных.
+func (s *SessionStore) CloseDB() {
+	err := s.db.Close()
+	if err != nil {
+		log.Error().Err(err).Msg("SessionStore CloseDB error")
+	}
+}
@@ -5,6 +5,7 @@ package storage
 import (
 	"database/sql"
 	"embed"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+	"gophkeeper/internal/records"
 	"gophkeeper/internal/server/config"
 	"gophkeeper/internal/server/crypto"
 )
@@ -20,20 +23,74 @@ import (
 // Storager интерфейс базы данных сервера.
 type Storager interface {
 	CheckUser(string) (bool, error)
-	RegisterUser(string, string) (string, string, string, error)
-	AuthUser(string, string) (string, error)
-	ChangeUserPassword(string, string, string) (bool, error)
-	UsersData(string) ([]byte, string, string, error)
-	UsersTimeStamp(string) (string, bool, string, error)
-	UsersDataLock(string, string) (bool, string)
-	UpdateUserData(string, string, string, []byte) (bool, string, error)
+	UserKeyParams(string) (UserKey, error)
+	RegisterUser(string, string, UserKey) (string, string, error)
+	AuthUser(string, string) (string, UserKey, error)
+	ChangeUserPassword(string, string, string, UserKey) (bool, error)
+	DeleteUser(string, string) error
+	AuthAttempt(string, int) (time.Time, bool, error)
+	AuthReleased(string, time.Time) error
+	AuthSucceeded(string) error
+	UserTOTP(string) (TOTPInfo, error)
+	SetTOTP(string, []byte, []string) error
+	EnableTOTP(string, int64) error
+	UseTOTPStep(string, int64) (bool, error)
+	UseRecoveryCode(string, string) (bool, error)
+	DisableTOTP(string) error
+	UsersData(string) ([]byte, string, int64, error)
+	UsersTimeStamp(string) (string, int64, bool, LockInfo, error)
+	UsersDataLock(string, LockInfo, bool) (bool, LockInfo)
+	ReleaseLock(string, string) error
+	RenewLock(string, string) (string, error)
+	UpdateUserData(string, string, int64, []byte) (bool, string, int64, error)
+	CreateRecord(string, string, records.Record) (records.Record, error)
+	UpdateRecord(string, string, records.Record) (records.Record, error)
+	DeleteRecord(string, string, string, int64) error
+	ListRecords(string) ([]records.Record, error)
+	GetRecord(string, string) (records.Record, error)
+	SyncRecords(string, string, int64, []records.Record) (int64, []records.Record, []records.Record, error)
+	ListHistory(string) ([]History, error)
+	RestoreVersion(string, string, int64, int64) (string, int64, []string, error)
+	ReserveAttachment(string, string, int64, int64) error
+	CommitAttachment(string, string, int64) error
+	ReleaseAttachment(string, string) error
+	ExpiredAttachments(string) ([]string, error)
+	DropAttachment(string, string) error
+	AttachmentsUsage(string) (int64, error)
+	StaleAttachments(string, time.Time) ([]string, error)
 	CloseDB()
 }
 
+// UserKey структура хранит параметры вычисления мастер-ключа пользователя и зашифрованный им ключ данных.
+// Сервер не может расшифровать ключ данных, мастер-ключ вычисляется только на клиенте.
+type UserKey struct {
+	Salt       []byte //Соль пользователя
+	Time       uint32 //Количество проходов argon2id
+	Memory     uint32 //Объем памяти argon2id, в килобайтах
+	Threads    uint8  //Количество потоков argon2id
+	WrappedKey []byte //Ключ данных, зашифрованный мастер-ключом
+	LegacyKey  string //Ключ данных, созданный сервером до перехода на ключ клиента
+}
+
+// Legacy метод сообщает, что ключ данных пользователя создан сервером и еще не заменен ключом клиента.
+func (k UserKey) Legacy() bool {
+	return len(k.Salt) == 0
+}
+
+// LockInfo структура описывает блокировку данных пользователя на изменение и сессию, которая ее установила.
+type LockInfo struct {
+	SessionID     string //Идентификатор сессии, установившей блокировку
+	DeviceName    string //Имя устройства клиента
+	ClientVersion string //Версия приложения клиента
+	TimeAcquired  string //Время установки блокировки
+	TimeLock      string //Время, до которого данные заблокированы
+}
+
 // Storage структура для хранения оперативных данных.
 type Storage struct {
-	cfg *config.Config
-	db  *sql.DB
+	cfg     *config.Config
+	db      *sql.DB
+	totpKey []byte //Мастер-ключ шифрования секретов TOTP
 }
 
 //go:embed migrate/*.sql
@@ -41,6 +98,23 @@ var embedMigrations embed.FS
 
 // NewStorage метод генерирует хранилище оперативных данных.
 func NewStorage(cfg *config.Config) (Storager, error) {
+	totpKey, err := hex.DecodeString(cfg.TOTPKey)
+	if err != nil || len(totpKey) != handshake.KeyLen {
+		return nil, gkerrors.ErrTOTPKeyIncorrect
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{
+		cfg:     cfg,
+		db:      db,
+		totpKey: totpKey,
+	}, nil
+}
+
+// openDB функция подключается к базе данных и применяет миграции.
+func openDB(cfg *config.Config) (*sql.DB, error) {
 	db, err := sql.Open("pgx", cfg.SQLDatabase)
 	if err != nil {
 		return nil, err
@@ -53,11 +127,7 @@ func NewStorage(cfg *config.Config) (Storager, error) {
 	if err := goose.Up(db, "migrate"); err != nil {
 		return nil, err
 	}
-
-	return &Storage{
-		cfg: cfg,
-		db:  db,
-	}, nil
+	return db, nil
 }
 
 // CheckUser метод проверят занят ли такой логин в системе
@@ -73,36 +143,74 @@ func (s *Storage) CheckUser(userLogin string) (bool, error) {
 	return true, nil
 }
 
-// RegisterUser метод регистрирует нового пользователя
-func (s *Storage) RegisterUser(userLogin, userPass string) (string, string, string, error) {
-	userID := crypto.RandomID(s.cfg.LenghtUserID)
-	symKey := crypto.NewSymmetricalKey(userID)
+// UserKeyParams метод возвращает параметры вычисления мастер-ключа пользователя без зашифрованного ключа данных.
+func (s *Storage) UserKeyParams(userLogin string) (UserKey, error) {
+	var key UserKey
+	err := s.db.QueryRow("SELECT kdf_salt, kdf_time, kdf_memory, kdf_threads FROM GophKeeper WHERE login = $1", userLogin).Scan(&key.Salt, &key.Time, &key.Memory, &key.Threads)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserKey{}, gkerrors.ErrNoSuchUser
+	}
+	if err != nil {
+		return UserKey{}, err
+	}
+	return key, nil
+}
+
+// RegisterUser метод регистрирует нового пользователя с ключом данных, зашифрованным на клиенте.
+func (s *Storage) RegisterUser(userLogin, userPass string, key UserKey) (string, string, error) {
+	userID, err := crypto.RandomID(s.cfg.LenghtUserID)
+	if err != nil {
+		return "", "", err
+	}
 	timeStamp := time.Now().Format(time.RFC3339)
-	_, err := s.db.Exec("INSERT INTO GophKeeper(user_id, login, password, aeskey, time_stamp) VALUES($1, $2, $3, $4, $5)", userID, userLogin, userPass, symKey, timeStamp)
+	passHash, err := crypto.NewPasswordHash(userPass, crypto.NewPasswordParams(s.cfg))
+	if err != nil {
+		return "", "", err
+	}
+	_, err = s.db.Exec("INSERT INTO GophKeeper(user_id, login, password, time_stamp, kdf_salt, kdf_time, kdf_memory, kdf_threads, wrapped_key) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		userID, userLogin, passHash, timeStamp, key.Salt, key.Time, key.Memory, key.Threads, key.WrappedKey)
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
-	return userID, symKey, timeStamp, nil
+	return userID, timeStamp, nil
 }
 
-// AuthUser метод авторизует пользователя в системе
-func (s *Storage) AuthUser(userLogin, userPass string) (string, error) {
+// AuthUser метод авторизует пользователя в системе и возвращает его зашифрованный ключ данных.
+// Хэш пароля, сохраненный в устаревшем формате или с устаревшими параметрами, пересчитывается после успешной проверки.
+func (s *Storage) AuthUser(userLogin, userPass string) (string, UserKey, error) {
 	var userID, login, pass string
-	err := s.db.QueryRow("SELECT user_id, login, password FROM GophKeeper WHERE login = $1", userLogin).Scan(&userID, &login, &pass)
+	var key UserKey
+	err := s.db.QueryRow("SELECT user_id, login, password, COALESCE(aeskey, ''), kdf_salt, kdf_time, kdf_memory, kdf_threads, wrapped_key FROM GophKeeper WHERE login = $1", userLogin).
+		Scan(&userID, &login, &pass, &key.LegacyKey, &key.Salt, &key.Time, &key.Memory, &key.Threads, &key.WrappedKey)
 	if errors.Is(err, sql.ErrNoRows) {
-		return "", gkerrors.ErrNoSuchUser
+		// Хэш вычисляется и для незарегистрированного логина, чтобы время ответа не раскрывало наличие пользователя
+		crypto.NewPasswordHash(userPass, crypto.NewPasswordParams(s.cfg))
+		return "", UserKey{}, gkerrors.ErrNoSuchUser
 	}
 	if err != nil {
-		return "", err
+		return "", UserKey{}, err
+	}
+	params := crypto.NewPasswordParams(s.cfg)
+	ok, rehash := crypto.CheckPasswd(userPass, pass, params)
+	if !ok {
+		return "", UserKey{}, gkerrors.ErrWrongPassword
 	}
-	if userPass != pass {
-		return "", gkerrors.ErrWrongPassword
+	if rehash {
+		passHash, err := crypto.NewPasswordHash(userPass, params)
+		if err != nil {
+			return "", UserKey{}, err
+		}
+		_, err = s.db.Exec("UPDATE GophKeeper SET password=$1 WHERE user_id=$2 AND password=$3", passHash, userID, pass)
+		if err != nil {
+			log.Error().Err(err).Msg("AuthUser rehash password error")
+		}
 	}
-	return userID, nil
+	return userID, key, nil
 }
 
-// AuthUser метод авторизует пользователя в системе
-func (s *Storage) ChangeUserPassword(userID, oldPass, newPass string) (bool, error) {
+// ChangeUserPassword метод проверяет старый пароль пользователя, сохраняет хэш нового и ключ данных, зашифрованный новым мастер-ключом.
+// Ключ данных, созданный сервером, при этом удаляется.
+func (s *Storage) ChangeUserPassword(userID, oldPass, newPass string, key UserKey) (bool, error) {
 	var pass string
 	err := s.db.QueryRow("SELECT password FROM GophKeeper WHERE user_id = $1", userID).Scan(&pass)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -111,112 +219,351 @@ func (s *Storage) ChangeUserPassword(userID, oldPass, newPass string) (bool, err
 	if err != nil {
 		return false, err
 	}
-	if oldPass != pass {
+	params := crypto.NewPasswordParams(s.cfg)
+	if ok, _ := crypto.CheckPasswd(oldPass, pass, params); !ok {
 		return false, gkerrors.ErrWrongPassword
 	}
-	_, err = s.db.Exec("UPDATE GophKeeper SET password=$1 WHERE user_id=$2", newPass, userID)
+	passHash, err := crypto.NewPasswordHash(newPass, params)
+	if err != nil {
+		return false, err
+	}
+	_, err = s.db.Exec("UPDATE GophKeeper SET password=$1, aeskey=NULL, kdf_salt=$2, kdf_time=$3, kdf_memory=$4, kdf_threads=$5, wrapped_key=$6 WHERE user_id=$7",
+		passHash, key.Salt, key.Time, key.Memory, key.Threads, key.WrappedKey, userID)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+// DeleteUser метод проверяет пароль пользователя и в одной транзакции удаляет его учетную запись, данные, записи,
+// историю версий, блокировку, коды восстановления и сессии, сохраненные в базе данных.
+func (s *Storage) DeleteUser(userID, password string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var login, pass string
+	err = tx.QueryRow("SELECT login, password FROM GophKeeper WHERE user_id = $1 FOR UPDATE", userID).Scan(&login, &pass)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gkerrors.ErrNoSuchUser
+	}
+	if err != nil {
+		return err
+	}
+	if ok, _ := crypto.CheckPasswd(password, pass, crypto.NewPasswordParams(s.cfg)); !ok {
+		return gkerrors.ErrWrongPassword
+	}
+	for _, table := range []string{"GophKeeperLocks", "GophKeeperHistory", "GophKeeperRecords", "GophKeeperRecoveryCodes", "GophKeeperSessions", "GophKeeperAttachments", "GophKeeper"} {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE user_id = $1", userID)
+		if err != nil {
+			log.Error().Err(err).Msgf("DeleteUser deleting from %s error. userID = %s", table, userID)
+			return err
+		}
+	}
+	_, err = tx.Exec("DELETE FROM GophKeeperAuthFailures WHERE auth_key IN ($1, $2)", LoginAuthKey(login), UserAuthKey(userID))
+	if err != nil {
+		log.Error().Err(err).Msgf("DeleteUser deleting auth failures error. userID = %s", userID)
+		return err
+	}
+	return tx.Commit()
+}
+
 // UsersData метод возвращает пользователю его сохраненные данные.
-func (s *Storage) UsersData(userID string) ([]byte, string, string, error) {
-	var key, timeStamp string
+// Если данные пользователя хранятся отдельными записями, они собираются в единый массив данных.
+func (s *Storage) UsersData(userID string) ([]byte, string, int64, error) {
+	var timeStamp string
+	var version int64
 	var fileBZ []byte
-	err := s.db.QueryRow("SELECT aeskey, time_stamp, user_data FROM GophKeeper WHERE user_id = $1", userID).Scan(&key, &timeStamp, &fileBZ)
+	err := s.db.QueryRow("SELECT time_stamp, version, user_data FROM GophKeeper WHERE user_id = $1", userID).Scan(&timeStamp, &version, &fileBZ)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	packed, err := packedRecords(s.db, userID)
 	if err != nil {
-		return nil, "", "", err
+		return nil, "", 0, err
+	}
+	if packed != nil {
+		fileBZ = packed
 	}
 	if len(fileBZ) == 0 {
-		return nil, timeStamp, key, gkerrors.ErrNoUserData
+		return nil, timeStamp, version, gkerrors.ErrNoUserData
 	}
-	return fileBZ, timeStamp, key, nil
+	return fileBZ, timeStamp, version, nil
 }
 
-// UsersTimeStamp метод возвращает пользователю время последнего сохранения данных и наличие текущей блокировки на изменение данных.
-func (s *Storage) UsersTimeStamp(userID string) (string, bool, string, error) {
-	var timeStamp, timeLock string
-	err := s.db.QueryRow("SELECT time_stamp FROM GophKeeper WHERE user_id = $1", userID).Scan(&timeStamp)
+// UsersTimeStamp метод возвращает пользователю время последнего сохранения данных, их версию и наличие текущей блокировки на изменение данных.
+// При наличии действующей блокировки возвращается описание сессии, которая ее установила.
+func (s *Storage) UsersTimeStamp(userID string) (string, int64, bool, LockInfo, error) {
+	var timeStamp string
+	var version int64
+	err := s.db.QueryRow("SELECT time_stamp, version FROM GophKeeper WHERE user_id = $1", userID).Scan(&timeStamp, &version)
 	if err != nil {
-		return "", false, "", err
+		return "", 0, false, LockInfo{}, err
 	}
 
-	err = s.db.QueryRow("SELECT time_lock FROM GophKeeperLocks WHERE user_id = $1", userID).Scan(&timeLock)
+	current, err := userLock(s.db, userID)
 	if err != nil {
-		return timeStamp, false, "", err
+		return timeStamp, version, false, LockInfo{}, err
 	}
-	lock, err := time.Parse(time.RFC3339, timeLock)
+	lock, err := time.Parse(time.RFC3339, current.TimeLock)
 	if err != nil {
-		return timeStamp, false, "", err
+		return timeStamp, version, false, LockInfo{}, err
 	}
 	if lock.After(time.Now()) {
-		return timeStamp, true, timeLock, nil
+		return timeStamp, version, true, current, nil
 	}
-	s.db.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1", userID)
-
-	return timeStamp, false, "", nil
+	s.db.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1 AND time_lock=$2", userID, current.TimeLock)
+	return timeStamp, version, false, LockInfo{}, nil
 }
 
-// UsersDataLock метод устанавливает временную блокировку на изменение данных, кроме текущей сессии пользователя
-func (s *Storage) UsersDataLock(userID, sessionID string) (bool, string) {
-	var timeLock string
-	err := s.db.QueryRow("SELECT time_lock FROM GophKeeperLocks WHERE user_id = $1", userID).Scan(&timeLock)
+// UsersDataLock метод устанавливает временную блокировку на изменение данных, кроме текущей сессии пользователя.
+// Если данные заблокированы другой сессией, возвращается ее блокировка. При takeOver блокировка другой сессии
+// пользователя снимается и устанавливается заново для текущей сессии.
+// Проверка и установка блокировки выполняются одной транзакцией под блокировкой строки пользователя.
+func (s *Storage) UsersDataLock(userID string, owner LockInfo, takeOver bool) (bool, LockInfo) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		log.Error().Err(err).Msgf("UsersDataLock begin transaction error. userID = %s", userID)
+		return false, LockInfo{}
+	}
+	defer tx.Rollback()
+	_, err = lockUserRow(tx, userID)
+	if err != nil {
+		log.Error().Err(err).Msgf("UsersDataLock locking user error. userID = %s", userID)
+		return false, LockInfo{}
+	}
+	current, err := userLock(tx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Error().Err(err).Msgf("UsersDataLock reading lock error. userID = %s", userID)
+		return false, LockInfo{}
+	}
 	if err == nil {
-		lock, err := time.Parse(time.RFC3339, timeLock)
+		lock, err := time.Parse(time.RFC3339, current.TimeLock)
 		if err != nil {
-			log.Error().Err(err).Msgf("UsersDataLock parsing timeLock error. userID = %s, timeLock = %s", userID, timeLock)
+			log.Error().Err(err).Msgf("UsersDataLock parsing timeLock error. userID = %s, timeLock = %s", userID, current.TimeLock)
 		} else if lock.After(time.Now()) {
-			return true, timeLock
+			if current.SessionID == owner.SessionID {
+				return true, current
+			}
+			if !takeOver {
+				return false, current
+			}
+			log.Info().Msgf("UsersDataLock lock taken over. userID = %s, device = %s", userID, current.DeviceName)
 		}
-		_, err = s.db.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1", userID)
+		_, err = tx.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1", userID)
 		if err != nil {
 			log.Error().Err(err).Msg("UsersDataLock deleting lock from DB error")
+			return false, LockInfo{}
 		}
 	}
 
-	timeLock = time.Now().Add(time.Minute * time.Duration(s.cfg.LockingTime)).Format(time.RFC3339)
-	_, err = s.db.Exec("INSERT INTO GophKeeperLocks(user_id, sessionID, time_lock) VALUES($1, $2, $3)", userID, sessionID, timeLock)
+	now := time.Now()
+	owner.TimeAcquired = now.Format(time.RFC3339)
+	owner.TimeLock = now.Add(time.Minute * time.Duration(s.cfg.LockingTime)).Format(time.RFC3339)
+	_, err = tx.Exec("INSERT INTO GophKeeperLocks(user_id, sessionID, time_lock, device_name, client_version, time_acquired) VALUES($1, $2, $3, $4, $5, $6)",
+		userID, owner.SessionID, owner.TimeLock, owner.DeviceName, owner.ClientVersion, owner.TimeAcquired)
 	if err != nil {
 		log.Error().Err(err).Msgf("UsersDataLock inserting DB error. userID = %s", userID)
-		return false, ""
+		return false, LockInfo{}
 	}
-	return true, timeLock
+	err = tx.Commit()
+	if err != nil {
+		log.Error().Err(err).Msgf("UsersDataLock commit error. userID = %s", userID)
+		return false, LockInfo{}
+	}
+	return true, owner
 }
 
-// UpdateUserData метод обновляет данные пользователя в хранилище
-func (s *Storage) UpdateUserData(userID, sessionID, userTimeStamp string, userData []byte) (bool, string, error) {
-	var lockedSessionID, timeLock string
-	err := s.db.QueryRow("SELECT sessionID, time_lock FROM GophKeeperLocks WHERE user_id = $1", userID).Scan(&lockedSessionID, &timeLock)
-	if err == nil {
-		lock, err := time.Parse(time.RFC3339, lockedSessionID)
-		if err != nil {
-			log.Error().Err(err).Msgf("UpdateUserData parsing timeLock error. userID = %s, timeLock = %s", userID, timeLock)
-		} else if lock.After(time.Now()) && sessionID != lockedSessionID {
-			return true, timeLock, gkerrors.ErrLocked
-		}
+// rowQuerier интерфейс выполнения запроса одной строки, общий для соединения с БД и транзакции.
+type rowQuerier interface {
+	QueryRow(string, ...any) *sql.Row
+}
+
+// userLock функция возвращает текущую блокировку данных пользователя.
+func userLock(q rowQuerier, userID string) (LockInfo, error) {
+	var lock LockInfo
+	err := q.QueryRow("SELECT sessionID, device_name, client_version, time_acquired, time_lock FROM GophKeeperLocks WHERE user_id = $1", userID).
+		Scan(&lock.SessionID, &lock.DeviceName, &lock.ClientVersion, &lock.TimeAcquired, &lock.TimeLock)
+	return lock, err
+}
+
+// lockUserRow функция блокирует строку пользователя до конца транзакции и возвращает текущую версию его данных.
+// Все изменения блокировки и данных пользователя начинаются с этого запроса, поэтому запросы разных сессий выполняются последовательно.
+func lockUserRow(tx *sql.Tx, userID string) (int64, error) {
+	var version int64
+	err := tx.QueryRow("SELECT version FROM GophKeeper WHERE user_id = $1 FOR UPDATE", userID).Scan(&version)
+	return version, err
+}
+
+// ReleaseLock метод снимает блокировку на изменение данных, если она установлена текущей сессией пользователя.
+// Отсутствие блокировки ошибкой не считается.
+func (s *Storage) ReleaseLock(userID, sessionID string) error {
+	_, err := s.db.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1 AND sessionID=$2", userID, sessionID)
+	if err != nil {
+		log.Error().Err(err).Msgf("ReleaseLock deleting lock from DB error. userID = %s", userID)
+		return err
 	}
+	return nil
+}
 
-	var timeStamp string
-	err = s.db.QueryRow("SELECT time_stamp FROM GophKeeper WHERE user_id = $1", userID).Scan(&timeStamp)
+// RenewLock метод продлевает действующую блокировку на изменение данных, установленную текущей сессией пользователя, на LockingTime минут.
+// Блокировка проверяется и продлевается под блокировкой строки пользователя, как и при ее установке и перехвате,
+// поэтому продление не пересекается с перехватом блокировки другой сессией.
+// Если блокировка снята, истекла или перехвачена другой сессией, возвращается ошибка ErrLocked.
+func (s *Storage) RenewLock(userID, sessionID string) (string, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return false, "", err
+		log.Error().Err(err).Msgf("RenewLock begin transaction error. userID = %s", userID)
+		return "", err
+	}
+	defer tx.Rollback()
+	_, err = lockUserRow(tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", gkerrors.ErrLocked
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("RenewLock locking user error. userID = %s", userID)
+		return "", err
+	}
+	var current string
+	err = tx.QueryRow("SELECT time_lock FROM GophKeeperLocks WHERE user_id=$1 AND sessionID=$2 FOR UPDATE", userID, sessionID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", gkerrors.ErrLocked
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("RenewLock reading lock error. userID = %s", userID)
+		return "", err
 	}
-	if timeStamp != userTimeStamp {
-		return false, "", gkerrors.ErrTimeNotEqual
+	lock, err := time.Parse(time.RFC3339, current)
+	if err != nil || !lock.After(time.Now()) {
+		return "", gkerrors.ErrLocked
+	}
+	timeLock := time.Now().Add(time.Minute * time.Duration(s.cfg.LockingTime)).Format(time.RFC3339)
+	_, err = tx.Exec("UPDATE GophKeeperLocks SET time_lock=$3 WHERE user_id=$1 AND sessionID=$2", userID, sessionID, timeLock)
+	if err != nil {
+		log.Error().Err(err).Msgf("RenewLock updating DB error. userID = %s", userID)
+		return "", err
+	}
+	return timeLock, tx.Commit()
+}
+
+// UpdateUserData метод обновляет данные пользователя в хранилище, если их версия на сервере не изменилась.
+// Версия данных увеличивается тем же запросом, что и сохраняет данные.
+// Упакованный список записей разбирается и сохраняется в таблицу отдельных записей.
+func (s *Storage) UpdateUserData(userID, sessionID string, userVersion int64, userData []byte) (bool, string, int64, error) {
+	timeStamp, version, err := s.saveUserData(userID, sessionID, userVersion, userData, nil)
+	if errors.Is(err, gkerrors.ErrLocked) {
+		return true, timeStamp, 0, err
+	}
+	if err != nil {
+		return false, "", 0, err
+	}
+	return true, timeStamp, version, nil
+}
+
+// lockedByOther функция проверяет наличие действующей блокировки данных пользователя другой сессией и возвращает время ее окончания.
+func lockedByOther(tx *sql.Tx, userID, sessionID string) (bool, string, error) {
+	current, err := userLock(tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, "", nil
 	}
-	timeStamp = time.Now().Format(time.RFC3339)
-	_, err = s.db.Exec("UPDATE GophKeeper SET time_stamp=$1, user_data=$2 WHERE user_id=$3", timeStamp, userData, userID)
 	if err != nil {
 		return false, "", err
 	}
-	log.Debug().Msgf("Запись об изменениях в БД обновлена")
-	_, err = s.db.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1", userID)
+	if current.SessionID == sessionID {
+		return false, "", nil
+	}
+	lock, err := time.Parse(time.RFC3339, current.TimeLock)
+	if err != nil {
+		log.Error().Err(err).Msgf("UpdateUserData parsing timeLock error. userID = %s, timeLock = %s", userID, current.TimeLock)
+		return false, "", nil
+	}
+	if lock.After(time.Now()) {
+		return true, current.TimeLock, nil
+	}
+	return false, "", nil
+}
+
+// saveUserData метод сохраняет данные пользователя одной транзакцией, если они не заблокированы другой сессией
+// и их версия на сервере не изменилась. Проверка блокировки, проверка версии и сохранение выполняются под блокировкой
+// строки пользователя, поэтому не пересекаются с запросами других сессий.
+// Предыдущая версия данных сохраняется в истории, после сохранения блокировка данных снимается.
+// При блокировке данных другой сессией возвращается время окончания блокировки и ошибка ErrLocked.
+// Функция after, если задана, выполняется в той же транзакции после сохранения данных.
+func (s *Storage) saveUserData(userID, sessionID string, userVersion int64, userData []byte, after func(*sql.Tx) error) (string, int64, error) {
+	timeStamp := time.Now().Format(time.RFC3339)
+	recs, packed, err := records.Unpack(userData)
+	if err != nil {
+		return "", 0, err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", 0, err
+	}
+	defer tx.Rollback()
+	version, err := lockUserRow(tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", 0, gkerrors.ErrDataVersionNotEqual
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	locked, timeLock, err := lockedByOther(tx, userID, sessionID)
+	if err != nil {
+		return "", 0, err
+	}
+	if locked {
+		return timeLock, 0, gkerrors.ErrLocked
+	}
+	if version != userVersion {
+		return "", 0, gkerrors.ErrDataVersionNotEqual
+	}
+	err = saveHistory(tx, userID)
 	if err != nil {
-		log.Error().Err(err).Msgf("UsersDataLock deleting lock from DB error. userID = %s", userID)
+		return "", 0, err
 	}
-	return true, timeStamp, nil
+	var revision int64
+	if packed {
+		err = tx.QueryRow("UPDATE GophKeeper SET version=version+1, revision=revision+1, time_stamp=$1, user_data=NULL WHERE user_id=$2 AND version=$3 RETURNING version, revision",
+			timeStamp, userID, userVersion).Scan(&version, &revision)
+	} else {
+		err = tx.QueryRow("UPDATE GophKeeper SET version=version+1, revision=revision+1, time_stamp=$1, user_data=$2 WHERE user_id=$3 AND version=$4 RETURNING version, revision",
+			timeStamp, userData, userID, userVersion).Scan(&version, &revision)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", 0, gkerrors.ErrDataVersionNotEqual
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	// Единый зашифрованный массив сервер не может разобрать на записи, поэтому при его сохранении,
+	// в том числе при восстановлении такой версии из истории, все записи помечаются удаленными:
+	// иначе UsersData продолжал бы собирать данные из прежних записей
+	err = replaceRecords(tx, userID, timeStamp, revision, recs)
+	if err != nil {
+		return "", 0, err
+	}
+	err = trimHistory(tx, userID, s.cfg.HistoryDepth)
+	if err != nil {
+		return "", 0, err
+	}
+	if after != nil {
+		err = after(tx)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	_, err = tx.Exec("DELETE FROM GophKeeperLocks WHERE user_id=$1", userID)
+	if err != nil {
+		return "", 0, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return "", 0, err
+	}
+	log.Debug().Msgf("Запись об изменениях в БД обновлена")
+	return timeStamp, version, nil
 }
 
 // CloseDB метод закрывает соединение с БД SQL
@@ -0,0 +1,494 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/records"
+	"gophkeeper/internal/server/config"
+	"gophkeeper/internal/server/crypto"
+)
+
+// workers количество горутин, одновременно обращающихся к данным одного пользователя.
+const workers = 32
+
+// newTestStorage функция подключается к локальной базе данных из переменной окружения GOPHKEEPER_TEST_DATABASE
+// и регистрирует отдельного пользователя для теста. Без базы данных тест пропускается.
+func newTestStorage(t *testing.T) (*Storage, string) {
+	dsn := os.Getenv("GOPHKEEPER_TEST_DATABASE")
+	if dsn == "" {
+		t.Skip("GOPHKEEPER_TEST_DATABASE is not set")
+	}
+	cfg := &config.Config{
+		SQLDatabase:     dsn,
+		LenghtUserID:    12,
+		LockingTime:     15,
+		HistoryDepth:    10,
+		PasswordTime:    1,
+		PasswordMemory:  1024,
+		PasswordThreads: 1,
+		TOTPKey:         "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+	}
+	strg, err := NewStorage(cfg)
+	require.NoError(t, err)
+	s := strg.(*Storage)
+	login, err := crypto.RandomID(16)
+	require.NoError(t, err)
+	userID, _, err := s.RegisterUser(login, "password", UserKey{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		for _, table := range []string{"GophKeeperLocks", "GophKeeperHistory", "GophKeeperRecords", "GophKeeperAttachments", "GophKeeper"} {
+			s.db.Exec("DELETE FROM "+table+" WHERE user_id = $1", userID)
+		}
+		s.CloseDB()
+	})
+	return s, userID
+}
+
+func TestUsersDataLockConcurrent(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Из всех сессий, одновременно запросивших блокировку, ее получает только одна
+	var wg sync.WaitGroup
+	results := make([]bool, workers)
+	owners := make([]LockInfo, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], owners[i] = s.UsersDataLock(userID, LockInfo{SessionID: fmt.Sprintf("session%d", i)}, false)
+		}(i)
+	}
+	wg.Wait()
+
+	winner := ""
+	for i := 0; i < workers; i++ {
+		if results[i] {
+			require.Empty(t, winner, "lock acquired by two sessions")
+			winner = owners[i].SessionID
+		}
+	}
+	require.NotEmpty(t, winner)
+	for i := 0; i < workers; i++ {
+		require.Equal(t, winner, owners[i].SessionID)
+	}
+	_, _, locked, lock, err := s.UsersTimeStamp(userID)
+	require.NoError(t, err)
+	require.True(t, locked)
+	require.Equal(t, winner, lock.SessionID)
+}
+
+func TestUpdateUserDataConcurrent(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Из одновременных сохранений одной версии данных проходит только одно
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, _, errs[i] = s.UpdateUserData(userID, fmt.Sprintf("session%d", i), 0, []byte(fmt.Sprintf("data%d", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	saved := 0
+	for _, err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		require.ErrorIs(t, err, gkerrors.ErrDataVersionNotEqual)
+	}
+	require.Equal(t, 1, saved)
+	_, version, _, _, err := s.UsersTimeStamp(userID)
+	require.True(t, err == nil || errors.Is(err, sql.ErrNoRows))
+	require.Equal(t, int64(1), version)
+
+	// Данные, заблокированные другой сессией, не сохраняются ни одной из остальных сессий
+	locked, _ := s.UsersDataLock(userID, LockInfo{SessionID: "owner"}, false)
+	require.True(t, locked)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, _, errs[i] = s.UpdateUserData(userID, fmt.Sprintf("session%d", i), 1, []byte("data"))
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.ErrorIs(t, err, gkerrors.ErrLocked)
+	}
+	_, _, version, err = s.UpdateUserData(userID, "owner", 1, []byte("owner data"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+}
+
+func TestLockAndUpdateConcurrent(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Сессии по очереди перехватывают освобожденную блокировку и сохраняют данные.
+	// Сохранение под собственной блокировкой всегда проходит, а версия растет ровно на число сохранений.
+	const rounds = 5
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	saved := 0
+	errs := make(chan error, workers*rounds)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sessionID := fmt.Sprintf("session%d", i)
+			for round := 0; round < rounds; round++ {
+				locked, lock := s.UsersDataLock(userID, LockInfo{SessionID: sessionID}, false)
+				if !locked || lock.SessionID != sessionID {
+					continue
+				}
+				_, version, _, _, err := s.UsersTimeStamp(userID)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					errs <- err
+					return
+				}
+				_, _, _, err = s.UpdateUserData(userID, sessionID, version, []byte(fmt.Sprintf("%s round %d", sessionID, round)))
+				if err != nil {
+					errs <- err
+					return
+				}
+				mu.Lock()
+				saved++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Greater(t, saved, 0)
+	_, version, _, _, err := s.UsersTimeStamp(userID)
+	require.True(t, err == nil || errors.Is(err, sql.ErrNoRows))
+	require.Equal(t, int64(saved), version)
+}
+
+func TestRenewLock(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	locked, _ := s.UsersDataLock(userID, LockInfo{SessionID: "session"}, false)
+	require.True(t, locked)
+	_, err := s.RenewLock(userID, "session")
+	require.NoError(t, err)
+	// Блокировку другой сессии продлить нельзя
+	_, err = s.RenewLock(userID, "other")
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+
+	// Истекшая блокировка не продлевается, ее могла перехватить другая сессия
+	_, err = s.db.Exec("UPDATE GophKeeperLocks SET time_lock = $2 WHERE user_id = $1", userID, time.Now().Add(-time.Minute).Format(time.RFC3339))
+	require.NoError(t, err)
+	_, err = s.RenewLock(userID, "session")
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+}
+
+func TestRecordsLocked(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	rec, err := s.CreateRecord(userID, "session", records.Record{ID: "record1", Type: "text", Data: []byte("first")})
+	require.NoError(t, err)
+	locked, _ := s.UsersDataLock(userID, LockInfo{SessionID: "other"}, false)
+	require.True(t, locked)
+
+	// Записи, заблокированные другой сессией, не изменяются ни по одной, ни синхронизацией
+	_, err = s.CreateRecord(userID, "session", records.Record{ID: "record2", Type: "text"})
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+	_, err = s.UpdateRecord(userID, "session", rec)
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+	require.ErrorIs(t, s.DeleteRecord(userID, "session", rec.ID, rec.Version), gkerrors.ErrLocked)
+	_, _, _, err = s.SyncRecords(userID, "session", 0, []records.Record{{ID: "record2", Type: "text"}})
+	require.ErrorIs(t, err, gkerrors.ErrLocked)
+	// получение изменений без отправки своих блокировкой не ограничивается
+	_, changed, _, err := s.SyncRecords(userID, "session", 0, nil)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+
+	// Сессия, установившая блокировку, изменяет записи
+	rec.Data = []byte("second")
+	_, err = s.UpdateRecord(userID, "other", rec)
+	require.NoError(t, err)
+}
+
+func TestAttachmentsQuota(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Одновременные загрузки не превышают квоту
+	const quota = 1000
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := s.ReserveAttachment(userID, fmt.Sprintf("attachment%d", i), 100, quota)
+			if err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, quota/100, reserved)
+	used, err := s.AttachmentsUsage(userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(quota), used)
+
+	// После завершения загрузки в квоте учитывается фактический объем, резерв сохраненного вложения не увеличивается
+	require.NoError(t, s.CommitAttachment(userID, "attachment0", 50))
+	require.ErrorIs(t, s.ReserveAttachment(userID, "attachment0", 10, quota), gkerrors.ErrNoSuchAttachment)
+	used, err = s.AttachmentsUsage(userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(quota-50), used)
+
+	// Незавершенные загрузки считаются прерванными по времени начала
+	stale, err := s.StaleAttachments(userID, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, stale, quota/100-1)
+	require.NotContains(t, stale, "attachment0")
+	for _, id := range stale {
+		require.NoError(t, s.DropAttachment(userID, id))
+	}
+	require.ErrorIs(t, s.DropAttachment(userID, stale[0]), gkerrors.ErrNoSuchAttachment)
+}
+
+func TestAttachmentsHistory(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	require.NoError(t, s.ReserveAttachment(userID, "attachment1", 100, 1000))
+	require.NoError(t, s.CommitAttachment(userID, "attachment1", 100))
+	_, _, _, err := s.UpdateUserData(userID, "session", 0, []byte("data with attachment"))
+	require.NoError(t, err)
+	_, _, _, err = s.UpdateUserData(userID, "session", 1, []byte("data without attachment"))
+	require.NoError(t, err)
+
+	// Освобожденное вложение хранится, пока в истории есть версия данных, которая может на него ссылаться
+	require.NoError(t, s.ReleaseAttachment(userID, "attachment1"))
+	require.ErrorIs(t, s.ReleaseAttachment(userID, "attachment1"), gkerrors.ErrNoSuchAttachment)
+	expired, err := s.ExpiredAttachments(userID)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	// Восстановление версии из истории снова делает вложение используемым
+	_, version, reclaimed, err := s.RestoreVersion(userID, "session", 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), version)
+	require.Equal(t, []string{"attachment1"}, reclaimed)
+	require.NoError(t, s.ReleaseAttachment(userID, "attachment1"))
+
+	// После удаления версий из истории вложение можно удалить
+	_, err = s.db.Exec("DELETE FROM GophKeeperHistory WHERE user_id = $1", userID)
+	require.NoError(t, err)
+	expired, err = s.ExpiredAttachments(userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"attachment1"}, expired)
+}
+
+func TestRecordsHistory(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Каждое изменение отдельной записи сохраняет предыдущую версию данных в истории
+	rec, err := s.CreateRecord(userID, "session", records.Record{ID: "record1", Type: "text", Data: []byte("first")})
+	require.NoError(t, err)
+	rec.Data = []byte("second")
+	rec, err = s.UpdateRecord(userID, "session", rec)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteRecord(userID, "session", rec.ID, rec.Version))
+	_, _, conflicts, err := s.SyncRecords(userID, "session", 0, []records.Record{{ID: "record2", Type: "text", Data: []byte("third")}})
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+	history, err := s.ListHistory(userID)
+	require.NoError(t, err)
+	// версия 3 без записей в истории не сохраняется
+	require.Len(t, history, 2)
+	require.Equal(t, []int64{2, 1}, []int64{history[0].Version, history[1].Version})
+
+	// Восстановление версии возвращает запись, измененную и удаленную по одной
+	_, _, _, err = s.RestoreVersion(userID, "session", 2, 4)
+	require.NoError(t, err)
+	restored, err := s.GetRecord(userID, "record1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), restored.Data)
+	_, err = s.GetRecord(userID, "record2")
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchRecord)
+}
+
+func TestRestoreLegacyVersion(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	// Версия, сохраненная единым зашифрованным массивом, заменяет записи, сохраненные после нее
+	_, _, _, err := s.UpdateUserData(userID, "session", 0, []byte("legacy"))
+	require.NoError(t, err)
+	_, err = s.CreateRecord(userID, "session", records.Record{ID: "record1", Type: "text", Data: []byte("first")})
+	require.NoError(t, err)
+	_, _, version, err := s.UsersData(userID)
+	require.NoError(t, err)
+	_, restoredVersion, _, err := s.RestoreVersion(userID, "session", 1, version)
+	require.NoError(t, err)
+	userData, _, currentVersion, err := s.UsersData(userID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("legacy"), userData)
+	require.Equal(t, restoredVersion, currentVersion)
+
+	// Удаление записей передается клиентам, синхронизирующим отдельные записи
+	_, err = s.GetRecord(userID, "record1")
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchRecord)
+	_, changed, _, err := s.SyncRecords(userID, "session", 1, nil)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	require.True(t, changed[0].Deleted)
+}
+
+func TestAuthDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		attempts int
+		want     time.Duration
+	}{
+		{name: "Первая неудачная попытка", failures: 1, attempts: 10, want: time.Second},
+		{name: "Задержка удваивается", failures: 3, attempts: 10, want: 4 * time.Second},
+		{name: "Задержка не превышает время блокировки", failures: 20, attempts: 100, want: 15 * time.Minute},
+		{name: "Попытки исчерпаны", failures: 10, attempts: 10, want: 15 * time.Minute},
+		{name: "Попытки сверх порога", failures: 25, attempts: 10, want: 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, authDelay(tt.failures, tt.attempts, time.Second, 15*time.Minute))
+		})
+	}
+}
+
+func TestAuthAttemptConcurrent(t *testing.T) {
+	s, userID := newTestStorage(t)
+	s.cfg.LoginBackoff, s.cfg.LoginLockout = 0, 15
+	const attempts = 5
+	authKey := UserAuthKey(userID)
+	defer s.db.Exec("DELETE FROM GophKeeperAuthFailures WHERE auth_key = $1", authKey)
+
+	// Из одновременных попыток проверку проходят только attempts, остальные отклоняются блокировкой,
+	// хотя ни одна из прошедших попыток еще не завершилась
+	var wg sync.WaitGroup
+	results := make([]bool, workers)
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, results[i], errs[i] = s.AuthAttempt(authKey, attempts)
+		}(i)
+	}
+	wg.Wait()
+	allowed := 0
+	for i := 0; i < workers; i++ {
+		require.NoError(t, errs[i])
+		if results[i] {
+			allowed++
+		}
+	}
+	require.Equal(t, attempts, allowed)
+	blocked, ok, err := s.AuthAttempt(authKey, attempts)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.True(t, blocked.After(time.Now().Add(14*time.Minute)))
+
+	// Отмена удачной попытки не снимает блокировку, установленную следующими попытками
+	require.NoError(t, s.AuthSucceeded(authKey))
+	first, ok, err := s.AuthAttempt(authKey, attempts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	s.cfg.LoginBackoff = 60
+	second, ok, err := s.AuthAttempt(authKey, attempts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, s.AuthReleased(authKey, first))
+	var failures int
+	var blockedUntil time.Time
+	require.NoError(t, s.db.QueryRow("SELECT failures, blocked_until FROM GophKeeperAuthFailures WHERE auth_key = $1", authKey).Scan(&failures, &blockedUntil))
+	require.Equal(t, 1, failures)
+	require.True(t, blockedUntil.Equal(second))
+}
+
+func TestDeleteUser(t *testing.T) {
+	s, userID := newTestStorage(t)
+
+	_, _, _, err := s.UpdateUserData(userID, "session", 0, []byte("data"))
+	require.NoError(t, err)
+	locked, _ := s.UsersDataLock(userID, LockInfo{SessionID: "session"}, false)
+	require.True(t, locked)
+	var login string
+	require.NoError(t, s.db.QueryRow("SELECT login FROM GophKeeper WHERE user_id = $1", userID).Scan(&login))
+	authKeys := []string{LoginAuthKey(login), UserAuthKey(userID)}
+	for _, key := range authKeys {
+		_, _, err = s.AuthAttempt(key, 1)
+		require.NoError(t, err)
+	}
+
+	// Неверный пароль не удаляет данные пользователя
+	err = s.DeleteUser(userID, "wrong")
+	require.ErrorIs(t, err, gkerrors.ErrWrongPassword)
+	_, _, version, err := s.UsersData(userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), version)
+
+	err = s.DeleteUser(userID, "password")
+	require.NoError(t, err)
+	for _, table := range []string{"GophKeeperLocks", "GophKeeperHistory", "GophKeeperRecords", "GophKeeper"} {
+		var count int
+		err = s.db.QueryRow("SELECT count(*) FROM "+table+" WHERE user_id = $1", userID).Scan(&count)
+		require.NoError(t, err)
+		require.Zero(t, count, table)
+	}
+	// Счетчики неудачных попыток входа удаляются вместе с учетной записью
+	for _, key := range authKeys {
+		var count int
+		err = s.db.QueryRow("SELECT count(*) FROM GophKeeperAuthFailures WHERE auth_key = $1", key).Scan(&count)
+		require.NoError(t, err)
+		require.Zero(t, count, key)
+	}
+	err = s.DeleteUser(userID, "password")
+	require.ErrorIs(t, err, gkerrors.ErrNoSuchUser)
+}
+
+func TestTOTPSecretEncrypted(t *testing.T) {
+	// Без корректного мастер-ключа хранилище не создается, секреты не сохраняются открытым текстом
+	_, err := NewStorage(&config.Config{TOTPKey: "0102"})
+	require.ErrorIs(t, err, gkerrors.ErrTOTPKeyIncorrect)
+
+	s, userID := newTestStorage(t)
+	secret := []byte("12345678901234567890")
+	require.NoError(t, s.SetTOTP(userID, secret, []string{"hash"}))
+	var stored []byte
+	require.NoError(t, s.db.QueryRow("SELECT totp_secret FROM GophKeeper WHERE user_id = $1", userID).Scan(&stored))
+	require.NotContains(t, string(stored), string(secret))
+	info, err := s.UserTOTP(userID)
+	require.NoError(t, err)
+	require.Equal(t, secret, info.Secret)
+
+	// Секрет привязан к пользователю и не расшифровывается в строке другого пользователя
+	other, _, err := s.RegisterUser(userID+"other", "password", UserKey{})
+	require.NoError(t, err)
+	defer s.db.Exec("DELETE FROM GophKeeper WHERE user_id = $1", other)
+	_, err = s.db.Exec("UPDATE GophKeeper SET totp_secret = $1 WHERE user_id = $2", stored, other)
+	require.NoError(t, err)
+	_, err = s.UserTOTP(other)
+	require.Error(t, err)
+}
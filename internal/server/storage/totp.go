@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	gkerrors "gophkeeper/internal/errors"
+	"gophkeeper/internal/handshake"
+)
+
+// TOTPInfo структура описывает настройки двухфакторной аутентификации пользователя.
+type TOTPInfo struct {
+	Login    string //Логин пользователя, используется в ссылке для приложения-аутентификатора
+	Secret   []byte //Секрет TOTP, пустой - секрет не выдавался
+	Enabled  bool   //Двухфакторная аутентификация включена после подтверждения кодом
+	LastStep int64  //Номер интервала последнего принятого кода, коды этого и предыдущих интервалов повторно не принимаются
+}
+
+// UserTOTP метод возвращает настройки двухфакторной аутентификации пользователя и расшифровывает секрет TOTP.
+func (s *Storage) UserTOTP(userID string) (TOTPInfo, error) {
+	var info TOTPInfo
+	var sealed []byte
+	err := s.db.QueryRow("SELECT login, totp_secret, totp_enabled, totp_last_step FROM GophKeeper WHERE user_id = $1", userID).
+		Scan(&info.Login, &sealed, &info.Enabled, &info.LastStep)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TOTPInfo{}, gkerrors.ErrNoSuchUser
+	}
+	if err != nil || len(sealed) == 0 {
+		return info, err
+	}
+	info.Secret, err = handshake.Open(s.totpKey, sealed, []byte(userID))
+	if err != nil {
+		return TOTPInfo{}, err
+	}
+	return info, nil
+}
+
+// SetTOTP метод сохраняет новый секрет TOTP и хэши кодов восстановления до подтверждения кодом.
+// Секрет зашифровывается мастер-ключом сервера, идентификатор пользователя используется как метка.
+// Если двухфакторная аутентификация уже включена, секрет не заменяется, чтобы ее нельзя было обойти без текущего кода.
+func (s *Storage) SetTOTP(userID string, secret []byte, recoveryHashes []string) error {
+	sealed, err := handshake.Seal(s.totpKey, secret, []byte(userID))
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var enabled bool
+	err = tx.QueryRow("SELECT totp_enabled FROM GophKeeper WHERE user_id = $1 FOR UPDATE", userID).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gkerrors.ErrNoSuchUser
+	}
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return gkerrors.ErrTOTPEnabled
+	}
+	_, err = tx.Exec("UPDATE GophKeeper SET totp_secret=$1, totp_last_step=0 WHERE user_id=$2", sealed, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM GophKeeperRecoveryCodes WHERE user_id=$1", userID)
+	if err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		_, err = tx.Exec("INSERT INTO GophKeeperRecoveryCodes(user_id, code_hash) VALUES($1, $2)", userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// EnableTOTP метод включает двухфакторную аутентификацию после подтверждения кодом из интервала step.
+func (s *Storage) EnableTOTP(userID string, step int64) error {
+	res, err := s.db.Exec("UPDATE GophKeeper SET totp_enabled=true, totp_last_step=$1 WHERE user_id=$2 AND totp_secret IS NOT NULL AND NOT totp_enabled", step, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return gkerrors.ErrTOTPNotEnabled
+	}
+	return nil
+}
+
+// UseTOTPStep метод отмечает код из интервала step использованным.
+// Возвращает false, если код этого или более позднего интервала уже был принят.
+func (s *Storage) UseTOTPStep(userID string, step int64) (bool, error) {
+	res, err := s.db.Exec("UPDATE GophKeeper SET totp_last_step=$1 WHERE user_id=$2 AND totp_last_step < $1", step, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// UseRecoveryCode метод удаляет код восстановления с хэшем codeHash.
+// Возвращает false, если такого кода у пользователя нет или он уже использован.
+func (s *Storage) UseRecoveryCode(userID, codeHash string) (bool, error) {
+	res, err := s.db.Exec("DELETE FROM GophKeeperRecoveryCodes WHERE user_id=$1 AND code_hash=$2", userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// DisableTOTP метод отключает двухфакторную аутентификацию, удаляет секрет и коды восстановления.
+func (s *Storage) DisableTOTP(userID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec("UPDATE GophKeeper SET totp_secret=NULL, totp_enabled=false, totp_last_step=0 WHERE user_id=$1", userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM GophKeeperRecoveryCodes WHERE user_id=$1", userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
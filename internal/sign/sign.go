@@ -0,0 +1,29 @@
+// Модуль предназначен для вычисления хэша запроса, который подписывает клиент и проверяет сервер.
+package sign
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestDigest функция вычисляет хэш SHA-256 от имени метода, идентификатора сессии, времени отправки, номера запроса
+// и детерминированной сериализации сообщения запроса.
+func RequestDigest(method, sessionID string, timestamp int64, nonce uint64, req proto.Message) ([]byte, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var numbers [16]byte
+	binary.BigEndian.PutUint64(numbers[:8], uint64(timestamp))
+	binary.BigEndian.PutUint64(numbers[8:], nonce)
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(sessionID))
+	hash.Write([]byte{0})
+	hash.Write(numbers[:])
+	hash.Write(body)
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,36 @@
+package sign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "gophkeeper/api/grpc/proto"
+)
+
+func TestRequestDigest(t *testing.T) {
+	req := &pb.UserDataRequest{SessionID: "session"}
+	digest, err := RequestDigest("/grpc.GophKeeper/UserData", "session", 1000, 1, req)
+	require.NoError(t, err)
+	require.Len(t, digest, 32)
+
+	same, err := RequestDigest("/grpc.GophKeeper/UserData", "session", 1000, 1, &pb.UserDataRequest{SessionID: "session"})
+	require.NoError(t, err)
+	require.Equal(t, digest, same)
+
+	other, err := RequestDigest("/grpc.GophKeeper/DeleteRecord", "session", 1000, 1, req)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, other)
+
+	other, err = RequestDigest("/grpc.GophKeeper/UserData", "session", 1000, 2, req)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, other)
+
+	other, err = RequestDigest("/grpc.GophKeeper/UserData", "session", 1001, 1, req)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, other)
+
+	other, err = RequestDigest("/grpc.GophKeeper/UserData", "session", 1000, 1, &pb.UserDataRequest{SessionID: "other"})
+	require.NoError(t, err)
+	require.NotEqual(t, digest, other)
+}